@@ -3,11 +3,13 @@ package account
 import (
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/ipfs/go-cid"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	init_ "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
 	"github.com/filecoin-project/specs-actors/v3/actors/runtime"
 )
 
@@ -17,6 +19,11 @@ func (a Actor) Exports() []interface{} {
 	return []interface{}{
 		1: a.Constructor,
 		2: a.PubkeyAddress,
+		3: a.RotateKey,
+		4: a.SetGuardians,
+		5: a.ApproveRecovery,
+		6: a.CancelRecovery,
+		7: a.CompleteRecovery,
 	}
 }
 
@@ -30,19 +37,11 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
-type State struct {
-	Address addr.Address
-}
-
 func (a Actor) Constructor(rt runtime.Runtime, address *addr.Address) *abi.EmptyValue {
 	// Account actors are created implicitly by sending a message to a pubkey-style address.
 	// This constructor is not invoked by the InitActor, but by the system.
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
-	switch address.Protocol() {
-	case addr.SECP256K1:
-	case addr.BLS:
-		break // ok
-	default:
+	if !isKeyAddress(*address) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "address must use BLS or SECP protocol, got %v", address.Protocol())
 	}
 	st := State{Address: *address}
@@ -57,3 +56,159 @@ func (a Actor) PubkeyAddress(rt runtime.Runtime, _ *abi.EmptyValue) *addr.Addres
 	rt.StateReadonly(&st)
 	return &st.Address
 }
+
+type RotateKeyParams struct {
+	NewAddress addr.Address
+}
+
+// Replaces the key signing for this account, authorized by the current key.
+// The new key must use the same protocol as the current one, and the current key's address no longer
+// resolves to this account. Any recovery in progress is cancelled.
+func (a Actor) RotateKey(rt runtime.Runtime, params *RotateKeyParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+	if !isKeyAddress(params.NewAddress) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "new address must use BLS or SECP protocol, got %v", params.NewAddress.Protocol())
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if params.NewAddress == st.Address {
+			rt.Abortf(exitcode.ErrIllegalArgument, "new address %v is the current address", params.NewAddress)
+		}
+		if params.NewAddress.Protocol() != st.Address.Protocol() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "new address protocol %v does not match current protocol %v",
+				params.NewAddress.Protocol(), st.Address.Protocol())
+		}
+		st.Address = params.NewAddress
+		st.clearRecovery()
+	})
+
+	updateInitAddress(rt, params.NewAddress)
+	return nil
+}
+
+type SetGuardiansParams struct {
+	Guardians []addr.Address
+	Threshold uint64
+	Delay     abi.ChainEpoch
+}
+
+// Configures the guardians able to recover this account, authorized by the current key.
+// Empty guardians disable recovery. Any recovery in progress is cancelled.
+func (a Actor) SetGuardians(rt runtime.Runtime, params *SetGuardiansParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	var recovery *RecoveryConfig
+	if len(params.Guardians) > 0 {
+		if len(params.Guardians) > MaxGuardians {
+			rt.Abortf(exitcode.ErrIllegalArgument, "%d guardians exceeds maximum of %d", len(params.Guardians), MaxGuardians)
+		}
+		if params.Threshold < 1 || params.Threshold > uint64(len(params.Guardians)) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "threshold %d must be between 1 and %d", params.Threshold, len(params.Guardians))
+		}
+		if params.Delay < MinRecoveryDelay {
+			rt.Abortf(exitcode.ErrIllegalArgument, "delay %d is less than minimum %d", params.Delay, MinRecoveryDelay)
+		}
+
+		guardians := make([]addr.Address, 0, len(params.Guardians))
+		for _, g := range params.Guardians {
+			resolved, ok := rt.ResolveAddress(g)
+			if !ok {
+				rt.Abortf(exitcode.ErrNotFound, "failed to resolve guardian %v", g)
+			}
+			if resolved == rt.Receiver() {
+				rt.Abortf(exitcode.ErrIllegalArgument, "account cannot be its own guardian")
+			}
+			if isGuardian(guardians, resolved) {
+				rt.Abortf(exitcode.ErrIllegalArgument, "duplicate guardian %v", g)
+			}
+			guardians = append(guardians, resolved)
+		}
+		recovery = &RecoveryConfig{
+			Guardians: guardians,
+			Threshold: params.Threshold,
+			Delay:     params.Delay,
+		}
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		st.Recovery = recovery
+		st.clearRecovery()
+	})
+	return nil
+}
+
+type ApproveRecoveryParams struct {
+	NewAddress addr.Address
+}
+
+// Records a guardian's approval to recover this account to a new key, replacing any previous approval by the
+// same guardian. The new key must use the same protocol as the current one. Once a threshold of guardians approve the same key, the recovery may be completed after
+// the configured delay, unless cancelled by the current key in the meantime.
+func (a Actor) ApproveRecovery(rt runtime.Runtime, params *ApproveRecoveryParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+	if !isKeyAddress(params.NewAddress) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "new address must use BLS or SECP protocol, got %v", params.NewAddress.Protocol())
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if st.Recovery == nil || !isGuardian(st.Recovery.Guardians, rt.Caller()) {
+			rt.Abortf(exitcode.ErrForbidden, "caller %v is not a guardian", rt.Caller())
+		}
+		if params.NewAddress.Protocol() != st.Address.Protocol() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "new address protocol %v does not match current protocol %v",
+				params.NewAddress.Protocol(), st.Address.Protocol())
+		}
+		st.approveRecovery(rt.Caller(), params.NewAddress, rt.CurrEpoch())
+	})
+	return nil
+}
+
+// Cancels any recovery in progress, authorized by the current key.
+func (a Actor) CancelRecovery(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if len(st.RecoveryApprovals) == 0 {
+			rt.Abortf(exitcode.ErrNotFound, "no recovery in progress")
+		}
+		st.clearRecovery()
+	})
+	return nil
+}
+
+// Completes a pending recovery once its delay has elapsed, replacing the key signing for this account.
+// The previous key's address no longer resolves to this account. May be called by anyone.
+func (a Actor) CompleteRecovery(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if st.PendingRecovery == nil {
+			rt.Abortf(exitcode.ErrNotFound, "no pending recovery")
+		}
+		if rt.CurrEpoch() < st.PendingRecovery.ReadyEpoch {
+			rt.Abortf(exitcode.ErrForbidden, "recovery not ready until epoch %d", st.PendingRecovery.ReadyEpoch)
+		}
+		st.Address = st.PendingRecovery.NewAddress
+		st.clearRecovery()
+	})
+
+	updateInitAddress(rt, st.Address)
+	return nil
+}
+
+// Maps this account's new key address to its ID in the init actor, in place of the previous key's address,
+// so that messages from the previous key are no longer accepted for this account.
+func updateInitAddress(rt runtime.Runtime, newAddress addr.Address) {
+	code := rt.Send(builtin.InitActorAddr, builtin.MethodsInit.UpdateAccountAddress,
+		&init_.UpdateAccountAddressParams{NewAddress: newAddress}, big.Zero(), &builtin.Discard{})
+	builtin.RequireSuccess(rt, code, "failed to update address %v in init actor", newAddress)
+}
+
+func isKeyAddress(a addr.Address) bool {
+	return a.Protocol() == addr.SECP256K1 || a.Protocol() == addr.BLS
+}
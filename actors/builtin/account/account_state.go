@@ -0,0 +1,89 @@
+package account
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+)
+
+// Maximum number of guardians able to recover an account.
+const MaxGuardians = 16
+
+// Minimum delay between guardians approving a recovery and its completion, during which the current key may
+// cancel it.
+const MinRecoveryDelay = abi.ChainEpoch(builtin.EpochsInDay)
+
+type State struct {
+	// The public-key address whose key signs for this account.
+	// Changes when the key is rotated or the account is recovered.
+	Address addr.Address
+	// Guardians able to recover the account, or nil if recovery is disabled.
+	Recovery *RecoveryConfig
+	// At most one approval per guardian of a new key for the account.
+	RecoveryApprovals []RecoveryApproval
+	// A recovery approved by a threshold of guardians and awaiting its delay, or nil.
+	PendingRecovery *PendingRecovery
+}
+
+type RecoveryConfig struct {
+	Guardians []addr.Address // ID addresses
+	Threshold uint64
+	Delay     abi.ChainEpoch
+}
+
+type RecoveryApproval struct {
+	Guardian   addr.Address
+	NewAddress addr.Address
+}
+
+type PendingRecovery struct {
+	NewAddress addr.Address
+	ReadyEpoch abi.ChainEpoch
+}
+
+// Records a guardian's approval of a new key, replacing its previous approval, and updates the pending recovery.
+// A key becomes pending upon reaching the approval threshold, and ceases to be pending if it falls below it.
+func (st *State) approveRecovery(guardian addr.Address, newAddress addr.Address, currEpoch abi.ChainEpoch) {
+	approvals := st.RecoveryApprovals[:0:0]
+	for _, approval := range st.RecoveryApprovals {
+		if approval.Guardian != guardian {
+			approvals = append(approvals, approval)
+		}
+	}
+	st.RecoveryApprovals = append(approvals, RecoveryApproval{Guardian: guardian, NewAddress: newAddress})
+
+	if st.PendingRecovery != nil && st.approvalCount(st.PendingRecovery.NewAddress) < st.Recovery.Threshold {
+		st.PendingRecovery = nil
+	}
+	if st.PendingRecovery == nil && st.approvalCount(newAddress) >= st.Recovery.Threshold {
+		st.PendingRecovery = &PendingRecovery{
+			NewAddress: newAddress,
+			ReadyEpoch: currEpoch + st.Recovery.Delay,
+		}
+	}
+}
+
+func (st *State) approvalCount(newAddress addr.Address) uint64 {
+	count := uint64(0)
+	for _, approval := range st.RecoveryApprovals {
+		if approval.NewAddress == newAddress {
+			count++
+		}
+	}
+	return count
+}
+
+func (st *State) clearRecovery() {
+	st.RecoveryApprovals = nil
+	st.PendingRecovery = nil
+}
+
+func isGuardian(guardians []addr.Address, a addr.Address) bool {
+	for _, g := range guardians {
+		if g == a {
+			return true
+		}
+	}
+	return false
+}
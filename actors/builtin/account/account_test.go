@@ -6,12 +6,14 @@ import (
 	"testing"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/account"
+	init_ "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
 	"github.com/filecoin-project/specs-actors/v3/support/mock"
 	tutil "github.com/filecoin-project/specs-actors/v3/support/testing"
 )
@@ -89,3 +91,263 @@ func checkState(t *testing.T, rt *mock.Runtime) {
 	_, msgs := account.CheckStateInvariants(&st, testAddress)
 	assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
 }
+
+func TestRotateKey(t *testing.T) {
+	actor := account.Actor{}
+	receiver := tutil.NewIDAddr(t, 100)
+	key := tutil.NewSECP256K1Addr(t, "key")
+	newKey := tutil.NewSECP256K1Addr(t, "new key")
+	builder := mock.NewBuilder(context.Background(), receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	t.Run("rotate key", func(t *testing.T) {
+		rt := builder.Build(t)
+		constructAccount(rt, key)
+
+		rt.SetCaller(receiver, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(receiver)
+		expectUpdateInitAddress(rt, newKey, exitcode.Ok)
+		rt.Call(actor.RotateKey, &account.RotateKeyParams{NewAddress: newKey})
+		rt.Verify()
+
+		rt.ExpectValidateCallerAny()
+		pubkeyAddress := rt.Call(actor.PubkeyAddress, nil).(*address.Address)
+		assert.Equal(t, newKey, *pubkeyAddress)
+		checkState(t, rt)
+	})
+
+	t.Run("rotation requires current key", func(t *testing.T) {
+		rt := builder.Build(t)
+		constructAccount(rt, key)
+
+		rt.SetCaller(tutil.NewIDAddr(t, 101), builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(receiver)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.RotateKey, &account.RotateKeyParams{NewAddress: newKey})
+		})
+	})
+
+	t.Run("rejects invalid key", func(t *testing.T) {
+		rt := builder.Build(t)
+		constructAccount(rt, key)
+		rt.SetCaller(receiver, builtin.AccountActorCodeID)
+
+		for _, a := range []address.Address{tutil.NewIDAddr(t, 1), tutil.NewActorAddr(t, "actor"), key, tutil.NewBLSAddr(t, 2)} {
+			rt.ExpectValidateCallerAddr(receiver)
+			rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+				rt.Call(actor.RotateKey, &account.RotateKeyParams{NewAddress: a})
+			})
+		}
+		checkState(t, rt)
+	})
+
+	t.Run("rotation fails if init rejects new key", func(t *testing.T) {
+		rt := builder.Build(t)
+		constructAccount(rt, key)
+
+		rt.SetCaller(receiver, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(receiver)
+		expectUpdateInitAddress(rt, newKey, exitcode.ErrForbidden)
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			rt.Call(actor.RotateKey, &account.RotateKeyParams{NewAddress: newKey})
+		})
+
+		var st account.State
+		rt.GetState(&st)
+		assert.Equal(t, key, st.Address)
+		checkState(t, rt)
+	})
+}
+
+func TestRecovery(t *testing.T) {
+	actor := account.Actor{}
+	receiver := tutil.NewIDAddr(t, 100)
+	key := tutil.NewSECP256K1Addr(t, "key")
+	newKey := tutil.NewSECP256K1Addr(t, "new key")
+	otherKey := tutil.NewSECP256K1Addr(t, "other key")
+	g1, g2, g3 := tutil.NewIDAddr(t, 201), tutil.NewIDAddr(t, 202), tutil.NewIDAddr(t, 203)
+	delay := account.MinRecoveryDelay
+	builder := mock.NewBuilder(context.Background(), receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := builder.Build(t)
+		constructAccount(rt, key)
+		setGuardians(rt, &account.SetGuardiansParams{Guardians: []address.Address{g1, g2, g3}, Threshold: 2, Delay: delay})
+		return rt
+	}
+
+	t.Run("guardians recover account after delay", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetEpoch(10)
+		approveRecovery(rt, g1, newKey)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no pending recovery", func() {
+			completeRecovery(rt)
+		})
+
+		approveRecovery(rt, g2, newKey)
+		var st account.State
+		rt.GetState(&st)
+		require.NotNil(t, st.PendingRecovery)
+		assert.Equal(t, newKey, st.PendingRecovery.NewAddress)
+		assert.Equal(t, 10+delay, st.PendingRecovery.ReadyEpoch)
+
+		rt.SetEpoch(10 + delay - 1)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "not ready", func() {
+			completeRecovery(rt)
+		})
+
+		rt.SetEpoch(10 + delay)
+		expectUpdateInitAddress(rt, newKey, exitcode.Ok)
+		completeRecovery(rt)
+		rt.GetState(&st)
+		assert.Equal(t, newKey, st.Address)
+		assert.Nil(t, st.PendingRecovery)
+		assert.Empty(t, st.RecoveryApprovals)
+		checkState(t, rt)
+	})
+
+	t.Run("changed approval withdraws pending recovery", func(t *testing.T) {
+		rt := setup(t)
+		approveRecovery(rt, g1, newKey)
+		approveRecovery(rt, g2, newKey)
+		approveRecovery(rt, g2, otherKey)
+
+		var st account.State
+		rt.GetState(&st)
+		assert.Nil(t, st.PendingRecovery)
+		checkState(t, rt)
+
+		approveRecovery(rt, g3, otherKey)
+		rt.GetState(&st)
+		require.NotNil(t, st.PendingRecovery)
+		assert.Equal(t, otherKey, st.PendingRecovery.NewAddress)
+		checkState(t, rt)
+	})
+
+	t.Run("current key cancels recovery", func(t *testing.T) {
+		rt := setup(t)
+		approveRecovery(rt, g1, newKey)
+		approveRecovery(rt, g2, newKey)
+
+		rt.SetCaller(receiver, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(receiver)
+		rt.Call(actor.CancelRecovery, nil)
+		rt.Verify()
+
+		rt.SetEpoch(delay)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no pending recovery", func() {
+			completeRecovery(rt)
+		})
+		rt.SetCaller(receiver, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(receiver)
+		rt.ExpectAbort(exitcode.ErrNotFound, func() {
+			rt.Call(actor.CancelRecovery, nil)
+		})
+
+		var st account.State
+		rt.GetState(&st)
+		assert.Equal(t, key, st.Address)
+		checkState(t, rt)
+	})
+
+	t.Run("rotation and reconfiguration cancel recovery", func(t *testing.T) {
+		rt := setup(t)
+		approveRecovery(rt, g1, newKey)
+		approveRecovery(rt, g2, newKey)
+
+		rt.SetCaller(receiver, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(receiver)
+		expectUpdateInitAddress(rt, otherKey, exitcode.Ok)
+		rt.Call(actor.RotateKey, &account.RotateKeyParams{NewAddress: otherKey})
+		rt.Verify()
+
+		var st account.State
+		rt.GetState(&st)
+		assert.Nil(t, st.PendingRecovery)
+		assert.Empty(t, st.RecoveryApprovals)
+
+		approveRecovery(rt, g1, newKey)
+		setGuardians(rt, &account.SetGuardiansParams{})
+		rt.GetState(&st)
+		assert.Nil(t, st.Recovery)
+		assert.Empty(t, st.RecoveryApprovals)
+		checkState(t, rt)
+
+		rt.SetCaller(g1, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "not a guardian", func() {
+			rt.Call(actor.ApproveRecovery, &account.ApproveRecoveryParams{NewAddress: newKey})
+		})
+	})
+
+	t.Run("only guardians approve", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetCaller(tutil.NewIDAddr(t, 204), builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "not a guardian", func() {
+			rt.Call(actor.ApproveRecovery, &account.ApproveRecoveryParams{NewAddress: newKey})
+		})
+		rt.SetCaller(g1, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			rt.Call(actor.ApproveRecovery, &account.ApproveRecoveryParams{NewAddress: g2})
+		})
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "does not match current protocol", func() {
+			rt.Call(actor.ApproveRecovery, &account.ApproveRecoveryParams{NewAddress: tutil.NewBLSAddr(t, 2)})
+		})
+		checkState(t, rt)
+	})
+
+	t.Run("invalid guardian configuration", func(t *testing.T) {
+		rt := builder.Build(t)
+		constructAccount(rt, key)
+
+		for _, params := range []*account.SetGuardiansParams{
+			{Guardians: []address.Address{g1, g2}, Threshold: 0, Delay: delay},
+			{Guardians: []address.Address{g1, g2}, Threshold: 3, Delay: delay},
+			{Guardians: []address.Address{g1, g2}, Threshold: 1, Delay: delay - 1},
+			{Guardians: []address.Address{g1, g1}, Threshold: 1, Delay: delay},
+			{Guardians: []address.Address{g1, receiver}, Threshold: 1, Delay: delay},
+			{Guardians: make([]address.Address, account.MaxGuardians+1), Threshold: 1, Delay: delay},
+		} {
+			rt.SetCaller(receiver, builtin.AccountActorCodeID)
+			rt.ExpectValidateCallerAddr(receiver)
+			rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+				rt.Call(actor.SetGuardians, params)
+			})
+		}
+		checkState(t, rt)
+	})
+}
+
+func constructAccount(rt *mock.Runtime, key address.Address) {
+	rt.SetCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+	rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+	rt.Call(account.Actor{}.Constructor, &key)
+	rt.Verify()
+}
+
+func setGuardians(rt *mock.Runtime, params *account.SetGuardiansParams) {
+	rt.SetCaller(rt.Receiver(), builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	rt.Call(account.Actor{}.SetGuardians, params)
+	rt.Verify()
+}
+
+func approveRecovery(rt *mock.Runtime, guardian, newKey address.Address) {
+	rt.SetCaller(guardian, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+	rt.Call(account.Actor{}.ApproveRecovery, &account.ApproveRecoveryParams{NewAddress: newKey})
+	rt.Verify()
+}
+
+func completeRecovery(rt *mock.Runtime) {
+	rt.ExpectValidateCallerAny()
+	rt.Call(account.Actor{}.CompleteRecovery, nil)
+	rt.Verify()
+}
+
+func expectUpdateInitAddress(rt *mock.Runtime, newKey address.Address, exitCode exitcode.ExitCode) {
+	rt.ExpectSend(builtin.InitActorAddr, builtin.MethodsInit.UpdateAccountAddress,
+		&init_.UpdateAccountAddressParams{NewAddress: newKey}, big.Zero(), nil, exitCode)
+}
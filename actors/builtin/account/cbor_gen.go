@@ -6,13 +6,15 @@ import (
 	"fmt"
 	"io"
 
+	address "github.com/filecoin-project/go-address"
+	abi "github.com/filecoin-project/go-state-types/abi"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	xerrors "golang.org/x/xerrors"
 )
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{129}
+var lengthBufState = []byte{132}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -23,10 +25,36 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	scratch := make([]byte, 9)
+
 	// t.Address (address.Address) (struct)
 	if err := t.Address.MarshalCBOR(w); err != nil {
 		return err
 	}
+
+	// t.Recovery (account.RecoveryConfig) (struct)
+	if err := t.Recovery.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.RecoveryApprovals ([]account.RecoveryApproval) (slice)
+	if len(t.RecoveryApprovals) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.RecoveryApprovals was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.RecoveryApprovals))); err != nil {
+		return err
+	}
+	for _, v := range t.RecoveryApprovals {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.PendingRecovery (account.PendingRecovery) (struct)
+	if err := t.PendingRecovery.MarshalCBOR(w); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -44,7 +72,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 4 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -56,6 +84,587 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.Address: %w", err)
 		}
 
+	}
+	// t.Recovery (account.RecoveryConfig) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Recovery = new(RecoveryConfig)
+			if err := t.Recovery.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Recovery pointer: %w", err)
+			}
+		}
+
+	}
+	// t.RecoveryApprovals ([]account.RecoveryApproval) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.RecoveryApprovals: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.RecoveryApprovals = make([]RecoveryApproval, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v RecoveryApproval
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.RecoveryApprovals[i] = v
+	}
+
+	// t.PendingRecovery (account.PendingRecovery) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.PendingRecovery = new(PendingRecovery)
+			if err := t.PendingRecovery.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.PendingRecovery pointer: %w", err)
+			}
+		}
+
+	}
+	return nil
+}
+
+var lengthBufRecoveryConfig = []byte{131}
+
+func (t *RecoveryConfig) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRecoveryConfig); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Guardians ([]address.Address) (slice)
+	if len(t.Guardians) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Guardians was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Guardians))); err != nil {
+		return err
+	}
+	for _, v := range t.Guardians {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Threshold (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Threshold)); err != nil {
+		return err
+	}
+
+	// t.Delay (abi.ChainEpoch) (int64)
+	if t.Delay >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Delay)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Delay-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *RecoveryConfig) UnmarshalCBOR(r io.Reader) error {
+	*t = RecoveryConfig{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Guardians ([]address.Address) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Guardians: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Guardians = make([]address.Address, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v address.Address
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Guardians[i] = v
+	}
+
+	// t.Threshold (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Threshold = uint64(extra)
+
+	}
+	// t.Delay (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Delay = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufRecoveryApproval = []byte{130}
+
+func (t *RecoveryApproval) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRecoveryApproval); err != nil {
+		return err
+	}
+
+	// t.Guardian (address.Address) (struct)
+	if err := t.Guardian.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.NewAddress (address.Address) (struct)
+	if err := t.NewAddress.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RecoveryApproval) UnmarshalCBOR(r io.Reader) error {
+	*t = RecoveryApproval{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Guardian (address.Address) (struct)
+
+	{
+
+		if err := t.Guardian.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Guardian: %w", err)
+		}
+
+	}
+	// t.NewAddress (address.Address) (struct)
+
+	{
+
+		if err := t.NewAddress.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewAddress: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufPendingRecovery = []byte{130}
+
+func (t *PendingRecovery) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufPendingRecovery); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.NewAddress (address.Address) (struct)
+	if err := t.NewAddress.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.ReadyEpoch (abi.ChainEpoch) (int64)
+	if t.ReadyEpoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ReadyEpoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ReadyEpoch-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *PendingRecovery) UnmarshalCBOR(r io.Reader) error {
+	*t = PendingRecovery{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewAddress (address.Address) (struct)
+
+	{
+
+		if err := t.NewAddress.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewAddress: %w", err)
+		}
+
+	}
+	// t.ReadyEpoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ReadyEpoch = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufRotateKeyParams = []byte{129}
+
+func (t *RotateKeyParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRotateKeyParams); err != nil {
+		return err
+	}
+
+	// t.NewAddress (address.Address) (struct)
+	if err := t.NewAddress.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RotateKeyParams) UnmarshalCBOR(r io.Reader) error {
+	*t = RotateKeyParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewAddress (address.Address) (struct)
+
+	{
+
+		if err := t.NewAddress.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewAddress: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufSetGuardiansParams = []byte{131}
+
+func (t *SetGuardiansParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSetGuardiansParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Guardians ([]address.Address) (slice)
+	if len(t.Guardians) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Guardians was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Guardians))); err != nil {
+		return err
+	}
+	for _, v := range t.Guardians {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Threshold (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Threshold)); err != nil {
+		return err
+	}
+
+	// t.Delay (abi.ChainEpoch) (int64)
+	if t.Delay >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Delay)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Delay-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *SetGuardiansParams) UnmarshalCBOR(r io.Reader) error {
+	*t = SetGuardiansParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Guardians ([]address.Address) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Guardians: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Guardians = make([]address.Address, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v address.Address
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Guardians[i] = v
+	}
+
+	// t.Threshold (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Threshold = uint64(extra)
+
+	}
+	// t.Delay (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Delay = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufApproveRecoveryParams = []byte{129}
+
+func (t *ApproveRecoveryParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufApproveRecoveryParams); err != nil {
+		return err
+	}
+
+	// t.NewAddress (address.Address) (struct)
+	if err := t.NewAddress.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ApproveRecoveryParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ApproveRecoveryParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewAddress (address.Address) (struct)
+
+	{
+
+		if err := t.NewAddress.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewAddress: %w", err)
+		}
+
 	}
 	return nil
 }
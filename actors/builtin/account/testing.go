@@ -20,9 +20,41 @@ func CheckStateInvariants(st *State, idAddr address.Address) (*StateSummary, *bu
 	if id, err := address.IDFromAddress(idAddr); err != nil {
 		acc.Addf("error extracting actor ID from address: %v", err)
 	} else if id >= builtin.FirstNonSingletonActorId {
-		acc.Require(st.Address.Protocol() == address.BLS || st.Address.Protocol() == address.SECP256K1,
-			"actor address %v must be BLS or SECP256K1 protocol", st.Address)
+		acc.Require(isKeyAddress(st.Address), "actor address %v must be BLS or SECP256K1 protocol", st.Address)
 	}
 
+	checkRecovery(st, idAddr, acc)
 	return accountSummary, acc
 }
+
+func checkRecovery(st *State, idAddr address.Address, acc *builtin.MessageAccumulator) {
+	if st.Recovery == nil {
+		acc.Require(len(st.RecoveryApprovals) == 0, "recovery approvals without guardians")
+		acc.Require(st.PendingRecovery == nil, "pending recovery without guardians")
+		return
+	}
+
+	guardians := st.Recovery.Guardians
+	acc.Require(len(guardians) > 0 && len(guardians) <= MaxGuardians, "guardian count %d out of range", len(guardians))
+	acc.Require(st.Recovery.Threshold >= 1 && st.Recovery.Threshold <= uint64(len(guardians)),
+		"threshold %d out of range for %d guardians", st.Recovery.Threshold, len(guardians))
+	acc.Require(st.Recovery.Delay >= MinRecoveryDelay, "recovery delay %d less than minimum", st.Recovery.Delay)
+	for i, g := range guardians {
+		acc.Require(g.Protocol() == address.ID, "guardian %v is not an ID address", g)
+		acc.Require(g != idAddr, "account is its own guardian")
+		acc.Require(!isGuardian(guardians[:i], g), "duplicate guardian %v", g)
+	}
+
+	approvers := make([]address.Address, 0, len(st.RecoveryApprovals))
+	for _, approval := range st.RecoveryApprovals {
+		acc.Require(isGuardian(guardians, approval.Guardian), "approval by non-guardian %v", approval.Guardian)
+		acc.Require(!isGuardian(approvers, approval.Guardian), "duplicate approval by guardian %v", approval.Guardian)
+		acc.Require(isKeyAddress(approval.NewAddress), "approved address %v must be BLS or SECP256K1 protocol", approval.NewAddress)
+		approvers = append(approvers, approval.Guardian)
+	}
+
+	if st.PendingRecovery != nil {
+		acc.Require(st.approvalCount(st.PendingRecovery.NewAddress) >= st.Recovery.Threshold,
+			"pending recovery to %v lacks threshold approvals", st.PendingRecovery.NewAddress)
+	}
+}
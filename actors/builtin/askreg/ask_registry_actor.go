@@ -0,0 +1,189 @@
+package askreg
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// The storage ask registry records the terms on which storage providers accept deals, so that
+// clients can discover them on chain.
+type Actor struct{}
+
+func (a Actor) Exports() []interface{} {
+	return []interface{}{
+		builtin.MethodConstructor: a.Constructor,
+		2:                         a.PublishAsk,
+		3:                         a.RemoveAsk,
+		4:                         a.GetAsk,
+	}
+}
+
+func (a Actor) Code() cid.Cid {
+	return builtin.StorageAskRegistryActorCodeID
+}
+
+func (a Actor) IsSingleton() bool {
+	return true
+}
+
+func (a Actor) State() cbor.Er {
+	return new(State)
+}
+
+var _ runtime.VMActor = Actor{}
+
+////////////////////////////////////////////////////////////////////////////////
+// Actor methods
+////////////////////////////////////////////////////////////////////////////////
+
+func (a Actor) Constructor(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
+
+	st, err := ConstructState(adt.AsStore(rt))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to construct state")
+	rt.StateCreate(st)
+	return nil
+}
+
+type PublishAskParams struct {
+	Provider      addr.Address
+	Price         abi.TokenAmount
+	VerifiedPrice abi.TokenAmount
+	MinPieceSize  abi.PaddedPieceSize
+	MaxPieceSize  abi.PaddedPieceSize
+	Expiry        abi.ChainEpoch
+}
+
+// Publishes a storage ask for a provider, replacing any ask it previously published.
+// The caller must be the provider's worker or one of its control addresses.
+func (a Actor) PublishAsk(rt runtime.Runtime, params *PublishAskParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+	provider := validateProviderCaller(rt, params.Provider)
+
+	builtin.RequireParam(rt, params.Price.GreaterThanEqual(big.Zero()), "negative price %v", params.Price)
+	builtin.RequireParam(rt, params.VerifiedPrice.GreaterThanEqual(big.Zero()), "negative verified price %v", params.VerifiedPrice)
+	if err := params.MinPieceSize.Validate(); err != nil {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid min piece size: %v", err)
+	}
+	if err := params.MaxPieceSize.Validate(); err != nil {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid max piece size: %v", err)
+	}
+	builtin.RequireParam(rt, params.MinPieceSize <= params.MaxPieceSize,
+		"min piece size %d exceeds max piece size %d", params.MinPieceSize, params.MaxPieceSize)
+	builtin.RequireParam(rt, params.Expiry > rt.CurrEpoch(), "expiry %d must be after current epoch %d", params.Expiry, rt.CurrEpoch())
+	builtin.RequireParam(rt, params.Expiry-rt.CurrEpoch() <= MaxAskDuration,
+		"expiry %d exceeds max ask duration %d from current epoch %d", params.Expiry, MaxAskDuration, rt.CurrEpoch())
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		asks, err := adt.AsMap(adt.AsStore(rt), st.Asks, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load asks")
+
+		var prior StorageAsk
+		found, err := asks.Get(abi.AddrKey(provider), &prior)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get ask for %v", provider)
+		seqNo := uint64(0)
+		if found {
+			seqNo = prior.SeqNo + 1
+		}
+
+		ask := StorageAsk{
+			Price:         params.Price,
+			VerifiedPrice: params.VerifiedPrice,
+			MinPieceSize:  params.MinPieceSize,
+			MaxPieceSize:  params.MaxPieceSize,
+			Timestamp:     rt.CurrEpoch(),
+			Expiry:        params.Expiry,
+			SeqNo:         seqNo,
+		}
+		err = asks.Put(abi.AddrKey(provider), &ask)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put ask for %v", provider)
+
+		st.Asks, err = asks.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush asks")
+	})
+	return nil
+}
+
+// Removes a provider's ask.
+// The caller must be the provider's worker or one of its control addresses.
+func (a Actor) RemoveAsk(rt runtime.Runtime, providerAddr *addr.Address) *abi.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+	provider := validateProviderCaller(rt, *providerAddr)
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		asks, err := adt.AsMap(adt.AsStore(rt), st.Asks, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load asks")
+
+		found, err := asks.TryDelete(abi.AddrKey(provider))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete ask for %v", provider)
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "no ask for provider %v", provider)
+		}
+
+		st.Asks, err = asks.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush asks")
+	})
+	return nil
+}
+
+type GetAskReturn struct {
+	// The provider's active ask, or nil if it has none.
+	Ask *StorageAsk
+}
+
+// Returns a provider's active ask, if any.
+func (a Actor) GetAsk(rt runtime.Runtime, providerAddr *addr.Address) *GetAskReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	provider, ok := rt.ResolveAddress(*providerAddr)
+	if !ok {
+		return &GetAskReturn{}
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+	ask, found, err := st.GetAsk(adt.AsStore(rt), provider)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get ask for %v", provider)
+	if !found || !ask.IsActive(rt.CurrEpoch()) {
+		return &GetAskReturn{}
+	}
+	return &GetAskReturn{Ask: ask}
+}
+
+// Resolves a provider address to a storage miner ID address, and checks that the caller is the provider's
+// worker or one of its control addresses.
+func validateProviderCaller(rt runtime.Runtime, providerAddr addr.Address) addr.Address {
+	provider, ok := rt.ResolveAddress(providerAddr)
+	if !ok {
+		rt.Abortf(exitcode.ErrNotFound, "failed to resolve provider address %v", providerAddr)
+	}
+	codeID, ok := rt.GetActorCodeCID(provider)
+	builtin.RequireParam(rt, ok, "no code ID for address %v", provider)
+	if !codeID.Equals(builtin.StorageMinerActorCodeID) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "provider %v is not a storage miner", provider)
+	}
+
+	caller := rt.Caller()
+	_, worker, controllers := builtin.RequestMinerControlAddrs(rt, provider)
+	callerOk := caller == worker
+	for _, controller := range controllers {
+		if callerOk {
+			break
+		}
+		callerOk = caller == controller
+	}
+	if !callerOk {
+		rt.Abortf(exitcode.ErrForbidden, "caller %v is not worker or control address of provider %v", caller, provider)
+	}
+	return provider
+}
@@ -0,0 +1,86 @@
+package askreg
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// Maximum number of epochs for which a published ask may remain active.
+var MaxAskDuration = abi.ChainEpoch(30 * builtin.EpochsInDay) // PARAM_SPEC
+
+// Number of bytes in the unit of storage against which ask prices are quoted.
+const askPriceUnitBytes = 1 << 30 // GiB
+
+type State struct {
+	// Storage asks published by providers, keyed by the provider's ID address.
+	// An ask remains in state after it expires, until it is replaced or removed.
+	Asks cid.Cid // HAMT[addr.Address]StorageAsk
+}
+
+// StorageAsk is a provider's published terms for accepting storage deals.
+type StorageAsk struct {
+	// Price per GiB per epoch for unverified deals.
+	Price abi.TokenAmount
+	// Price per GiB per epoch for verified deals.
+	VerifiedPrice abi.TokenAmount
+	// Bounds (inclusive) on the padded size of pieces the provider accepts.
+	MinPieceSize abi.PaddedPieceSize
+	MaxPieceSize abi.PaddedPieceSize
+	// Epoch at which the ask was published.
+	Timestamp abi.ChainEpoch
+	// Epoch at which the ask ceases to be active.
+	Expiry abi.ChainEpoch
+	// Number of times the provider has published an ask, including this one.
+	SeqNo uint64
+}
+
+func ConstructState(store adt.Store) (*State, error) {
+	emptyMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty map: %w", err)
+	}
+	return &State{Asks: emptyMapCid}, nil
+}
+
+// Returns the ask most recently published by a provider, whether or not it is still active.
+func (st *State) GetAsk(store adt.Store, provider addr.Address) (*StorageAsk, bool, error) {
+	asks, err := adt.AsMap(store, st.Asks, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load asks: %w", err)
+	}
+	var ask StorageAsk
+	found, err := asks.Get(abi.AddrKey(provider), &ask)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to get ask for %v: %w", provider, err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+	return &ask, true, nil
+}
+
+// Whether the ask is active at an epoch.
+func (a *StorageAsk) IsActive(epoch abi.ChainEpoch) bool {
+	return epoch < a.Expiry
+}
+
+// Whether the ask accepts pieces of a size.
+func (a *StorageAsk) AcceptsPieceSize(size abi.PaddedPieceSize) bool {
+	return size >= a.MinPieceSize && size <= a.MaxPieceSize
+}
+
+// Computes the minimum price per epoch the ask accepts for a piece, rounding up.
+func (a *StorageAsk) MinPricePerEpoch(size abi.PaddedPieceSize, verified bool) abi.TokenAmount {
+	price := a.Price
+	if verified {
+		price = a.VerifiedPrice
+	}
+	num := big.Mul(price, big.NewIntUnsigned(uint64(size)))
+	return big.Div(big.Add(num, big.NewInt(askPriceUnitBytes-1)), big.NewInt(askPriceUnitBytes))
+}
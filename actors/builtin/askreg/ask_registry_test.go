@@ -0,0 +1,272 @@
+package askreg_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/askreg"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v3/support/mock"
+	tutil "github.com/filecoin-project/specs-actors/v3/support/testing"
+)
+
+func TestExports(t *testing.T) {
+	mock.CheckActorExports(t, askreg.Actor{})
+}
+
+func TestConstruction(t *testing.T) {
+	rt := mock.NewBuilder(context.Background(), builtin.StorageAskRegistryActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+		Build(t)
+	actor := askRegActorTestHarness{t: t}
+	actor.constructAndVerify(rt)
+
+	emptyMap, err := adt.StoreEmptyMap(rt.AdtStore(), builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	assert.Equal(t, emptyMap, actor.state(rt).Asks)
+	actor.checkState(rt)
+}
+
+func TestPublishAsk(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	worker := tutil.NewIDAddr(t, 102)
+	control := tutil.NewIDAddr(t, 103)
+	provider := tutil.NewIDAddr(t, 104)
+	other := tutil.NewIDAddr(t, 105)
+
+	setup := func(t *testing.T) (*mock.Runtime, *askRegActorTestHarness) {
+		rt := mock.NewBuilder(context.Background(), builtin.StorageAskRegistryActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			WithActorType(provider, builtin.StorageMinerActorCodeID).
+			WithEpoch(100).
+			Build(t)
+		actor := askRegActorTestHarness{t: t, owner: owner, worker: worker, control: control, provider: provider}
+		actor.constructAndVerify(rt)
+		return rt, &actor
+	}
+
+	t.Run("worker publishes and replaces ask", func(t *testing.T) {
+		rt, actor := setup(t)
+		params := actor.askParams(rt)
+		actor.publishAsk(rt, worker, params)
+
+		ask := actor.getAsk(rt)
+		require.NotNil(t, ask)
+		assert.Equal(t, params.Price, ask.Price)
+		assert.Equal(t, params.VerifiedPrice, ask.VerifiedPrice)
+		assert.Equal(t, params.MinPieceSize, ask.MinPieceSize)
+		assert.Equal(t, params.MaxPieceSize, ask.MaxPieceSize)
+		assert.Equal(t, params.Expiry, ask.Expiry)
+		assert.Equal(t, rt.Epoch(), ask.Timestamp)
+		assert.Equal(t, uint64(0), ask.SeqNo)
+
+		rt.SetEpoch(200)
+		params = actor.askParams(rt)
+		params.Price = abi.NewTokenAmount(2000)
+		actor.publishAsk(rt, control, params)
+
+		ask = actor.getAsk(rt)
+		require.NotNil(t, ask)
+		assert.Equal(t, params.Price, ask.Price)
+		assert.Equal(t, abi.ChainEpoch(200), ask.Timestamp)
+		assert.Equal(t, uint64(1), ask.SeqNo)
+		actor.checkState(rt)
+	})
+
+	t.Run("ask is inactive after expiry", func(t *testing.T) {
+		rt, actor := setup(t)
+		params := actor.askParams(rt)
+		actor.publishAsk(rt, worker, params)
+
+		rt.SetEpoch(params.Expiry - 1)
+		assert.NotNil(t, actor.getAsk(rt))
+		rt.SetEpoch(params.Expiry)
+		assert.Nil(t, actor.getAsk(rt))
+		actor.checkState(rt)
+	})
+
+	t.Run("no ask for unknown provider", func(t *testing.T) {
+		rt, actor := setup(t)
+		assert.Nil(t, actor.getAsk(rt))
+
+		unresolvable := tutil.NewBLSAddr(t, 1)
+		rt.ExpectValidateCallerAny()
+		ret := rt.Call(actor.GetAsk, &unresolvable).(*askreg.GetAskReturn)
+		rt.Verify()
+		assert.Nil(t, ret.Ask)
+	})
+
+	t.Run("fails when caller is not worker or control address", func(t *testing.T) {
+		rt, actor := setup(t)
+		params := actor.askParams(rt)
+		for _, caller := range []addr.Address{owner, other} {
+			rt.SetCaller(caller, builtin.AccountActorCodeID)
+			rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+			actor.expectControlAddresses(rt)
+			rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "is not worker or control address", func() {
+				rt.Call(actor.PublishAsk, params)
+			})
+			rt.Verify()
+		}
+		actor.checkState(rt)
+	})
+
+	t.Run("fails when provider is not a miner", func(t *testing.T) {
+		rt, actor := setup(t)
+		params := actor.askParams(rt)
+		params.Provider = other
+		rt.SetAddressActorType(other, builtin.AccountActorCodeID)
+		rt.SetCaller(worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "is not a storage miner", func() {
+			rt.Call(actor.PublishAsk, params)
+		})
+		rt.Verify()
+	})
+
+	t.Run("fails with invalid params", func(t *testing.T) {
+		rt, actor := setup(t)
+		for _, tc := range []struct {
+			name   string
+			modify func(p *askreg.PublishAskParams)
+			msg    string
+		}{
+			{"negative price", func(p *askreg.PublishAskParams) { p.Price = abi.NewTokenAmount(-1) }, "negative price"},
+			{"negative verified price", func(p *askreg.PublishAskParams) { p.VerifiedPrice = abi.NewTokenAmount(-1) }, "negative verified price"},
+			{"invalid min size", func(p *askreg.PublishAskParams) { p.MinPieceSize = 1000 }, "invalid min piece size"},
+			{"invalid max size", func(p *askreg.PublishAskParams) { p.MaxPieceSize = 1000 }, "invalid max piece size"},
+			{"min exceeds max", func(p *askreg.PublishAskParams) { p.MinPieceSize, p.MaxPieceSize = p.MaxPieceSize, p.MinPieceSize }, "exceeds max piece size"},
+			{"expired", func(p *askreg.PublishAskParams) { p.Expiry = rt.Epoch() }, "must be after current epoch"},
+			{"too long", func(p *askreg.PublishAskParams) { p.Expiry = rt.Epoch() + askreg.MaxAskDuration + 1 }, "exceeds max ask duration"},
+		} {
+			params := actor.askParams(rt)
+			tc.modify(params)
+			rt.SetCaller(worker, builtin.AccountActorCodeID)
+			rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+			actor.expectControlAddresses(rt)
+			rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, tc.msg, func() {
+				rt.Call(actor.PublishAsk, params)
+			})
+			rt.Verify()
+		}
+		assert.Nil(t, actor.getAsk(rt))
+	})
+}
+
+func TestRemoveAsk(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	worker := tutil.NewIDAddr(t, 102)
+	provider := tutil.NewIDAddr(t, 104)
+
+	rt := mock.NewBuilder(context.Background(), builtin.StorageAskRegistryActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+		WithActorType(provider, builtin.StorageMinerActorCodeID).
+		WithEpoch(100).
+		Build(t)
+	actor := askRegActorTestHarness{t: t, owner: owner, worker: worker, provider: provider}
+	actor.constructAndVerify(rt)
+	actor.publishAsk(rt, worker, actor.askParams(rt))
+
+	rt.SetCaller(worker, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+	actor.expectControlAddresses(rt)
+	rt.Call(actor.RemoveAsk, &provider)
+	rt.Verify()
+	assert.Nil(t, actor.getAsk(rt))
+
+	// Removing again fails.
+	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+	actor.expectControlAddresses(rt)
+	rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no ask for provider", func() {
+		rt.Call(actor.RemoveAsk, &provider)
+	})
+	rt.Verify()
+	actor.checkState(rt)
+}
+
+func TestMinPricePerEpoch(t *testing.T) {
+	ask := askreg.StorageAsk{
+		Price:         abi.NewTokenAmount(1 << 30),
+		VerifiedPrice: abi.NewTokenAmount(3),
+	}
+	assert.Equal(t, abi.NewTokenAmount(1<<20), ask.MinPricePerEpoch(1<<20, false))
+	assert.Equal(t, abi.NewTokenAmount(3<<5), ask.MinPricePerEpoch(32<<30, true))
+	// Rounds up.
+	assert.Equal(t, abi.NewTokenAmount(1), ask.MinPricePerEpoch(1<<20, true))
+	free := askreg.StorageAsk{VerifiedPrice: big.Zero()}
+	assert.True(t, free.MinPricePerEpoch(1<<20, true).Equals(big.Zero()))
+}
+
+type askRegActorTestHarness struct {
+	askreg.Actor
+	t *testing.T
+
+	owner    addr.Address
+	worker   addr.Address
+	control  addr.Address
+	provider addr.Address
+}
+
+func (h *askRegActorTestHarness) constructAndVerify(rt *mock.Runtime) {
+	rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+	ret := rt.Call(h.Constructor, nil)
+	require.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *askRegActorTestHarness) state(rt *mock.Runtime) *askreg.State {
+	var st askreg.State
+	rt.GetState(&st)
+	return &st
+}
+
+func (h *askRegActorTestHarness) checkState(rt *mock.Runtime) {
+	st := h.state(rt)
+	_, msgs := askreg.CheckStateInvariants(st, rt.AdtStore())
+	assert.True(h.t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+}
+
+func (h *askRegActorTestHarness) askParams(rt *mock.Runtime) *askreg.PublishAskParams {
+	return &askreg.PublishAskParams{
+		Provider:      h.provider,
+		Price:         abi.NewTokenAmount(1000),
+		VerifiedPrice: abi.NewTokenAmount(10),
+		MinPieceSize:  abi.PaddedPieceSize(1 << 20),
+		MaxPieceSize:  abi.PaddedPieceSize(32 << 30),
+		Expiry:        rt.Epoch() + builtin.EpochsInDay,
+	}
+}
+
+func (h *askRegActorTestHarness) expectControlAddresses(rt *mock.Runtime) {
+	var controls []addr.Address
+	if h.control != addr.Undef {
+		controls = append(controls, h.control)
+	}
+	rt.ExpectSend(h.provider, builtin.MethodsMiner.ControlAddresses, nil, big.Zero(),
+		&builtin.MinerAddrs{Owner: h.owner, Worker: h.worker, ControlAddrs: controls}, exitcode.Ok)
+}
+
+func (h *askRegActorTestHarness) publishAsk(rt *mock.Runtime, caller addr.Address, params *askreg.PublishAskParams) {
+	rt.SetCaller(caller, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+	h.expectControlAddresses(rt)
+	ret := rt.Call(h.PublishAsk, params)
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *askRegActorTestHarness) getAsk(rt *mock.Runtime) *askreg.StorageAsk {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.GetAsk, &h.provider).(*askreg.GetAskReturn)
+	rt.Verify()
+	return ret.Ask
+}
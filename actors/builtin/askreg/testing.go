@@ -0,0 +1,44 @@
+package askreg
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+type StateSummary struct {
+	Asks map[addr.Address]StorageAsk
+}
+
+// Checks internal invariants of storage ask registry state.
+func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.MessageAccumulator) {
+	acc := &builtin.MessageAccumulator{}
+
+	allAsks := map[addr.Address]StorageAsk{}
+	if asks, err := adt.AsMap(store, st.Asks, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading asks: %v", err)
+	} else {
+		var ask StorageAsk
+		err = asks.ForEach(&ask, func(key string) error {
+			provider, err := addr.NewFromBytes([]byte(key))
+			if err != nil {
+				return err
+			}
+			acc.Require(provider.Protocol() == addr.ID, "provider %v should have ID protocol", provider)
+			acc.Require(ask.Price.GreaterThanEqual(big.Zero()), "provider %v ask price %v is negative", provider, ask.Price)
+			acc.Require(ask.VerifiedPrice.GreaterThanEqual(big.Zero()), "provider %v ask verified price %v is negative", provider, ask.VerifiedPrice)
+			acc.Require(ask.MinPieceSize <= ask.MaxPieceSize, "provider %v ask min piece size %d exceeds max %d",
+				provider, ask.MinPieceSize, ask.MaxPieceSize)
+			acc.Require(ask.Timestamp < ask.Expiry, "provider %v ask expiry %d not after timestamp %d", provider, ask.Expiry, ask.Timestamp)
+			allAsks[provider] = ask
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating asks")
+	}
+
+	return &StateSummary{
+		Asks: allAsks,
+	}, acc
+}
@@ -9,18 +9,19 @@ import (
 
 // The built-in actor code IDs
 var (
-	SystemActorCodeID           cid.Cid
-	InitActorCodeID             cid.Cid
-	CronActorCodeID             cid.Cid
-	AccountActorCodeID          cid.Cid
-	StoragePowerActorCodeID     cid.Cid
-	StorageMinerActorCodeID     cid.Cid
-	StorageMarketActorCodeID    cid.Cid
-	PaymentChannelActorCodeID   cid.Cid
-	MultisigActorCodeID         cid.Cid
-	RewardActorCodeID           cid.Cid
-	VerifiedRegistryActorCodeID cid.Cid
-	CallerTypesSignable         []cid.Cid
+	SystemActorCodeID             cid.Cid
+	InitActorCodeID               cid.Cid
+	CronActorCodeID               cid.Cid
+	AccountActorCodeID            cid.Cid
+	StoragePowerActorCodeID       cid.Cid
+	StorageMinerActorCodeID       cid.Cid
+	StorageMarketActorCodeID      cid.Cid
+	PaymentChannelActorCodeID     cid.Cid
+	MultisigActorCodeID           cid.Cid
+	RewardActorCodeID             cid.Cid
+	VerifiedRegistryActorCodeID   cid.Cid
+	StorageAskRegistryActorCodeID cid.Cid
+	CallerTypesSignable           []cid.Cid
 )
 
 var builtinActors map[cid.Cid]*actorInfo
@@ -35,17 +36,18 @@ func init() {
 	builtinActors = make(map[cid.Cid]*actorInfo)
 
 	for id, info := range map[*cid.Cid]*actorInfo{ //nolint:nomaprange
-		&SystemActorCodeID:           {name: "fil/3/system"},
-		&InitActorCodeID:             {name: "fil/3/init"},
-		&CronActorCodeID:             {name: "fil/3/cron"},
-		&StoragePowerActorCodeID:     {name: "fil/3/storagepower"},
-		&StorageMinerActorCodeID:     {name: "fil/3/storageminer"},
-		&StorageMarketActorCodeID:    {name: "fil/3/storagemarket"},
-		&PaymentChannelActorCodeID:   {name: "fil/3/paymentchannel"},
-		&RewardActorCodeID:           {name: "fil/3/reward"},
-		&VerifiedRegistryActorCodeID: {name: "fil/3/verifiedregistry"},
-		&AccountActorCodeID:          {name: "fil/3/account", signer: true},
-		&MultisigActorCodeID:         {name: "fil/3/multisig", signer: true},
+		&SystemActorCodeID:             {name: "fil/3/system"},
+		&InitActorCodeID:               {name: "fil/3/init"},
+		&CronActorCodeID:               {name: "fil/3/cron"},
+		&StoragePowerActorCodeID:       {name: "fil/3/storagepower"},
+		&StorageMinerActorCodeID:       {name: "fil/3/storageminer"},
+		&StorageMarketActorCodeID:      {name: "fil/3/storagemarket"},
+		&PaymentChannelActorCodeID:     {name: "fil/3/paymentchannel"},
+		&RewardActorCodeID:             {name: "fil/3/reward"},
+		&VerifiedRegistryActorCodeID:   {name: "fil/3/verifiedregistry"},
+		&StorageAskRegistryActorCodeID: {name: "fil/3/storageaskregistry"},
+		&AccountActorCodeID:            {name: "fil/3/account", signer: true},
+		&MultisigActorCodeID:           {name: "fil/3/multisig", signer: true},
 	} {
 		c, err := builder.Sum([]byte(info.name))
 		if err != nil {
@@ -1,8 +1,10 @@
 package cron
 
 import (
+	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
 	cron0 "github.com/filecoin-project/specs-actors/actors/builtin/cron"
 	"github.com/ipfs/go-cid"
 
@@ -17,6 +19,10 @@ func (a Actor) Exports() []interface{} {
 	return []interface{}{
 		builtin.MethodConstructor: a.Constructor,
 		2:                         a.EpochTick,
+		3:                         a.RegisterEntry,
+		4:                         a.RemoveEntry,
+		5:                         a.SetGovernor,
+		6:                         a.ListEntries,
 	}
 }
 
@@ -34,10 +40,10 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
-//type ConstructorParams struct {
-//	Entries []Entry
-//}
-type ConstructorParams = cron0.ConstructorParams
+type ConstructorParams struct {
+	Entries  []EntryParam
+	Governor *addr.Address // Actor permitted to manage the cron table alongside the system actor, if any
+}
 
 type EntryParam = cron0.Entry
 
@@ -45,22 +51,192 @@ func (a Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *abi.E
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
 	entries := make([]Entry, len(params.Entries))
 	for i, e := range params.Entries {
-		entries[i] = Entry(e) // Identical
+		entries[i] = Entry{Receiver: e.Receiver, MethodNum: e.MethodNum, Interval: 1}
+	}
+	var governor *addr.Address
+	if params.Governor != nil {
+		resolved, ok := rt.ResolveAddress(*params.Governor)
+		if !ok {
+			rt.Abortf(exitcode.ErrNotFound, "failed to resolve governor %v", *params.Governor)
+		}
+		governor = &resolved
 	}
-	rt.StateCreate(ConstructState(entries))
+	rt.StateCreate(ConstructState(entries, governor))
 	return nil
 }
 
 // Invoked by the system after all other messages in the epoch have been processed.
+// Dispatches the entries due in the current epoch and records their failures, then removes the entries which
+// will never again be due.
 func (a Actor) EpochTick(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
+	currEpoch := rt.CurrEpoch()
 
 	var st State
 	rt.StateReadonly(&st)
+	var dispatched []dispatchResult
+	expired := false
 	for _, entry := range st.Entries {
-		_ = rt.Send(entry.Receiver, entry.MethodNum, nil, abi.NewTokenAmount(0), &builtin.Discard{})
-		// Any error and return value are ignored.
+		if entry.IsDue(currEpoch) {
+			code := rt.Send(entry.Receiver, entry.MethodNum, nil, abi.NewTokenAmount(0), &builtin.Discard{})
+			// A failure is recorded but does not prevent dispatch of other entries. Any return value is ignored.
+			if !code.IsSuccess() || entry.Failures.Consecutive > 0 {
+				dispatched = append(dispatched, dispatchResult{entry.Receiver, entry.MethodNum, code})
+			}
+		}
+		expired = expired || entry.IsExpired(currEpoch)
+	}
+
+	if len(dispatched) > 0 || expired {
+		// Entries may have changed during dispatch, so they are found again in the current state.
+		rt.StateTransaction(&st, func() {
+			for _, d := range dispatched {
+				if i := st.FindEntry(d.receiver, d.methodNum); i >= 0 {
+					st.Entries[i].Failures.Record(currEpoch, d.code)
+				}
+			}
+			remaining := st.Entries[:0]
+			for _, entry := range st.Entries {
+				if !entry.IsExpired(currEpoch) {
+					remaining = append(remaining, entry)
+				}
+			}
+			st.Entries = remaining
+		})
+	}
+	return nil
+}
+
+// The outcome of dispatching an entry which failed or had been failing.
+type dispatchResult struct {
+	receiver  addr.Address
+	methodNum abi.MethodNum
+	code      exitcode.ExitCode
+}
+
+type ListEntriesReturn struct {
+	Entries []Entry
+}
+
+// Returns the cron table, including the failure record of each entry.
+func (a Actor) ListEntries(rt runtime.Runtime, _ *abi.EmptyValue) *ListEntriesReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.StateReadonly(&st)
+	return &ListEntriesReturn{Entries: st.Entries}
+}
+
+type RegisterEntryParams struct {
+	Receiver  addr.Address
+	MethodNum abi.MethodNum
+	Interval  abi.ChainEpoch
+	EndEpoch  abi.ChainEpoch // Zero if the entry does not expire
+}
+
+// Adds an entry to the cron table, or updates the interval and end epoch of an existing entry for the same
+// receiver and method, retaining its failure record. Callable by the system actor or the governor, though
+// only the system actor may update a built-in entry.
+func (a Actor) RegisterEntry(rt runtime.Runtime, params *RegisterEntryParams) *abi.EmptyValue {
+	validateGovernanceCaller(rt)
+	if params.MethodNum <= builtin.MethodConstructor {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid method number %d", params.MethodNum)
 	}
+	if params.Interval <= 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "interval %d must be positive", params.Interval)
+	}
+	if params.EndEpoch != 0 && params.EndEpoch < rt.CurrEpoch() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "end epoch %d is in the past", params.EndEpoch)
+	}
+	receiver, ok := rt.ResolveAddress(params.Receiver)
+	if !ok {
+		rt.Abortf(exitcode.ErrNotFound, "failed to resolve receiver %v", params.Receiver)
+	}
+	validateBuiltInEntryCaller(rt, receiver, params.MethodNum)
 
+	entry := Entry{
+		Receiver:  receiver,
+		MethodNum: params.MethodNum,
+		Interval:  params.Interval,
+		EndEpoch:  params.EndEpoch,
+	}
+	var st State
+	rt.StateTransaction(&st, func() {
+		if i := st.FindEntry(receiver, params.MethodNum); i >= 0 {
+			entry.Failures = st.Entries[i].Failures
+			st.Entries[i] = entry
+			return
+		}
+		if len(st.Entries) >= MaxEntries {
+			rt.Abortf(exitcode.ErrForbidden, "cron table is full with %d entries", len(st.Entries))
+		}
+		st.Entries = append(st.Entries, entry)
+	})
 	return nil
 }
+
+type RemoveEntryParams struct {
+	Receiver  addr.Address
+	MethodNum abi.MethodNum
+}
+
+// Removes the entry for a receiver and method from the cron table. Callable by the system actor or the governor,
+// though only the system actor may remove a built-in entry.
+func (a Actor) RemoveEntry(rt runtime.Runtime, params *RemoveEntryParams) *abi.EmptyValue {
+	validateGovernanceCaller(rt)
+	receiver, ok := rt.ResolveAddress(params.Receiver)
+	if !ok {
+		rt.Abortf(exitcode.ErrNotFound, "failed to resolve receiver %v", params.Receiver)
+	}
+	validateBuiltInEntryCaller(rt, receiver, params.MethodNum)
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		i := st.FindEntry(receiver, params.MethodNum)
+		if i < 0 {
+			rt.Abortf(exitcode.ErrNotFound, "no entry for method %d on %v", params.MethodNum, receiver)
+		}
+		st.Entries = append(st.Entries[:i], st.Entries[i+1:]...)
+	})
+	return nil
+}
+
+type SetGovernorParams struct {
+	Governor *addr.Address // Nil to leave the cron table to the system actor alone
+}
+
+// Replaces the governor. Callable by the system actor or the current governor.
+func (a Actor) SetGovernor(rt runtime.Runtime, params *SetGovernorParams) *abi.EmptyValue {
+	validateGovernanceCaller(rt)
+	var governor *addr.Address
+	if params.Governor != nil {
+		resolved, ok := rt.ResolveAddress(*params.Governor)
+		if !ok {
+			rt.Abortf(exitcode.ErrNotFound, "failed to resolve governor %v", *params.Governor)
+		}
+		governor = &resolved
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		st.Governor = governor
+	})
+	return nil
+}
+
+func validateGovernanceCaller(rt runtime.Runtime) {
+	var st State
+	rt.StateReadonly(&st)
+	if st.Governor != nil {
+		rt.ValidateImmediateCallerIs(builtin.SystemActorAddr, *st.Governor)
+	} else {
+		rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
+	}
+}
+
+// Aborts unless the caller is the system actor if the receiver and method are those of a built-in entry,
+// upon which the chain's operation depends.
+func validateBuiltInEntryCaller(rt runtime.Runtime, receiver addr.Address, methodNum abi.MethodNum) {
+	if rt.Caller() != builtin.SystemActorAddr && IsBuiltInEntry(receiver, methodNum) {
+		rt.Abortf(exitcode.ErrForbidden, "built-in entry for method %d on %v may only be changed by the system actor", methodNum, receiver)
+	}
+}
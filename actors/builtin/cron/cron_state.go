@@ -3,21 +3,85 @@ package cron
 import (
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 )
 
+// Maximum number of entries in the cron table, bounding the work done in each epoch tick.
+const MaxEntries = 64
+
 type State struct {
 	Entries []Entry
+	// Actor permitted, in addition to the system actor, to register and remove entries and to replace itself.
+	// Nil if only the system actor may.
+	Governor *addr.Address
 }
 
 type Entry struct {
-	Receiver  addr.Address  // The actor to call (must be an ID-address)
-	MethodNum abi.MethodNum // The method number to call (must accept empty parameters)
+	Receiver  addr.Address   // The actor to call (must be an ID-address)
+	MethodNum abi.MethodNum  // The method number to call (must accept empty parameters)
+	Interval  abi.ChainEpoch // The entry is due at epochs that are a multiple of the interval (must be positive)
+	EndEpoch  abi.ChainEpoch // The last epoch at which the entry is due, or zero if there is none
+	Failures  FailureRecord  // The outcome of recent dispatches that failed
+}
+
+// Records an entry's failing dispatches.
+type FailureRecord struct {
+	Consecutive  uint64            // The number of consecutive dispatches that failed, reset by a success
+	LastEpoch    abi.ChainEpoch    // The epoch of the last failing dispatch (meaningful if Total is non-zero)
+	LastExitCode exitcode.ExitCode // The exit code of the last failing dispatch
+	Total        uint64            // The number of dispatches that have failed since the entry was registered
+}
+
+// Updates the record with the outcome of a dispatch, returning whether it changed.
+func (r *FailureRecord) Record(epoch abi.ChainEpoch, code exitcode.ExitCode) bool {
+	if code.IsSuccess() {
+		if r.Consecutive == 0 {
+			return false
+		}
+		r.Consecutive = 0
+		return true
+	}
+	r.Consecutive++
+	r.Total++
+	r.LastEpoch = epoch
+	r.LastExitCode = code
+	return true
+}
+
+func ConstructState(entries []Entry, governor *addr.Address) *State {
+	return &State{Entries: entries, Governor: governor}
 }
 
-func ConstructState(entries []Entry) *State {
-	return &State{Entries: entries}
+// Checks whether an entry is to be dispatched in an epoch.
+func (e *Entry) IsDue(epoch abi.ChainEpoch) bool {
+	return epoch%e.Interval == 0 && (e.EndEpoch == 0 || epoch <= e.EndEpoch)
+}
+
+// Checks whether an entry will never again be due after an epoch.
+func (e *Entry) IsExpired(epoch abi.ChainEpoch) bool {
+	return e.EndEpoch != 0 && epoch >= e.EndEpoch
+}
+
+// Returns the index of the entry calling a method on a receiver, or -1 if there is none.
+func (st *State) FindEntry(receiver addr.Address, methodNum abi.MethodNum) int {
+	for i, e := range st.Entries {
+		if e.Receiver == receiver && e.MethodNum == methodNum {
+			return i
+		}
+	}
+	return -1
+}
+
+// Checks whether a receiver and method are those of one of the built-in entries.
+func IsBuiltInEntry(receiver addr.Address, methodNum abi.MethodNum) bool {
+	for _, e := range BuiltInEntries() {
+		if e.Receiver == receiver && e.MethodNum == methodNum {
+			return true
+		}
+	}
+	return false
 }
 
 // The default entries to install in the cron actor's state at genesis.
@@ -26,10 +90,12 @@ func BuiltInEntries() []Entry {
 		{
 			Receiver:  builtin.StoragePowerActorAddr,
 			MethodNum: builtin.MethodsPower.OnEpochTickEnd,
+			Interval:  1,
 		},
 		{
 			Receiver:  builtin.StorageMarketActorAddr,
 			MethodNum: builtin.MethodsMarket.CronTick,
+			Interval:  1,
 		},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/exitcode"
@@ -53,12 +54,26 @@ func TestConstructor(t *testing.T) {
 		rt.GetState(&st)
 		expectedEntries := make([]cron.Entry, len(entryParams))
 		for i, e := range entryParams {
-			expectedEntries[i] = cron.Entry(e)
+			expectedEntries[i] = cron.Entry{Receiver: e.Receiver, MethodNum: e.MethodNum, Interval: 1}
 		}
 		assert.Equal(t, expectedEntries, st.Entries)
 
 		actor.checkState(rt)
 	})
+
+	t.Run("construct with governor", func(t *testing.T) {
+		rt := builder.Build(t)
+
+		governor := tutil.NewIDAddr(t, 101)
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.Call(actor.Constructor, &cron.ConstructorParams{Governor: &governor})
+		rt.Verify()
+
+		var st cron.State
+		rt.GetState(&st)
+		assert.Equal(t, &governor, st.Governor)
+		actor.checkState(rt)
+	})
 }
 
 func TestEpochTick(t *testing.T) {
@@ -101,6 +116,183 @@ func TestEpochTick(t *testing.T) {
 	})
 }
 
+func TestEntryRegistration(t *testing.T) {
+	actor := cronHarness{cron.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	governor := tutil.NewIDAddr(t, 101)
+	auditor := tutil.NewIDAddr(t, 1001)
+	builder := mock.NewBuilder(context.Background(), receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	t.Run("dispatches entries due in the epoch", func(t *testing.T) {
+		rt := builder.Build(t)
+		entry := cron.EntryParam{Receiver: tutil.NewIDAddr(t, 1002), MethodNum: abi.MethodNum(1002)}
+		actor.constructAndVerify(rt, entry)
+		actor.registerEntry(rt, builtin.SystemActorAddr, &cron.RegisterEntryParams{
+			Receiver:  auditor,
+			MethodNum: 5,
+			Interval:  3,
+			EndEpoch:  6,
+		})
+
+		for epoch := abi.ChainEpoch(1); epoch <= 9; epoch++ {
+			rt.SetEpoch(epoch)
+			rt.ExpectSend(entry.Receiver, entry.MethodNum, nil, big.Zero(), nil, exitcode.Ok)
+			if epoch == 3 || epoch == 6 {
+				rt.ExpectSend(auditor, 5, nil, big.Zero(), nil, exitcode.Ok)
+			}
+			actor.epochTickAndVerify(rt)
+		}
+
+		// The expired entry was removed.
+		var st cron.State
+		rt.GetState(&st)
+		assert.Len(t, st.Entries, 1)
+		assert.Equal(t, -1, st.FindEntry(auditor, 5))
+		actor.checkState(rt)
+	})
+
+	t.Run("register updates and remove deletes entry", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		actor.registerEntry(rt, builtin.SystemActorAddr, &cron.RegisterEntryParams{Receiver: auditor, MethodNum: 5, Interval: 2})
+		actor.registerEntry(rt, builtin.SystemActorAddr, &cron.RegisterEntryParams{Receiver: auditor, MethodNum: 5, Interval: 4})
+
+		var st cron.State
+		rt.GetState(&st)
+		assert.Equal(t, []cron.Entry{{Receiver: auditor, MethodNum: 5, Interval: 4}}, st.Entries)
+
+		actor.removeEntry(rt, builtin.SystemActorAddr, &cron.RemoveEntryParams{Receiver: auditor, MethodNum: 5})
+		rt.GetState(&st)
+		assert.Empty(t, st.Entries)
+
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.ExpectAbort(exitcode.ErrNotFound, func() {
+			rt.Call(actor.RemoveEntry, &cron.RemoveEntryParams{Receiver: auditor, MethodNum: 5})
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("governor manages entries", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(governor, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.RegisterEntry, &cron.RegisterEntryParams{Receiver: auditor, MethodNum: 5, Interval: 1})
+		})
+
+		actor.setGovernor(rt, builtin.SystemActorAddr, &governor)
+		actor.registerEntry(rt, governor, &cron.RegisterEntryParams{Receiver: auditor, MethodNum: 5, Interval: 1})
+		actor.removeEntry(rt, governor, &cron.RemoveEntryParams{Receiver: auditor, MethodNum: 5})
+
+		// The governor may hand over to another.
+		other := tutil.NewIDAddr(t, 102)
+		actor.setGovernor(rt, governor, &other)
+		rt.SetCaller(governor, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr, other)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.RegisterEntry, &cron.RegisterEntryParams{Receiver: auditor, MethodNum: 5, Interval: 1})
+		})
+		actor.setGovernor(rt, builtin.SystemActorAddr, nil)
+		actor.checkState(rt)
+	})
+
+	t.Run("governor cannot change built-in entries", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		for _, e := range cron.BuiltInEntries() {
+			actor.registerEntry(rt, builtin.SystemActorAddr, &cron.RegisterEntryParams{Receiver: e.Receiver, MethodNum: e.MethodNum, Interval: e.Interval})
+		}
+		actor.setGovernor(rt, builtin.SystemActorAddr, &governor)
+
+		power := cron.BuiltInEntries()[0]
+		actor.expectGovernanceCaller(rt, governor)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "built-in entry", func() {
+			rt.Call(actor.RegisterEntry, &cron.RegisterEntryParams{Receiver: power.Receiver, MethodNum: power.MethodNum, Interval: 10})
+		})
+		actor.expectGovernanceCaller(rt, governor)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "built-in entry", func() {
+			rt.Call(actor.RemoveEntry, &cron.RemoveEntryParams{Receiver: power.Receiver, MethodNum: power.MethodNum})
+		})
+
+		var st cron.State
+		rt.GetState(&st)
+		assert.Equal(t, cron.BuiltInEntries(), st.Entries)
+
+		// The system actor may still change them.
+		actor.removeEntry(rt, builtin.SystemActorAddr, &cron.RemoveEntryParams{Receiver: power.Receiver, MethodNum: power.MethodNum})
+		actor.checkState(rt)
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetEpoch(10)
+
+		for _, params := range []*cron.RegisterEntryParams{
+			{Receiver: auditor, MethodNum: builtin.MethodConstructor, Interval: 1},
+			{Receiver: auditor, MethodNum: 5, Interval: 0},
+			{Receiver: auditor, MethodNum: 5, Interval: 1, EndEpoch: 9},
+		} {
+			rt.SetCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+			rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+			rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+				rt.Call(actor.RegisterEntry, params)
+			})
+		}
+
+		for i := 0; i < cron.MaxEntries; i++ {
+			actor.registerEntry(rt, builtin.SystemActorAddr, &cron.RegisterEntryParams{Receiver: auditor, MethodNum: abi.MethodNum(2 + i), Interval: 1})
+		}
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "cron table is full", func() {
+			rt.Call(actor.RegisterEntry, &cron.RegisterEntryParams{Receiver: auditor, MethodNum: 1000, Interval: 1})
+		})
+		actor.checkState(rt)
+	})
+}
+
+func TestEntryFailures(t *testing.T) {
+	actor := cronHarness{cron.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	builder := mock.NewBuilder(context.Background(), receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+	power := cron.EntryParam{Receiver: tutil.NewIDAddr(t, 1001), MethodNum: abi.MethodNum(1001)}
+	market := cron.EntryParam{Receiver: tutil.NewIDAddr(t, 1002), MethodNum: abi.MethodNum(1002)}
+
+	rt := builder.Build(t)
+	actor.constructAndVerify(rt, power, market)
+	tick := func(epoch abi.ChainEpoch, powerCode, marketCode exitcode.ExitCode) {
+		rt.SetEpoch(epoch)
+		rt.ExpectSend(power.Receiver, power.MethodNum, nil, big.Zero(), nil, powerCode)
+		rt.ExpectSend(market.Receiver, market.MethodNum, nil, big.Zero(), nil, marketCode)
+		actor.epochTickAndVerify(rt)
+	}
+
+	// A failing entry does not prevent dispatch of the others.
+	tick(1, exitcode.ErrIllegalState, exitcode.Ok)
+	tick(2, exitcode.ErrInsufficientFunds, exitcode.Ok)
+	entries := actor.listEntries(rt)
+	assert.Equal(t, cron.FailureRecord{Consecutive: 2, LastEpoch: 2, LastExitCode: exitcode.ErrInsufficientFunds, Total: 2}, entries[0].Failures)
+	assert.Equal(t, cron.FailureRecord{}, entries[1].Failures)
+	actor.checkState(rt)
+
+	// A success resets the consecutive count but retains the last failure.
+	tick(3, exitcode.Ok, exitcode.ErrForbidden)
+	entries = actor.listEntries(rt)
+	assert.Equal(t, cron.FailureRecord{Consecutive: 0, LastEpoch: 2, LastExitCode: exitcode.ErrInsufficientFunds, Total: 2}, entries[0].Failures)
+	assert.Equal(t, cron.FailureRecord{Consecutive: 1, LastEpoch: 3, LastExitCode: exitcode.ErrForbidden, Total: 1}, entries[1].Failures)
+
+	// Re-registering an entry retains its record.
+	actor.registerEntry(rt, builtin.SystemActorAddr, &cron.RegisterEntryParams{Receiver: market.Receiver, MethodNum: market.MethodNum, Interval: 2})
+	entries = actor.listEntries(rt)
+	assert.Equal(t, abi.ChainEpoch(2), entries[1].Interval)
+	assert.Equal(t, uint64(1), entries[1].Failures.Consecutive)
+	actor.checkState(rt)
+}
+
 type cronHarness struct {
 	cron.Actor
 	t testing.TB
@@ -121,6 +313,53 @@ func (h *cronHarness) epochTickAndVerify(rt *mock.Runtime) {
 	rt.Verify()
 }
 
+func (h *cronHarness) registerEntry(rt *mock.Runtime, caller addr.Address, params *cron.RegisterEntryParams) {
+	h.expectGovernanceCaller(rt, caller)
+	ret := rt.Call(h.RegisterEntry, params)
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *cronHarness) removeEntry(rt *mock.Runtime, caller addr.Address, params *cron.RemoveEntryParams) {
+	h.expectGovernanceCaller(rt, caller)
+	ret := rt.Call(h.RemoveEntry, params)
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *cronHarness) setGovernor(rt *mock.Runtime, caller addr.Address, governor *addr.Address) {
+	h.expectGovernanceCaller(rt, caller)
+	ret := rt.Call(h.SetGovernor, &cron.SetGovernorParams{Governor: governor})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+
+	var st cron.State
+	rt.GetState(&st)
+	assert.Equal(h.t, governor, st.Governor)
+}
+
+func (h *cronHarness) expectGovernanceCaller(rt *mock.Runtime, caller addr.Address) {
+	var st cron.State
+	rt.GetState(&st)
+	if st.Governor != nil {
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr, *st.Governor)
+	} else {
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+	}
+	code := builtin.SystemActorCodeID
+	if caller != builtin.SystemActorAddr {
+		code = builtin.MultisigActorCodeID
+	}
+	rt.SetCaller(caller, code)
+}
+
+func (h *cronHarness) listEntries(rt *mock.Runtime) []cron.Entry {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.ListEntries, nil).(*cron.ListEntriesReturn)
+	rt.Verify()
+	return ret.Entries
+}
+
 func (h *cronHarness) checkState(rt *mock.Runtime) {
 	var st cron.State
 	rt.GetState(&st)
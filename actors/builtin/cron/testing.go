@@ -19,6 +19,15 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	for i, e := range st.Entries {
 		acc.Require(e.Receiver.Protocol() == address.ID, "entry %d receiver address %v must be ID protocol", i, e.Receiver)
 		acc.Require(e.MethodNum > 0, "entry %d has invalid method number %d", i, e.MethodNum)
+		acc.Require(e.Interval > 0, "entry %d has non-positive interval %d", i, e.Interval)
+		acc.Require(e.EndEpoch >= 0, "entry %d has negative end epoch %d", i, e.EndEpoch)
+		acc.Require(e.Failures.Consecutive <= e.Failures.Total, "entry %d has %d consecutive failures but %d in total",
+			i, e.Failures.Consecutive, e.Failures.Total)
+		acc.Require(e.Failures.Total == 0 || !e.Failures.LastExitCode.IsSuccess(), "entry %d last failed with success code", i)
+		acc.Require(st.FindEntry(e.Receiver, e.MethodNum) == i, "entry %d duplicates method %d on %v", i, e.MethodNum, e.Receiver)
+	}
+	if st.Governor != nil {
+		acc.Require(st.Governor.Protocol() == address.ID, "governor address %v must be ID protocol", st.Governor)
 	}
 	return cronSummary, acc
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/askreg"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/cron"
 	init_ "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/market"
@@ -39,6 +40,7 @@ func TestKnownActors(t *testing.T) {
 		methods interface{}
 	}{
 		{account.Actor{}, builtin.AccountActorCodeID, builtin.MethodsAccount},
+		{askreg.Actor{}, builtin.StorageAskRegistryActorCodeID, builtin.MethodsStorageAskRegistry},
 		{cron.Actor{}, builtin.CronActorCodeID, builtin.MethodsCron},
 		{init_.Actor{}, builtin.InitActorCodeID, builtin.MethodsInit},
 		{market.Actor{}, builtin.StorageMarketActorCodeID, builtin.MethodsMarket},
@@ -13,7 +13,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{131}
+var lengthBufState = []byte{134}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -49,6 +49,25 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 	if _, err := io.WriteString(w, string(t.NetworkName)); err != nil {
 		return err
 	}
+
+	// t.RobustAddresses (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.RobustAddresses); err != nil {
+		return xerrors.Errorf("failed to write cid field t.RobustAddresses: %w", err)
+	}
+
+	// t.Aliases (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Aliases); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Aliases: %w", err)
+	}
+
+	// t.ActorAliases (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.ActorAliases); err != nil {
+		return xerrors.Errorf("failed to write cid field t.ActorAliases: %w", err)
+	}
+
 	return nil
 }
 
@@ -66,7 +85,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 3 {
+	if extra != 6 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -106,5 +125,327 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 
 		t.NetworkName = string(sval)
 	}
+	// t.RobustAddresses (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.RobustAddresses: %w", err)
+		}
+
+		t.RobustAddresses = c
+
+	}
+	// t.Aliases (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Aliases: %w", err)
+		}
+
+		t.Aliases = c
+
+	}
+	// t.ActorAliases (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.ActorAliases: %w", err)
+		}
+
+		t.ActorAliases = c
+
+	}
+	return nil
+}
+
+var lengthBufActorAlias = []byte{129}
+
+func (t *ActorAlias) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufActorAlias); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Alias (string) (string)
+	if len(t.Alias) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.Alias was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Alias))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, string(t.Alias)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ActorAlias) UnmarshalCBOR(r io.Reader) error {
+	*t = ActorAlias{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Alias (string) (string)
+
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+
+		t.Alias = string(sval)
+	}
+	return nil
+}
+
+var lengthBufExec2Params = []byte{131}
+
+func (t *Exec2Params) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufExec2Params); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.CodeCID (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.CodeCID); err != nil {
+		return xerrors.Errorf("failed to write cid field t.CodeCID: %w", err)
+	}
+
+	// t.ConstructorParams ([]uint8) (slice)
+	if len(t.ConstructorParams) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.ConstructorParams was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.ConstructorParams))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.ConstructorParams[:]); err != nil {
+		return err
+	}
+
+	// t.Salt ([]uint8) (slice)
+	if len(t.Salt) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.Salt was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Salt))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.Salt[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *Exec2Params) UnmarshalCBOR(r io.Reader) error {
+	*t = Exec2Params{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.CodeCID (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.CodeCID: %w", err)
+		}
+
+		t.CodeCID = c
+
+	}
+	// t.ConstructorParams ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.ConstructorParams: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+
+	if extra > 0 {
+		t.ConstructorParams = make([]uint8, extra)
+	}
+
+	if _, err := io.ReadFull(br, t.ConstructorParams[:]); err != nil {
+		return err
+	}
+	// t.Salt ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.Salt: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+
+	if extra > 0 {
+		t.Salt = make([]uint8, extra)
+	}
+
+	if _, err := io.ReadFull(br, t.Salt[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+var lengthBufSetAliasParams = []byte{129}
+
+func (t *SetAliasParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSetAliasParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Alias (string) (string)
+	if len(t.Alias) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.Alias was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Alias))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, string(t.Alias)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *SetAliasParams) UnmarshalCBOR(r io.Reader) error {
+	*t = SetAliasParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Alias (string) (string)
+
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+
+		t.Alias = string(sval)
+	}
+	return nil
+}
+
+var lengthBufUpdateAccountAddressParams = []byte{129}
+
+func (t *UpdateAccountAddressParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufUpdateAccountAddressParams); err != nil {
+		return err
+	}
+
+	// t.NewAddress (address.Address) (struct)
+	if err := t.NewAddress.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *UpdateAccountAddressParams) UnmarshalCBOR(r io.Reader) error {
+	*t = UpdateAccountAddressParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewAddress (address.Address) (struct)
+
+	{
+
+		if err := t.NewAddress.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewAddress: %w", err)
+		}
+
+	}
 	return nil
 }
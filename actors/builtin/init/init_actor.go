@@ -7,6 +7,7 @@ import (
 	"github.com/filecoin-project/go-state-types/exitcode"
 	init0 "github.com/filecoin-project/specs-actors/actors/builtin/init"
 	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/runtime"
@@ -21,6 +22,10 @@ func (a Actor) Exports() []interface{} {
 	return []interface{}{
 		builtin.MethodConstructor: a.Constructor,
 		2:                         a.Exec,
+		3:                         a.Exec2,
+		4:                         a.SetAlias,
+		5:                         a.ClearAlias,
+		6:                         a.UpdateAccountAddress,
 	}
 }
 
@@ -75,6 +80,148 @@ func (a Actor) Exec(rt runtime.Runtime, params *ExecParams) *ExecReturn {
 	// a different ID.
 	uniqueAddress := rt.NewActorAddress()
 
+	return createActor(rt, params.CodeCID, params.ConstructorParams, uniqueAddress)
+}
+
+type Exec2Params struct {
+	CodeCID           cid.Cid `checked:"true"` // invalid CIDs won't get committed to the state tree
+	ConstructorParams []byte
+	Salt              []byte
+}
+
+// Maximum size of the salt from which Exec2 derives an actor's address.
+const MaxExec2SaltSize = 32
+
+// Creates an actor as Exec does, but at a re-org-stable address derived from the caller, a salt and
+// the constructor params, rather than from the message. The address may thus be computed in advance
+// with ComputeExec2Address.
+// Fails if an actor already exists at the address.
+func (a Actor) Exec2(rt runtime.Runtime, params *Exec2Params) *ExecReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	callerCodeCID, ok := rt.GetActorCodeCID(rt.Caller())
+	builtin.RequireState(rt, ok, "no code for caller at %s", rt.Caller())
+	if !canExec(callerCodeCID, params.CodeCID) {
+		rt.Abortf(exitcode.ErrForbidden, "caller type %v cannot exec actor type %v", callerCodeCID, params.CodeCID)
+	}
+	if len(params.Salt) > MaxExec2SaltSize {
+		rt.Abortf(exitcode.ErrIllegalArgument, "salt must be at most %d bytes long", MaxExec2SaltSize)
+	}
+
+	uniqueAddress, err := ComputeExec2Address(rt.Caller(), params.Salt, rt.HashBlake2b(params.ConstructorParams))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to compute actor address")
+
+	var st State
+	rt.StateReadonly(&st)
+	_, found, err := st.ResolveAddress(adt.AsStore(rt), uniqueAddress)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve address %v", uniqueAddress)
+	if found {
+		rt.Abortf(exitcode.ErrForbidden, "actor already exists at %v", uniqueAddress)
+	}
+
+	return createActor(rt, params.CodeCID, params.ConstructorParams, uniqueAddress)
+}
+
+// Prefix of the data hashed to compute an Exec2 address, distinguishing it from the data hashed by NewActorAddress.
+const Exec2AddressDomainSeparation = "fil_exec2:"
+
+// Computes the re-org-stable address of an actor created with Exec2 by the actor with ID address caller,
+// with a salt and constructor params whose Blake2b-256 hash is paramsHash.
+func ComputeExec2Address(caller addr.Address, salt []byte, paramsHash [32]byte) (addr.Address, error) {
+	if caller.Protocol() != addr.ID {
+		return addr.Undef, xerrors.Errorf("caller %v must be an ID address", caller)
+	}
+	// The caller's address is self-delimiting and the hash has fixed length, so the salt follows unambiguously.
+	data := []byte(Exec2AddressDomainSeparation)
+	data = append(data, caller.Bytes()...)
+	data = append(data, paramsHash[:]...)
+	data = append(data, salt...)
+	return addr.NewActorAddress(data)
+}
+
+type SetAliasParams struct {
+	Alias string
+}
+
+// Maximum length in bytes of an actor alias.
+const MaxAliasLength = 64
+
+// Assigns the caller a human-readable alias, replacing any alias it already holds.
+// An alias consists of lowercase letters, digits, '-' and '_', and is held by at most one actor.
+func (a Actor) SetAlias(rt runtime.Runtime, params *SetAliasParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	if err := validateAlias(params.Alias); err != nil {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid alias: %v", err)
+	}
+	callerID, err := addr.IDFromAddress(rt.Caller())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get ID for caller %v", rt.Caller())
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		set, err := st.SetAlias(adt.AsStore(rt), abi.ActorID(callerID), params.Alias)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set alias %s", params.Alias)
+		if !set {
+			rt.Abortf(exitcode.ErrForbidden, "alias %s is held by another actor", params.Alias)
+		}
+	})
+	return nil
+}
+
+// Removes the alias held by the caller.
+func (a Actor) ClearAlias(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	callerID, err := addr.IDFromAddress(rt.Caller())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get ID for caller %v", rt.Caller())
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		cleared, err := st.ClearAlias(adt.AsStore(rt), abi.ActorID(callerID))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to clear alias")
+		if !cleared {
+			rt.Abortf(exitcode.ErrNotFound, "caller %v holds no alias", rt.Caller())
+		}
+	})
+	return nil
+}
+
+type UpdateAccountAddressParams struct {
+	NewAddress addr.Address
+}
+
+// Maps the calling account's new key address to its ID in place of its previous one, after the account
+// has rotated its key. The previous key address no longer resolves to the account.
+func (a Actor) UpdateAccountAddress(rt runtime.Runtime, params *UpdateAccountAddressParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.AccountActorCodeID)
+	if params.NewAddress.Protocol() != addr.SECP256K1 && params.NewAddress.Protocol() != addr.BLS {
+		rt.Abortf(exitcode.ErrIllegalArgument, "new address must use BLS or SECP protocol, got %v", params.NewAddress.Protocol())
+	}
+	callerID, err := addr.IDFromAddress(rt.Caller())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get ID for caller %v", rt.Caller())
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		remapped, err := st.RemapAddress(adt.AsStore(rt), abi.ActorID(callerID), params.NewAddress)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to remap address %v", params.NewAddress)
+		if !remapped {
+			rt.Abortf(exitcode.ErrForbidden, "address %v is in use by another actor", params.NewAddress)
+		}
+	})
+	return nil
+}
+
+func validateAlias(alias string) error {
+	if len(alias) == 0 || len(alias) > MaxAliasLength {
+		return xerrors.Errorf("length %d must be between 1 and %d", len(alias), MaxAliasLength)
+	}
+	for _, c := range alias {
+		if !('a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_') {
+			return xerrors.Errorf("character %q not allowed", c)
+		}
+	}
+	return nil
+}
+
+// Allocates an ID for a new actor with a re-org-stable address, then creates and constructs it.
+func createActor(rt runtime.Runtime, codeCID cid.Cid, constructorParams []byte, uniqueAddress addr.Address) *ExecReturn {
 	// Allocate an ID for this actor.
 	// Store mapping of pubkey or actor address to actor ID
 	var st State
@@ -86,10 +233,10 @@ func (a Actor) Exec(rt runtime.Runtime, params *ExecParams) *ExecReturn {
 	})
 
 	// Create an empty actor.
-	rt.CreateActor(params.CodeCID, idAddr)
+	rt.CreateActor(codeCID, idAddr)
 
 	// Invoke constructor.
-	code := rt.Send(idAddr, builtin.MethodConstructor, builtin.CBORBytes(params.ConstructorParams), rt.ValueReceived(), &builtin.Discard{})
+	code := rt.Send(idAddr, builtin.MethodConstructor, builtin.CBORBytes(constructorParams), rt.ValueReceived(), &builtin.Discard{})
 	builtin.RequireSuccess(rt, code, "constructor failed")
 
 	return &ExecReturn{IDAddress: idAddr, RobustAddress: uniqueAddress}
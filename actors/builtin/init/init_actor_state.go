@@ -15,18 +15,32 @@ type State struct {
 	AddressMap  cid.Cid // HAMT[addr.Address]abi.ActorID
 	NextID      abi.ActorID
 	NetworkName string
+
+	// Inverse of AddressMap, resolving an actor's ID to the robust address it was allocated for.
+	RobustAddresses cid.Cid // HAMT[abi.ActorID]addr.Address
+
+	// Human-readable names chosen by actors for themselves, at most one per actor.
+	Aliases      cid.Cid // HAMT[alias string]abi.ActorID
+	ActorAliases cid.Cid // HAMT[abi.ActorID]ActorAlias, the inverse of Aliases
+}
+
+type ActorAlias struct {
+	Alias string
 }
 
 func ConstructState(store adt.Store, networkName string) (*State, error) {
-	emptyAddressMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	emptyMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create empty map: %w", err)
 	}
 
 	return &State{
-		AddressMap:  emptyAddressMapCid,
-		NextID:      abi.ActorID(builtin.FirstNonSingletonActorId),
-		NetworkName: networkName,
+		AddressMap:      emptyMapCid,
+		NextID:          abi.ActorID(builtin.FirstNonSingletonActorId),
+		NetworkName:     networkName,
+		RobustAddresses: emptyMapCid,
+		Aliases:         emptyMapCid,
+		ActorAliases:    emptyMapCid,
 	}, nil
 }
 
@@ -82,6 +96,201 @@ func (s *State) MapAddressToNewID(store adt.Store, address addr.Address) (addr.A
 	}
 	s.AddressMap = amr
 
+	rm, err := adt.AsMap(store, s.RobustAddresses, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return addr.Undef, xerrors.Errorf("failed to load robust address map: %w", err)
+	}
+	if err = rm.Put(abi.UIntKey(uint64(actorID)), &address); err != nil {
+		return addr.Undef, xerrors.Errorf("map ID failed to store entry: %w", err)
+	}
+	if s.RobustAddresses, err = rm.Root(); err != nil {
+		return addr.Undef, xerrors.Errorf("failed to get robust address map root: %w", err)
+	}
+
 	idAddr, err := addr.NewIDAddress(uint64(actorID))
 	return idAddr, err
 }
+
+// ResolveRobust resolves an ID address to the robust address it was allocated for, if possible.
+// If the provided address is not an ID address, it is returned as-is.
+//
+// Returns a robust address and `true` if the address was already robust or its ID was found in the mapping.
+// Returns an undefined address and `false` if the ID was not found, as is the case for singleton actors.
+// Returns an error only if state was inconsistent.
+func (s *State) ResolveRobust(store adt.Store, address addr.Address) (addr.Address, bool, error) {
+	if address.Protocol() != addr.ID {
+		return address, true, nil
+	}
+	actorID, err := addr.IDFromAddress(address)
+	if err != nil {
+		return addr.Undef, false, err
+	}
+
+	m, err := adt.AsMap(store, s.RobustAddresses, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return addr.Undef, false, xerrors.Errorf("failed to load robust address map: %w", err)
+	}
+	var robust addr.Address
+	found, err := m.Get(abi.UIntKey(actorID), &robust)
+	if err != nil {
+		return addr.Undef, false, xerrors.Errorf("failed to get from robust address map: %w", err)
+	} else if !found {
+		return addr.Undef, false, nil
+	}
+	return robust, true, nil
+}
+
+// RemapAddress replaces the robust address mapped to an actor's ID with a new one, so that the
+// previous address no longer resolves to the actor.
+// Returns false without modifying state if the new address is already mapped.
+func (s *State) RemapAddress(store adt.Store, actorID abi.ActorID, newAddress addr.Address) (bool, error) {
+	m, err := adt.AsMap(store, s.AddressMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load address map: %w", err)
+	}
+	if found, err := m.Has(abi.AddrKey(newAddress)); err != nil {
+		return false, xerrors.Errorf("failed to get from address map: %w", err)
+	} else if found {
+		return false, nil
+	}
+
+	rm, err := adt.AsMap(store, s.RobustAddresses, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load robust address map: %w", err)
+	}
+	var oldAddress addr.Address
+	if found, err := rm.Get(abi.UIntKey(uint64(actorID)), &oldAddress); err != nil {
+		return false, xerrors.Errorf("failed to get from robust address map: %w", err)
+	} else if found {
+		if err = m.Delete(abi.AddrKey(oldAddress)); err != nil {
+			return false, xerrors.Errorf("failed to delete address %v: %w", oldAddress, err)
+		}
+	}
+
+	id := cbg.CborInt(actorID)
+	if err = m.Put(abi.AddrKey(newAddress), &id); err != nil {
+		return false, xerrors.Errorf("map address failed to store entry: %w", err)
+	}
+	if s.AddressMap, err = m.Root(); err != nil {
+		return false, xerrors.Errorf("failed to get address map root: %w", err)
+	}
+	if err = rm.Put(abi.UIntKey(uint64(actorID)), &newAddress); err != nil {
+		return false, xerrors.Errorf("map ID failed to store entry: %w", err)
+	}
+	if s.RobustAddresses, err = rm.Root(); err != nil {
+		return false, xerrors.Errorf("failed to get robust address map root: %w", err)
+	}
+	return true, nil
+}
+
+// ResolveAlias resolves an alias to the ID address of the actor that holds it.
+// Returns an undefined address and `false` if no actor holds the alias.
+func (s *State) ResolveAlias(store adt.Store, alias string) (addr.Address, bool, error) {
+	m, err := adt.AsMap(store, s.Aliases, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return addr.Undef, false, xerrors.Errorf("failed to load alias map: %w", err)
+	}
+	var actorID cbg.CborInt
+	found, err := m.Get(StringKey(alias), &actorID)
+	if err != nil {
+		return addr.Undef, false, xerrors.Errorf("failed to get from alias map: %w", err)
+	} else if !found {
+		return addr.Undef, false, nil
+	}
+	idAddr, err := addr.NewIDAddress(uint64(actorID))
+	return idAddr, true, err
+}
+
+// GetAlias returns the alias held by an actor, if any.
+func (s *State) GetAlias(store adt.Store, actorID abi.ActorID) (string, bool, error) {
+	m, err := adt.AsMap(store, s.ActorAliases, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return "", false, xerrors.Errorf("failed to load actor alias map: %w", err)
+	}
+	var alias ActorAlias
+	found, err := m.Get(abi.UIntKey(uint64(actorID)), &alias)
+	if err != nil {
+		return "", false, xerrors.Errorf("failed to get from actor alias map: %w", err)
+	}
+	return alias.Alias, found, nil
+}
+
+// SetAlias assigns an alias to an actor, replacing any alias it already holds.
+// Returns false without modifying state if the alias is held by another actor.
+func (s *State) SetAlias(store adt.Store, actorID abi.ActorID, alias string) (bool, error) {
+	holder, found, err := s.ResolveAlias(store, alias)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		holderID, err := addr.IDFromAddress(holder)
+		if err != nil {
+			return false, err
+		}
+		return holderID == uint64(actorID), nil
+	}
+
+	if _, err := s.ClearAlias(store, actorID); err != nil {
+		return false, err
+	}
+
+	aliases, err := adt.AsMap(store, s.Aliases, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load alias map: %w", err)
+	}
+	holderID := cbg.CborInt(actorID)
+	if err = aliases.Put(StringKey(alias), &holderID); err != nil {
+		return false, xerrors.Errorf("failed to put alias %s: %w", alias, err)
+	}
+	if s.Aliases, err = aliases.Root(); err != nil {
+		return false, xerrors.Errorf("failed to flush alias map: %w", err)
+	}
+
+	actorAliases, err := adt.AsMap(store, s.ActorAliases, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load actor alias map: %w", err)
+	}
+	if err = actorAliases.Put(abi.UIntKey(uint64(actorID)), &ActorAlias{Alias: alias}); err != nil {
+		return false, xerrors.Errorf("failed to put alias for actor %d: %w", actorID, err)
+	}
+	if s.ActorAliases, err = actorAliases.Root(); err != nil {
+		return false, xerrors.Errorf("failed to flush actor alias map: %w", err)
+	}
+	return true, nil
+}
+
+// ClearAlias removes any alias held by an actor, returning whether there was one.
+func (s *State) ClearAlias(store adt.Store, actorID abi.ActorID) (bool, error) {
+	actorAliases, err := adt.AsMap(store, s.ActorAliases, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load actor alias map: %w", err)
+	}
+	var alias ActorAlias
+	found, err := actorAliases.Pop(abi.UIntKey(uint64(actorID)), &alias)
+	if err != nil {
+		return false, xerrors.Errorf("failed to remove alias for actor %d: %w", actorID, err)
+	} else if !found {
+		return false, nil
+	}
+	if s.ActorAliases, err = actorAliases.Root(); err != nil {
+		return false, xerrors.Errorf("failed to flush actor alias map: %w", err)
+	}
+
+	aliases, err := adt.AsMap(store, s.Aliases, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load alias map: %w", err)
+	}
+	if err = aliases.Delete(StringKey(alias.Alias)); err != nil {
+		return false, xerrors.Errorf("failed to delete alias %s: %w", alias.Alias, err)
+	}
+	if s.Aliases, err = aliases.Root(); err != nil {
+		return false, xerrors.Errorf("failed to flush alias map: %w", err)
+	}
+	return true, nil
+}
+
+type StringKey string
+
+func (k StringKey) Key() string {
+	return string(k)
+}
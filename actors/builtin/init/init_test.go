@@ -10,7 +10,9 @@ import (
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	cid "github.com/ipfs/go-cid"
+	"github.com/minio/blake2b-simd"
 	assert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	init_ "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
@@ -213,6 +215,248 @@ func TestExec(t *testing.T) {
 	})
 }
 
+func TestExec2(t *testing.T) {
+	actor := initHarness{init_.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 1000)
+	anne := tutil.NewIDAddr(t, 1001)
+	builder := mock.NewBuilder(context.Background(), receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+	fakeParams := builtin.CBORBytes([]byte{'D', 'E', 'A', 'D', 'B', 'E', 'E', 'F'})
+	salt := []byte("salt")
+
+	t.Run("creates actor at precomputed address", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		expectedAddr, err := init_.ComputeExec2Address(anne, salt, blake2b.Sum256(fakeParams))
+		require.NoError(t, err)
+		expectedIdAddr := tutil.NewIDAddr(t, 100)
+		rt.ExpectCreateActor(builtin.MultisigActorCodeID, expectedIdAddr)
+		rt.ExpectSend(expectedIdAddr, builtin.MethodConstructor, fakeParams, big.Zero(), nil, exitcode.Ok)
+		ret := actor.exec2AndVerify(rt, builtin.MultisigActorCodeID, fakeParams, salt)
+		assert.Equal(t, expectedAddr, ret.RobustAddress)
+		assert.Equal(t, expectedIdAddr, ret.IDAddress)
+
+		var st init_.State
+		rt.GetState(&st)
+		actualIdAddr, found, err := st.ResolveAddress(adt.AsStore(rt), expectedAddr)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, expectedIdAddr, actualIdAddr)
+
+		robustAddr, found, err := st.ResolveRobust(adt.AsStore(rt), expectedIdAddr)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, expectedAddr, robustAddr)
+		_, found, err = st.ResolveRobust(adt.AsStore(rt), builtin.StoragePowerActorAddr)
+		assert.NoError(t, err)
+		assert.False(t, found)
+		actor.checkState(rt)
+	})
+
+	t.Run("address depends on caller, salt and params", func(t *testing.T) {
+		hash := blake2b.Sum256(fakeParams)
+		base, err := init_.ComputeExec2Address(anne, salt, hash)
+		require.NoError(t, err)
+
+		other, err := init_.ComputeExec2Address(tutil.NewIDAddr(t, 1002), salt, hash)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, other)
+		other, err = init_.ComputeExec2Address(anne, []byte("pepper"), hash)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, other)
+		other, err = init_.ComputeExec2Address(anne, salt, blake2b.Sum256([]byte{}))
+		require.NoError(t, err)
+		assert.NotEqual(t, base, other)
+
+		_, err = init_.ComputeExec2Address(tutil.NewActorAddr(t, "anne"), salt, hash)
+		assert.Error(t, err)
+	})
+
+	t.Run("fails if address already exists", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		rt.ExpectCreateActor(builtin.PaymentChannelActorCodeID, tutil.NewIDAddr(t, 100))
+		rt.ExpectSend(tutil.NewIDAddr(t, 100), builtin.MethodConstructor, fakeParams, big.Zero(), nil, exitcode.Ok)
+		actor.exec2AndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, salt)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "actor already exists", func() {
+			actor.exec2AndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, salt)
+		})
+
+		// A different salt yields a new address.
+		rt.ExpectCreateActor(builtin.PaymentChannelActorCodeID, tutil.NewIDAddr(t, 101))
+		rt.ExpectSend(tutil.NewIDAddr(t, 101), builtin.MethodConstructor, fakeParams, big.Zero(), nil, exitcode.Ok)
+		actor.exec2AndVerify(rt, builtin.PaymentChannelActorCodeID, fakeParams, []byte("pepper"))
+		actor.checkState(rt)
+	})
+
+	t.Run("fails with invalid params", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		rt.ExpectAbort(exitcode.ErrForbidden, func() {
+			actor.exec2AndVerify(rt, builtin.StorageMinerActorCodeID, fakeParams, salt)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "salt must be at most", func() {
+			actor.exec2AndVerify(rt, builtin.MultisigActorCodeID, fakeParams, make([]byte, init_.MaxExec2SaltSize+1))
+		})
+		actor.checkState(rt)
+	})
+}
+
+func TestAliases(t *testing.T) {
+	actor := initHarness{init_.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 1000)
+	anne := tutil.NewIDAddr(t, 1001)
+	bob := tutil.NewIDAddr(t, 1002)
+	builder := mock.NewBuilder(context.Background(), receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	t.Run("set, replace and clear alias", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.setAlias(rt, "anne")
+		actor.checkAlias(rt, "anne", anne)
+
+		// Setting again is a no-op.
+		actor.setAlias(rt, "anne")
+		actor.checkAlias(rt, "anne", anne)
+
+		// A new alias replaces the old one.
+		actor.setAlias(rt, "anne-2")
+		actor.checkAlias(rt, "anne-2", anne)
+		actor.checkAlias(rt, "anne", addr.Undef)
+
+		actor.clearAlias(rt)
+		actor.checkAlias(rt, "anne-2", addr.Undef)
+		alias, found, err := actor.state(rt).GetAlias(rt.AdtStore(), 1001)
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, "", alias)
+		actor.checkState(rt)
+	})
+
+	t.Run("alias held by another actor", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.setAlias(rt, "shared")
+
+		rt.SetCaller(bob, builtin.MultisigActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "held by another actor", func() {
+			actor.setAlias(rt, "shared")
+		})
+
+		// Once released, the alias is available.
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.clearAlias(rt)
+		rt.SetCaller(bob, builtin.MultisigActorCodeID)
+		actor.setAlias(rt, "shared")
+		actor.checkAlias(rt, "shared", bob)
+		actor.checkState(rt)
+	})
+
+	t.Run("invalid alias", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		for _, alias := range []string{"", "Anne", "anne smith", "ann\u00e9", strings.Repeat("a", init_.MaxAliasLength+1)} {
+			rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid alias", func() {
+				actor.setAlias(rt, alias)
+			})
+		}
+		actor.setAlias(rt, strings.Repeat("a", init_.MaxAliasLength))
+		actor.checkState(rt)
+	})
+
+	t.Run("clear without alias", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		rt.ExpectAbort(exitcode.ErrNotFound, func() {
+			actor.clearAlias(rt)
+		})
+		actor.checkState(rt)
+	})
+}
+
+func TestUpdateAccountAddress(t *testing.T) {
+	actor := initHarness{init_.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 1000)
+	oldKey := tutil.NewSECP256K1Addr(t, "old key")
+	newKey := tutil.NewSECP256K1Addr(t, "new key")
+	builder := mock.NewBuilder(context.Background(), receiver).WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+
+	setup := func(t *testing.T) (*mock.Runtime, addr.Address) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		st := actor.state(rt)
+		account, err := st.MapAddressToNewID(rt.AdtStore(), oldKey)
+		require.NoError(t, err)
+		rt.ReplaceState(st)
+		return rt, account
+	}
+
+	t.Run("previous key no longer resolves", func(t *testing.T) {
+		rt, account := setup(t)
+		rt.SetCaller(account, builtin.AccountActorCodeID)
+		actor.updateAccountAddress(rt, newKey)
+
+		st := actor.state(rt)
+		_, found, err := st.ResolveAddress(rt.AdtStore(), oldKey)
+		require.NoError(t, err)
+		assert.False(t, found)
+		resolved, found, err := st.ResolveAddress(rt.AdtStore(), newKey)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, account, resolved)
+		robust, found, err := st.ResolveRobust(rt.AdtStore(), account)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, newKey, robust)
+		actor.checkState(rt)
+	})
+
+	t.Run("fails if new key belongs to another actor", func(t *testing.T) {
+		rt, account := setup(t)
+		st := actor.state(rt)
+		_, err := st.MapAddressToNewID(rt.AdtStore(), newKey)
+		require.NoError(t, err)
+		rt.ReplaceState(st)
+
+		rt.SetCaller(account, builtin.AccountActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "in use by another actor", func() {
+			actor.updateAccountAddress(rt, newKey)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails for non-account caller or non-key address", func(t *testing.T) {
+		rt, account := setup(t)
+		rt.SetCaller(account, builtin.MultisigActorCodeID)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.updateAccountAddress(rt, newKey)
+		})
+
+		rt.SetCaller(account, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.updateAccountAddress(rt, tutil.NewActorAddr(t, "actor"))
+		})
+		actor.checkState(rt)
+	})
+}
+
 type initHarness struct {
 	init_.Actor
 	t testing.TB
@@ -254,3 +498,54 @@ func (h *initHarness) execAndVerify(rt *mock.Runtime, codeID cid.Cid, constructo
 	rt.Verify()
 	return ret
 }
+
+func (h *initHarness) setAlias(rt *mock.Runtime, alias string) {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.SetAlias, &init_.SetAliasParams{Alias: alias})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *initHarness) clearAlias(rt *mock.Runtime) {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.ClearAlias, nil)
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *initHarness) updateAccountAddress(rt *mock.Runtime, newAddress addr.Address) {
+	rt.ExpectValidateCallerType(builtin.AccountActorCodeID)
+	ret := rt.Call(h.UpdateAccountAddress, &init_.UpdateAccountAddressParams{NewAddress: newAddress})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+// Checks that alias resolves to holder, or to nothing if holder is undefined.
+func (h *initHarness) checkAlias(rt *mock.Runtime, alias string, holder addr.Address) {
+	st := h.state(rt)
+	resolved, found, err := st.ResolveAlias(rt.AdtStore(), alias)
+	require.NoError(h.t, err)
+	assert.Equal(h.t, holder != addr.Undef, found)
+	assert.Equal(h.t, holder, resolved)
+	if !found {
+		return
+	}
+
+	holderID, err := addr.IDFromAddress(holder)
+	require.NoError(h.t, err)
+	actual, found, err := st.GetAlias(rt.AdtStore(), abi.ActorID(holderID))
+	require.NoError(h.t, err)
+	assert.True(h.t, found)
+	assert.Equal(h.t, alias, actual)
+}
+
+func (h *initHarness) exec2AndVerify(rt *mock.Runtime, codeID cid.Cid, constructorParams []byte, salt []byte) *init_.ExecReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Exec2, &init_.Exec2Params{
+		CodeCID:           codeID,
+		ConstructorParams: constructorParams,
+		Salt:              salt,
+	}).(*init_.ExecReturn)
+	rt.Verify()
+	return ret
+}
@@ -12,6 +12,7 @@ import (
 type StateSummary struct {
 	AddrIDs map[addr.Address]abi.ActorID
 	NextID  abi.ActorID
+	Aliases map[string]abi.ActorID
 }
 
 // Checks internal invariants of init state.
@@ -55,5 +56,73 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 		return nil
 	})
 	acc.RequireNoError(err, "error iterating address map")
+
+	checkRobustAddresses(st, store, reverse, acc)
+	initSummary.Aliases = checkAliases(st, store, acc)
 	return initSummary, acc
 }
+
+// Checks that the robust address map is the inverse of the address map.
+func checkRobustAddresses(st *State, store adt.Store, reverse map[abi.ActorID]addr.Address, acc *builtin.MessageAccumulator) {
+	robustAddresses, err := adt.AsMap(store, st.RobustAddresses, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading robust address map: %v", err)
+		return
+	}
+
+	count := 0
+	var robust addr.Address
+	err = robustAddresses.ForEach(&robust, func(key string) error {
+		actorID, err := abi.ParseUIntKey(key)
+		if err != nil {
+			return err
+		}
+		count++
+		expected, found := reverse[abi.ActorID(actorID)]
+		acc.Require(found, "robust address %v for ID %d has no address map entry", robust, actorID)
+		acc.Require(!found || expected == robust, "robust address %v for ID %d does not match address map entry %v", robust, actorID, expected)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating robust address map")
+	acc.Require(count == len(reverse), "robust address map has %d entries, address map has %d", count, len(reverse))
+}
+
+// Checks that the alias maps are mutually inverse and hold valid aliases.
+func checkAliases(st *State, store adt.Store, acc *builtin.MessageAccumulator) map[string]abi.ActorID {
+	aliases, err := adt.AsMap(store, st.Aliases, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading alias map: %v", err)
+		return nil
+	}
+	actorAliases, err := adt.AsMap(store, st.ActorAliases, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading actor alias map: %v", err)
+		return nil
+	}
+
+	byAlias := map[string]abi.ActorID{}
+	var holder cbg.CborInt
+	err = aliases.ForEach(&holder, func(key string) error {
+		acc.RequireNoError(validateAlias(key), "invalid alias %q", key)
+		byAlias[key] = abi.ActorID(holder)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating alias map")
+
+	count := 0
+	var alias ActorAlias
+	err = actorAliases.ForEach(&alias, func(key string) error {
+		actorID, err := abi.ParseUIntKey(key)
+		if err != nil {
+			return err
+		}
+		count++
+		holderID, found := byAlias[alias.Alias]
+		acc.Require(found, "alias %s of actor %d has no alias map entry", alias.Alias, actorID)
+		acc.Require(!found || holderID == abi.ActorID(actorID), "alias %s of actor %d is held by %d", alias.Alias, actorID, holderID)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating actor alias map")
+	acc.Require(count == len(byAlias), "actor alias map has %d entries, alias map has %d", count, len(byAlias))
+	return byAlias
+}
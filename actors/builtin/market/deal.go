@@ -1,7 +1,14 @@
 package market
 
 import (
+	"bytes"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
 	market0 "github.com/filecoin-project/specs-actors/actors/builtin/market"
+	"github.com/ipfs/go-cid"
 )
 
 //var PieceCIDPrefix = cid.Prefix{
@@ -20,33 +27,56 @@ var PieceCIDPrefix = market0.PieceCIDPrefix
 // minimal deals that last for a long time.
 // Note: ClientCollateralPerEpoch may not be needed and removed pending future confirmation.
 // There will be a Minimum value for both client and provider deal collateral.
-//type DealProposal struct {
-//	PieceCID     cid.Cid `checked:"true"` // Checked in validateDeal, CommP
-//	PieceSize    abi.PaddedPieceSize
-//	VerifiedDeal bool
-//	Client       addr.Address
-//	Provider     addr.Address
-//
-//	// Label is an arbitrary client chosen label to apply to the deal
-//	// TODO: Limit the size of this: https://github.com/filecoin-project/specs-actors/issues/897
-//	Label string
-//
-//	// Nominal start epoch. Deal payment is linear between StartEpoch and EndEpoch,
-//	// with total amount StoragePricePerEpoch * (EndEpoch - StartEpoch).
-//	// Storage deal must appear in a sealed (proven) sector no later than StartEpoch,
-//	// otherwise it is invalid.
-//	StartEpoch           abi.ChainEpoch
-//	EndEpoch             abi.ChainEpoch
-//	StoragePricePerEpoch abi.TokenAmount
-//
-//	ProviderCollateral abi.TokenAmount
-//	ClientCollateral   abi.TokenAmount
-//}
-type DealProposal = market0.DealProposal
+// Changed since v2:
+// - Label is a DealLabel, which may hold bytes as well as a string
+type DealProposal struct {
+	PieceCID     cid.Cid `checked:"true"` // Checked in validateDeal, CommP
+	PieceSize    abi.PaddedPieceSize
+	VerifiedDeal bool
+	Client       addr.Address
+	Provider     addr.Address
+
+	// Label is an arbitrary client chosen label to apply to the deal
+	Label DealLabel
+
+	// Nominal start epoch. Deal payment is linear between StartEpoch and EndEpoch,
+	// with total amount StoragePricePerEpoch * (EndEpoch - StartEpoch).
+	// Storage deal must appear in a sealed (proven) sector no later than StartEpoch,
+	// otherwise it is invalid.
+	StartEpoch           abi.ChainEpoch
+	EndEpoch             abi.ChainEpoch
+	StoragePricePerEpoch abi.TokenAmount
+
+	ProviderCollateral abi.TokenAmount
+	ClientCollateral   abi.TokenAmount
+}
 
 // ClientDealProposal is a DealProposal signed by a client
-//type ClientDealProposal struct {
-//	Proposal        DealProposal
-//	ClientSignature crypto.Signature
-//}
-type ClientDealProposal = market0.ClientDealProposal
+type ClientDealProposal struct {
+	Proposal        DealProposal
+	ClientSignature crypto.Signature
+}
+
+func (p *DealProposal) Duration() abi.ChainEpoch {
+	return p.EndEpoch - p.StartEpoch
+}
+
+func (p *DealProposal) TotalStorageFee() abi.TokenAmount {
+	return big.Mul(p.StoragePricePerEpoch, big.NewInt(int64(p.Duration())))
+}
+
+func (p *DealProposal) ClientBalanceRequirement() abi.TokenAmount {
+	return big.Add(p.ClientCollateral, p.TotalStorageFee())
+}
+
+func (p *DealProposal) ProviderBalanceRequirement() abi.TokenAmount {
+	return p.ProviderCollateral
+}
+
+func (p *DealProposal) Cid() (cid.Cid, error) {
+	buf := new(bytes.Buffer)
+	if err := p.MarshalCBOR(buf); err != nil {
+		return cid.Undef, err
+	}
+	return abi.CidBuilder.Sum(buf.Bytes())
+}
@@ -0,0 +1,179 @@
+package market
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// DealLabel is a client chosen label for a deal, holding either a UTF-8 string or raw bytes.
+// A string label is encoded as a CBOR text string, as labels always were before labels could hold bytes,
+// so labels of previously published deals decode unchanged. A bytes label is encoded as a CBOR byte string.
+// The zero value is the empty string label.
+type DealLabel struct {
+	bs      []byte
+	isBytes bool
+}
+
+// EmptyDealLabel is the empty string label.
+var EmptyDealLabel = DealLabel{}
+
+// Constructs a string label, which must be valid UTF-8.
+func NewLabelFromString(s string) (DealLabel, error) {
+	if !utf8.ValidString(s) {
+		return EmptyDealLabel, xerrors.Errorf("deal label string is not valid UTF-8")
+	}
+	return DealLabel{bs: copyLabelBytes([]byte(s))}, nil
+}
+
+// Constructs a bytes label.
+func NewLabelFromBytes(b []byte) DealLabel {
+	return DealLabel{bs: copyLabelBytes(b), isBytes: true}
+}
+
+// Constructs a bytes label holding CBOR-encoded deal metadata.
+func NewLabelFromMetadata(m *DealMetadata) (DealLabel, error) {
+	buf := new(bytes.Buffer)
+	if err := m.MarshalCBOR(buf); err != nil {
+		return EmptyDealLabel, xerrors.Errorf("failed to marshal deal metadata: %w", err)
+	}
+	return DealLabel{bs: buf.Bytes(), isBytes: true}, nil
+}
+
+func (l DealLabel) IsString() bool {
+	return !l.isBytes
+}
+
+func (l DealLabel) IsBytes() bool {
+	return l.isBytes
+}
+
+// Length of the label content, in bytes.
+func (l DealLabel) Length() int {
+	return len(l.bs)
+}
+
+func (l DealLabel) IsEmpty() bool {
+	return len(l.bs) == 0
+}
+
+// Returns the content of a string label.
+func (l DealLabel) ToString() (string, error) {
+	if l.isBytes {
+		return "", xerrors.Errorf("deal label is not a string")
+	}
+	return string(l.bs), nil
+}
+
+// Returns the content of a bytes label.
+func (l DealLabel) ToBytes() ([]byte, error) {
+	if !l.isBytes {
+		return nil, xerrors.Errorf("deal label is not bytes")
+	}
+	return copyLabelBytes(l.bs), nil
+}
+
+// Decodes the deal metadata held in a bytes label.
+func (l DealLabel) Metadata() (*DealMetadata, error) {
+	if !l.isBytes {
+		return nil, xerrors.Errorf("deal label is not bytes")
+	}
+	var m DealMetadata
+	if err := m.UnmarshalCBOR(bytes.NewReader(l.bs)); err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal deal metadata: %w", err)
+	}
+	return &m, nil
+}
+
+func (l DealLabel) Equals(o DealLabel) bool {
+	return l.isBytes == o.isBytes && bytes.Equal(l.bs, o.bs)
+}
+
+func (l DealLabel) String() string {
+	if l.isBytes {
+		return fmt.Sprintf("0x%x", l.bs)
+	}
+	return string(l.bs)
+}
+
+func (l *DealLabel) MarshalCBOR(w io.Writer) error {
+	majorType := byte(cbg.MajTextString)
+	maxLength := uint64(cbg.MaxLength)
+	if l.isBytes {
+		majorType = cbg.MajByteString
+		maxLength = cbg.ByteArrayMaxLen
+	}
+	if uint64(len(l.bs)) > maxLength {
+		return xerrors.Errorf("deal label is too long")
+	}
+	if err := cbg.CborWriteHeader(w, majorType, uint64(len(l.bs))); err != nil {
+		return err
+	}
+	_, err := w.Write(l.bs)
+	return err
+}
+
+func (l *DealLabel) UnmarshalCBOR(r io.Reader) error {
+	*l = DealLabel{}
+
+	br := cbg.GetPeeker(r)
+	maj, extra, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return err
+	}
+
+	switch maj {
+	case cbg.MajTextString:
+		if extra > cbg.MaxLength {
+			return xerrors.Errorf("deal label string too long (%d)", extra)
+		}
+	case cbg.MajByteString:
+		if extra > cbg.ByteArrayMaxLen {
+			return xerrors.Errorf("deal label bytes too long (%d)", extra)
+		}
+		l.isBytes = true
+	default:
+		return xerrors.Errorf("deal label must be a text or byte string, got major type %d", maj)
+	}
+
+	// Text strings are not checked for UTF-8 validity here, so that labels stored before
+	// validation was introduced continue to decode.
+	if extra == 0 {
+		return nil
+	}
+	l.bs = make([]byte, extra)
+	_, err = io.ReadFull(br, l.bs)
+	return err
+}
+
+// Copies label content, representing empty content as nil so that equal labels are deeply equal.
+func copyLabelBytes(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	bs := make([]byte, len(b))
+	copy(bs, b)
+	return bs
+}
+
+// Current version of the DealMetadata schema.
+const DealMetadataSchemaVersion = 1
+
+// DealMetadata is a structured description of the data stored by a deal, carried CBOR-encoded in a bytes label.
+// The market actor does not interpret it; it exists so that off-chain tools can agree on a format.
+type DealMetadata struct {
+	// Version of this schema with which the metadata was written.
+	SchemaVersion uint64
+	// Identifier of the dataset of which the piece is part.
+	DatasetID string
+	// Knowledge domain of the dataset.
+	Domain string
+	// Identifier of the expert responsible for the dataset.
+	Expert string
+	// Version of the dataset.
+	DatasetVersion string
+}
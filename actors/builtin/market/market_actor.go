@@ -18,6 +18,7 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/askreg"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/reward"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/verifreg"
@@ -40,6 +41,8 @@ func (a Actor) Exports() []interface{} {
 		7:                         a.OnMinerSectorsTerminate,
 		8:                         a.ComputeDataCommitment,
 		9:                         a.CronTick,
+		10:                        a.PieceReplicas,
+		11:                        a.SettleDealPayments,
 	}
 }
 
@@ -140,10 +143,11 @@ func (a Actor) AddBalance(rt Runtime, providerOrClientAddress *addr.Address) *ab
 	return nil
 }
 
-//type PublishStorageDealsParams struct {
-//	Deals []ClientDealProposal
-//}
-type PublishStorageDealsParams = market0.PublishStorageDealsParams
+// Changed since v2:
+// - Deals hold the changed DealProposal, with a DealLabel
+type PublishStorageDealsParams struct {
+	Deals []ClientDealProposal
+}
 
 //type PublishStorageDealsReturn struct {
 //	IDs []abi.DealID
@@ -186,6 +190,11 @@ func (a Actor) PublishStorageDeals(rt Runtime, params *PublishStorageDealsParams
 		rt.Abortf(exitcode.ErrForbidden, "caller %v is not worker or control address of provider %v", caller, provider)
 	}
 
+	var providerAsk *askreg.StorageAsk
+	if DealsMustMatchProviderAsk {
+		providerAsk = requestProviderAsk(rt, provider)
+	}
+
 	resolvedAddrs := make(map[addr.Address]addr.Address, len(params.Deals))
 	baselinePower := requestCurrentBaselinePower(rt)
 	networkRawPower, networkQAPower := requestCurrentNetworkPower(rt)
@@ -200,7 +209,7 @@ func (a Actor) PublishStorageDeals(rt Runtime, params *PublishStorageDealsParams
 
 		// All storage dealProposals will be added in an atomic transaction; this operation will be unrolled if any of them fails.
 		for di, deal := range params.Deals {
-			validateDeal(rt, deal, networkRawPower, networkQAPower, baselinePower)
+			validateDeal(rt, deal, networkRawPower, networkQAPower, baselinePower, providerAsk)
 
 			if deal.Proposal.Provider != provider && deal.Proposal.Provider != providerRaw {
 				rt.Abortf(exitcode.ErrIllegalArgument, "cannot publish deals from different providers at the same time")
@@ -359,7 +368,8 @@ func (a Actor) ActivateDeals(rt Runtime, params *ActivateDealsParams) *abi.Empty
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to validate dealProposals for activation")
 
 		msm, err := st.mutator(adt.AsStore(rt)).withDealStates(WritePermission).
-			withPendingProposals(ReadOnlyPermission).withDealProposals(ReadOnlyPermission).build()
+			withPendingProposals(ReadOnlyPermission).withDealProposals(ReadOnlyPermission).
+			withPieceReplicas(WritePermission).build()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load state")
 
 		for _, dealID := range params.DealIDs {
@@ -390,6 +400,9 @@ func (a Actor) ActivateDeals(rt Runtime, params *ActivateDealsParams) *abi.Empty
 				SlashEpoch:       epochUndefined,
 			})
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set deal state %d", dealID)
+
+			err = msm.addPieceReplica(proposal)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to add deal %d to piece replicas", dealID)
 		}
 
 		err = msm.commitState()
@@ -448,7 +461,7 @@ func (a Actor) OnMinerSectorsTerminate(rt Runtime, params *OnMinerSectorsTermina
 	var st State
 	rt.StateTransaction(&st, func() {
 		msm, err := st.mutator(adt.AsStore(rt)).withDealStates(WritePermission).
-			withDealProposals(ReadOnlyPermission).build()
+			withDealProposals(ReadOnlyPermission).withPieceReplicas(WritePermission).build()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deal state")
 
 		for _, dealID := range params.DealIDs {
@@ -484,6 +497,10 @@ func (a Actor) OnMinerSectorsTerminate(rt Runtime, params *OnMinerSectorsTermina
 
 			err = msm.dealStates.Set(dealID, state)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set deal state %v", dealID)
+
+			// a terminated deal no longer counts towards the replication of its piece.
+			err = msm.removePieceReplica(deal)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to remove deal %v from piece replicas", dealID)
 		}
 
 		err = msm.commitState()
@@ -504,13 +521,34 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 
 		msm, err := st.mutator(adt.AsStore(rt)).withDealStates(WritePermission).
 			withLockedTable(WritePermission).withEscrowTable(WritePermission).withDealsByEpoch(WritePermission).
-			withDealProposals(WritePermission).withPendingProposals(WritePermission).build()
+			withDealProposals(WritePermission).withPendingProposals(WritePermission).
+			withPieceReplicas(WritePermission).withSettledDeals(WritePermission).build()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load state")
 
+		// Deal ops are processed in epoch order, up to a limit per tick. Ops remaining when the limit is reached
+		// are carried over, and the next tick resumes with them before moving on to later epochs.
+		opsProcessed := 0
+		lastCron := st.LastCron
 		for i := st.LastCron + 1; i <= rt.CurrEpoch(); i++ {
+			var processed []abi.DealID
 			err = msm.dealsByEpoch.ForEach(i, func(dealID abi.DealID) error {
-				deal, err := getDealProposal(msm.dealProposals, dealID)
+				if opsProcessed >= MaxDealOpsPerCronTick {
+					return errStop
+				}
+				opsProcessed++
+				processed = append(processed, dealID)
+
+				deal, found, err := msm.dealProposals.Get(dealID)
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get dealId %d", dealID)
+				if !found {
+					// A deal completed by payment settlement leaves its scheduled op behind, which is dropped here.
+					settled, err := msm.settledDeals.TryDelete(dealKey(dealID))
+					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete settled deal %d", dealID)
+					if !settled {
+						rt.Abortf(exitcode.ErrNotFound, "no such deal %d", dealID)
+					}
+					return nil
+				}
 
 				dcid, err := deal.Cid()
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to calculate CID for proposal %v", dealID)
@@ -548,7 +586,7 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 					builtin.RequireNoErr(rt, pdErr, exitcode.ErrIllegalState, "failed to delete pending proposal %v", dcid)
 				}
 
-				slashAmount, nextEpoch, removeDeal := msm.updatePendingDealState(rt, state, deal, rt.CurrEpoch())
+				_, slashAmount, nextEpoch, removeDeal := msm.updatePendingDealState(rt, state, deal, rt.CurrEpoch())
 				builtin.RequireState(rt, slashAmount.GreaterThanEqual(big.Zero()), "computed negative slash amount %v for deal %d", slashAmount, dealID)
 
 				if removeDeal {
@@ -571,10 +609,16 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 
 				return nil
 			})
+			if err == errStop {
+				err = msm.dealsByEpoch.RemoveMany(i, processed)
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete processed deal ops for epoch %v", i)
+				break
+			}
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to iterate deal ops")
 
 			err = msm.dealsByEpoch.RemoveAll(i)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete deal ops for epoch %v", i)
+			lastCron = i
 		}
 
 		// Iterate changes in sorted order to ensure that loads/stores
@@ -592,7 +636,7 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to reinsert deal IDs for epoch %v", epoch)
 		}
 
-		st.LastCron = rt.CurrEpoch()
+		st.LastCron = lastCron
 
 		err = msm.commitState()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush state")
@@ -624,6 +668,140 @@ func (a Actor) CronTick(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	return nil
 }
 
+// Returns the replication status of a piece: the number of active deals storing it and the providers
+// storing it in those deals.
+func (a Actor) PieceReplicas(rt Runtime, pieceCID *cbg.CborCid) *PieceReplicas {
+	rt.ValidateImmediateCallerAcceptAny()
+	builtin.RequireParam(rt, cid.Cid(*pieceCID).Defined(), "piece CID undefined")
+
+	var st State
+	rt.StateReadonly(&st)
+	replicas, _, err := st.GetPieceReplicas(adt.AsStore(rt), cid.Cid(*pieceCID))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load piece replicas")
+	return replicas
+}
+
+type SettleDealPaymentsParams struct {
+	DealIDs []abi.DealID
+}
+
+type SettleDealPaymentsReturn struct {
+	Results []DealSettlement
+}
+
+// The outcome of settling payment for a single deal.
+type DealSettlement struct {
+	DealID abi.DealID
+	// Amount transferred from the client's to the provider's escrow by this settlement.
+	Payment abi.TokenAmount
+	// Provider collateral slashed, if the deal's sector was terminated.
+	Slashed abi.TokenAmount
+	// Whether the deal has expired or been terminated, and so has been removed.
+	Completed bool
+}
+
+// Processes payments for a set of active deals up to the current epoch, ahead of their scheduled cron processing.
+// All deals must have the same provider, and the caller must be the provider's owner, worker or a control address.
+// Deals that have expired or been terminated are completed and removed, exactly as they would be in cron.
+func (a Actor) SettleDealPayments(rt Runtime, params *SettleDealPaymentsParams) *SettleDealPaymentsReturn {
+	builtin.RequireParam(rt, len(params.DealIDs) > 0, "empty deal IDs parameter")
+	currEpoch := rt.CurrEpoch()
+
+	var st State
+	rt.StateReadonly(&st)
+	proposals, err := AsDealProposalArray(adt.AsStore(rt), st.Proposals)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deal proposals")
+
+	var provider addr.Address
+	for i, dealID := range params.DealIDs {
+		deal, err := getDealProposal(proposals, dealID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get dealId %d", dealID)
+		if i == 0 {
+			provider = deal.Provider
+		} else if deal.Provider != provider {
+			rt.Abortf(exitcode.ErrIllegalArgument, "cannot settle deals from different providers at the same time")
+		}
+	}
+
+	owner, worker, controllers := builtin.RequestMinerControlAddrs(rt, provider)
+	rt.ValidateImmediateCallerIs(append([]addr.Address{owner, worker}, controllers...)...)
+
+	results := make([]DealSettlement, len(params.DealIDs))
+	amountSlashed := big.Zero()
+	rt.StateTransaction(&st, func() {
+		msm, err := st.mutator(adt.AsStore(rt)).withDealStates(WritePermission).
+			withLockedTable(WritePermission).withEscrowTable(WritePermission).
+			withDealProposals(WritePermission).withPendingProposals(WritePermission).
+			withPieceReplicas(WritePermission).withSettledDeals(WritePermission).build()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load state")
+
+		seenDealIDs := make(map[abi.DealID]struct{}, len(params.DealIDs))
+		for i, dealID := range params.DealIDs {
+			if _, seen := seenDealIDs[dealID]; seen {
+				rt.Abortf(exitcode.ErrIllegalArgument, "deal ID %d present multiple times", dealID)
+			}
+			seenDealIDs[dealID] = struct{}{}
+
+			deal, err := getDealProposal(msm.dealProposals, dealID)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get dealId %d", dealID)
+
+			state, found, err := msm.dealStates.Get(dealID)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get deal state %d", dealID)
+			if !found {
+				rt.Abortf(exitcode.ErrIllegalArgument, "deal %d has not been activated", dealID)
+			}
+
+			results[i] = DealSettlement{DealID: dealID, Payment: big.Zero(), Slashed: big.Zero()}
+			// payment does not begin until the deal's start epoch.
+			if currEpoch < deal.StartEpoch {
+				continue
+			}
+
+			// if the deal has not been processed before, it should be in the pending state.
+			if state.LastUpdatedEpoch == epochUndefined {
+				dcid, err := deal.Cid()
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to calculate CID for proposal %v", dealID)
+
+				err = msm.pendingDeals.Delete(abi.CidKey(dcid))
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete pending proposal %v", dcid)
+			}
+
+			payment, slashAmount, _, removeDeal := msm.updatePendingDealState(rt, state, deal, currEpoch)
+			builtin.RequireState(rt, slashAmount.GreaterThanEqual(big.Zero()), "computed negative slash amount %v for deal %d", slashAmount, dealID)
+			results[i].Payment = payment
+			results[i].Slashed = slashAmount
+			results[i].Completed = removeDeal
+
+			if removeDeal {
+				amountSlashed = big.Add(amountSlashed, slashAmount)
+				err = deleteDealProposalAndState(dealID, msm.dealStates, msm.dealProposals, true, true)
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete deal proposal and states")
+
+				// the deal's scheduled op remains, to be dropped when cron reaches it.
+				err = msm.settledDeals.Put(dealKey(dealID))
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record settled deal %d", dealID)
+			} else {
+				builtin.RequireState(rt, slashAmount.IsZero(), "continuing deal %d should not be slashed", dealID)
+
+				// The deal remains scheduled at its existing epoch, from which cron resumes payment.
+				state.LastUpdatedEpoch = currEpoch
+				err = msm.dealStates.Set(dealID, state)
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set deal state")
+			}
+		}
+
+		err = msm.commitState()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush state")
+	})
+
+	if !amountSlashed.IsZero() {
+		e := rt.Send(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, amountSlashed, &builtin.Discard{})
+		builtin.RequireSuccess(rt, e, "expected send to burnt funds actor to succeed")
+	}
+
+	return &SettleDealPaymentsReturn{Results: results}
+}
+
 func genRandNextEpoch(currEpoch abi.ChainEpoch, deal *DealProposal, rbF func(crypto.DomainSeparationTag, abi.ChainEpoch, []byte) abi.Randomness) (abi.ChainEpoch, error) {
 	buf := bytes.Buffer{}
 	if err := deal.MarshalCBOR(&buf); err != nil {
@@ -726,17 +904,13 @@ func validateDealCanActivate(proposal *DealProposal, minerAddr addr.Address, sec
 	return nil
 }
 
-func validateDeal(rt Runtime, deal ClientDealProposal, networkRawPower, networkQAPower, baselinePower abi.StoragePower) {
+func validateDeal(rt Runtime, deal ClientDealProposal, networkRawPower, networkQAPower, baselinePower abi.StoragePower, providerAsk *askreg.StorageAsk) {
 	if err := dealProposalIsInternallyValid(rt, deal); err != nil {
 		rt.Abortf(exitcode.ErrIllegalArgument, "Invalid deal proposal: %s", err)
 	}
 
 	proposal := deal.Proposal
 
-	if len(proposal.Label) > DealMaxLabelSize {
-		rt.Abortf(exitcode.ErrIllegalArgument, "deal label can be at most %d bytes, is %d", DealMaxLabelSize, len(proposal.Label))
-	}
-
 	if err := proposal.PieceSize.Validate(); err != nil {
 		rt.Abortf(exitcode.ErrIllegalArgument, "proposal piece size is invalid: %v", err)
 	}
@@ -767,6 +941,17 @@ func validateDeal(rt Runtime, deal ClientDealProposal, networkRawPower, networkQ
 		rt.Abortf(exitcode.ErrIllegalArgument, "Storage price out of bounds.")
 	}
 
+	if providerAsk != nil {
+		if !providerAsk.AcceptsPieceSize(proposal.PieceSize) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "piece size %d outside provider ask bounds [%d, %d]",
+				proposal.PieceSize, providerAsk.MinPieceSize, providerAsk.MaxPieceSize)
+		}
+		askPrice := providerAsk.MinPricePerEpoch(proposal.PieceSize, proposal.VerifiedDeal)
+		if proposal.StoragePricePerEpoch.LessThan(askPrice) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "storage price %v below provider ask price %v", proposal.StoragePricePerEpoch, askPrice)
+		}
+	}
+
 	minProviderCollateral, maxProviderCollateral := DealProviderCollateralBounds(proposal.PieceSize, proposal.VerifiedDeal,
 		networkRawPower, networkQAPower, baselinePower, rt.TotalFilCircSupply())
 	if proposal.ProviderCollateral.LessThan(minProviderCollateral) || proposal.ProviderCollateral.GreaterThan(maxProviderCollateral) {
@@ -826,6 +1011,14 @@ func requestCurrentBaselinePower(rt Runtime) abi.StoragePower {
 	return ret.ThisEpochBaselinePower
 }
 
+// Requests a provider's active storage ask from the ask registry, returning nil if it has none.
+func requestProviderAsk(rt Runtime, provider addr.Address) *askreg.StorageAsk {
+	var ret askreg.GetAskReturn
+	code := rt.Send(builtin.StorageAskRegistryActorAddr, builtin.MethodsStorageAskRegistry.GetAsk, &provider, big.Zero(), &ret)
+	builtin.RequireSuccess(rt, code, "failed to get provider ask")
+	return ret.Ask
+}
+
 // Requests the current network total power and pledge from the power actor.
 func requestCurrentNetworkPower(rt Runtime) (rawPower, qaPower abi.StoragePower) {
 	var pwr power.CurrentTotalPowerReturn
@@ -2,6 +2,7 @@ package market
 
 import (
 	"bytes"
+	"unicode/utf8"
 
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -54,7 +55,9 @@ type State struct {
 
 	// Metadata cached for efficient iteration over deals.
 	DealOpsByEpoch cid.Cid // SetMultimap, HAMT[epoch]Set
-	LastCron       abi.ChainEpoch
+	// Last epoch for which all deal ops have been processed.
+	// This may lag the epoch of the last cron tick when a tick exhausts its processing budget.
+	LastCron abi.ChainEpoch
 
 	// Total Client Collateral that is locked -> unlocked when deal is terminated
 	TotalClientLockedCollateral abi.TokenAmount
@@ -62,6 +65,13 @@ type State struct {
 	TotalProviderLockedCollateral abi.TokenAmount
 	// Total storage fee that is locked in escrow -> unlocked when payments are made
 	TotalClientStorageFee abi.TokenAmount
+
+	// Active deal count and provider set for each piece, updated as deals are activated, terminated and expire.
+	PieceReplicas cid.Cid // HAMT[PieceCID]PieceReplicas
+
+	// Deals completed by payment settlement before cron reached their scheduled deal op.
+	// An entry is removed when cron processes the deal's remaining op.
+	SettledDeals cid.Cid // Set[DealID]
 }
 
 func ConstructState(store adt.Store) (*State, error) {
@@ -86,6 +96,14 @@ func ConstructState(store adt.Store) (*State, error) {
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create empty balance table: %w", err)
 	}
+	emptyPieceReplicasMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty piece replicas map: %w", err)
+	}
+	emptySettledDealsSetCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty settled deals set: %w", err)
+	}
 
 	return &State{
 		Proposals:        emptyProposalsArrayCid,
@@ -100,6 +118,8 @@ func ConstructState(store adt.Store) (*State, error) {
 		TotalClientLockedCollateral:   abi.NewTokenAmount(0),
 		TotalProviderLockedCollateral: abi.NewTokenAmount(0),
 		TotalClientStorageFee:         abi.NewTokenAmount(0),
+		PieceReplicas:                 emptyPieceReplicasMapCid,
+		SettledDeals:                  emptySettledDealsSetCid,
 	}, nil
 }
 
@@ -107,7 +127,8 @@ func ConstructState(store adt.Store) (*State, error) {
 // Deal state operations
 ////////////////////////////////////////////////////////////////////////////////
 
-func (m *marketStateMutation) updatePendingDealState(rt Runtime, state *DealState, deal *DealProposal, epoch abi.ChainEpoch) (amountSlashed abi.TokenAmount, nextEpoch abi.ChainEpoch, removeDeal bool) {
+func (m *marketStateMutation) updatePendingDealState(rt Runtime, state *DealState, deal *DealProposal, epoch abi.ChainEpoch) (amountPaid, amountSlashed abi.TokenAmount, nextEpoch abi.ChainEpoch, removeDeal bool) {
+	amountPaid = abi.NewTokenAmount(0)
 	amountSlashed = abi.NewTokenAmount(0)
 
 	everUpdated := state.LastUpdatedEpoch != epochUndefined
@@ -118,7 +139,7 @@ func (m *marketStateMutation) updatePendingDealState(rt Runtime, state *DealStat
 	// This would be the case that the first callback somehow triggers before it is scheduled to
 	// This is expected not to be able to happen
 	if deal.StartEpoch > epoch {
-		return amountSlashed, epochUndefined, false
+		return amountPaid, amountSlashed, epochUndefined, false
 	}
 
 	paymentEndEpoch := deal.EndEpoch
@@ -146,6 +167,7 @@ func (m *marketStateMutation) updatePendingDealState(rt Runtime, state *DealStat
 			err := m.transferBalance(deal.Client, deal.Provider, totalPayment)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to transfer %v from %v to %v",
 				totalPayment, deal.Client, deal.Provider)
+			amountPaid = totalPayment
 		}
 	}
 
@@ -166,19 +188,19 @@ func (m *marketStateMutation) updatePendingDealState(rt Runtime, state *DealStat
 		amountSlashed = deal.ProviderCollateral
 		err = m.slashBalance(deal.Provider, amountSlashed, ProviderCollateral)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "slashing balance")
-		return amountSlashed, epochUndefined, true
+		return amountPaid, amountSlashed, epochUndefined, true
 	}
 
 	if epoch >= deal.EndEpoch {
 		m.processDealExpired(rt, deal, state)
-		return amountSlashed, epochUndefined, true
+		return amountPaid, amountSlashed, epochUndefined, true
 	}
 
 	// We're explicitly not inspecting the end epoch and may process a deal's expiration late, in order to prevent an outsider
 	// from loading a cron tick by activating too many deals with the same end epoch.
 	nextEpoch = epoch + DealUpdatesInterval
 
-	return amountSlashed, nextEpoch, false
+	return amountPaid, amountSlashed, nextEpoch, false
 }
 
 // Deal start deadline elapsed without appearing in a proven sector.
@@ -216,6 +238,9 @@ func (m *marketStateMutation) processDealExpired(rt Runtime, deal *DealProposal,
 
 	err = m.unlockBalance(deal.Client, deal.ClientCollateral, ClientCollateral)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed unlocking deal client balance")
+
+	err = m.removePieceReplica(deal)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to remove expired deal from piece replicas")
 }
 
 func (m *marketStateMutation) generateStorageDealID() abi.DealID {
@@ -240,6 +265,22 @@ func dealProposalIsInternallyValid(rt Runtime, proposal ClientDealProposal) erro
 	if err != nil {
 		return xerrors.Errorf("signature proposal invalid: %w", err)
 	}
+	if err := validateDealLabel(proposal.Proposal.Label); err != nil {
+		return xerrors.Errorf("invalid label: %w", err)
+	}
+	return nil
+}
+
+func validateDealLabel(label DealLabel) error {
+	if label.Length() > DealMaxLabelSize {
+		return xerrors.Errorf("deal label can be at most %d bytes, is %d", DealMaxLabelSize, label.Length())
+	}
+	if label.IsString() {
+		// Labels stored before strings were validated may not be UTF-8, but new ones must be.
+		if s, _ := label.ToString(); !utf8.ValidString(s) {
+			return xerrors.Errorf("deal label string is not valid UTF-8")
+		}
+	}
 	return nil
 }
 
@@ -292,6 +333,12 @@ type marketStateMutation struct {
 	dpePermit    MarketStateMutationPermission
 	dealsByEpoch *SetMultimap
 
+	replicasPermit MarketStateMutationPermission
+	pieceReplicas  *adt.Map
+
+	settledPermit MarketStateMutationPermission
+	settledDeals  *adt.Set
+
 	lockedPermit                  MarketStateMutationPermission
 	lockedTable                   *adt.BalanceTable
 	totalClientLockedCollateral   abi.TokenAmount
@@ -357,6 +404,22 @@ func (m *marketStateMutation) build() (*marketStateMutation, error) {
 		m.dealsByEpoch = dbe
 	}
 
+	if m.replicasPermit != Invalid {
+		replicas, err := adt.AsMap(m.store, m.st.PieceReplicas, builtin.DefaultHamtBitwidth)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to load piece replicas: %w", err)
+		}
+		m.pieceReplicas = replicas
+	}
+
+	if m.settledPermit != Invalid {
+		settled, err := adt.AsSet(m.store, m.st.SettledDeals, builtin.DefaultHamtBitwidth)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to load settled deals: %w", err)
+		}
+		m.settledDeals = settled
+	}
+
 	m.nextDealId = m.st.NextID
 
 	return m, nil
@@ -392,6 +455,16 @@ func (m *marketStateMutation) withDealsByEpoch(permit MarketStateMutationPermiss
 	return m
 }
 
+func (m *marketStateMutation) withPieceReplicas(permit MarketStateMutationPermission) *marketStateMutation {
+	m.replicasPermit = permit
+	return m
+}
+
+func (m *marketStateMutation) withSettledDeals(permit MarketStateMutationPermission) *marketStateMutation {
+	m.settledPermit = permit
+	return m
+}
+
 func (m *marketStateMutation) commitState() error {
 	var err error
 	if m.proposalPermit == WritePermission {
@@ -433,6 +506,18 @@ func (m *marketStateMutation) commitState() error {
 		}
 	}
 
+	if m.replicasPermit == WritePermission {
+		if m.st.PieceReplicas, err = m.pieceReplicas.Root(); err != nil {
+			return xerrors.Errorf("failed to flush piece replicas: %w", err)
+		}
+	}
+
+	if m.settledPermit == WritePermission {
+		if m.st.SettledDeals, err = m.settledDeals.Root(); err != nil {
+			return xerrors.Errorf("failed to flush settled deals: %w", err)
+		}
+	}
+
 	m.st.NextID = m.nextDealId
 	return nil
 }
@@ -14,10 +14,12 @@ import (
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	market0 "github.com/filecoin-project/specs-actors/actors/builtin/market"
 	cid "github.com/ipfs/go-cid"
 	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/askreg"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/market"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
@@ -40,6 +42,14 @@ func mustCbor(o cbor.Marshaler) []byte {
 	return buf.Bytes()
 }
 
+func mustLabel(s string) market.DealLabel {
+	label, err := market.NewLabelFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return label
+}
+
 func TestExports(t *testing.T) {
 	mock.CheckActorExports(t, market.Actor{})
 }
@@ -1547,6 +1557,86 @@ func TestCronTick(t *testing.T) {
 	})
 }
 
+func TestCronTickProcessingLimit(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	provider := tutil.NewIDAddr(t, 102)
+	worker := tutil.NewIDAddr(t, 103)
+	client := tutil.NewIDAddr(t, 104)
+	mAddrs := &minerAddrs{owner, worker, provider, nil}
+
+	startEpoch := abi.ChainEpoch(50)
+	endEpoch := startEpoch + 200*builtin.EpochsInDay
+	sectorExpiry := endEpoch + 100
+
+	defer func(limit int) { market.MaxDealOpsPerCronTick = limit }(market.MaxDealOpsPerCronTick)
+	market.MaxDealOpsPerCronTick = 2
+
+	lastCron := func(rt *mock.Runtime) abi.ChainEpoch {
+		var st market.State
+		rt.GetState(&st)
+		return st.LastCron
+	}
+
+	processed := func(rt *mock.Runtime, actor *marketActorTestHarness, dealIDs ...abi.DealID) int {
+		count := 0
+		for _, id := range dealIDs {
+			if actor.getDealState(rt, id).LastUpdatedEpoch != -1 {
+				count++
+			}
+		}
+		return count
+	}
+
+	t.Run("ops beyond the limit carry over to the next tick in epoch order", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealID1 := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+		dealID2 := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch+1, endEpoch+1, 0, sectorExpiry, startEpoch+1)
+		dealID3 := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch+2, endEpoch+2, 0, sectorExpiry, startEpoch+2)
+
+		current := startEpoch + 10
+		rt.SetEpoch(current)
+		actor.cronTick(rt)
+
+		// The two earliest ops are processed, and the last carries over.
+		assert.Equal(t, current, actor.getDealState(rt, dealID1).LastUpdatedEpoch)
+		assert.Equal(t, current, actor.getDealState(rt, dealID2).LastUpdatedEpoch)
+		assert.Equal(t, abi.ChainEpoch(-1), actor.getDealState(rt, dealID3).LastUpdatedEpoch)
+		var st market.State
+		rt.GetState(&st)
+		assert.Equal(t, startEpoch+1, st.LastCron)
+		summary, msgs := market.CheckStateInvariants(&st, rt.AdtStore(), rt.Balance(), rt.Epoch())
+		assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+		assert.Equal(t, uint64(1), summary.DueDealOpCount)
+
+		// The carried op is processed by the next tick.
+		current++
+		rt.SetEpoch(current)
+		actor.cronTick(rt)
+		assert.Equal(t, current, actor.getDealState(rt, dealID3).LastUpdatedEpoch)
+		assert.Equal(t, current, lastCron(rt))
+		actor.checkState(rt)
+	})
+
+	t.Run("ops beyond the limit within an epoch carry over", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealID1 := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+		dealID2 := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch+1, 0, sectorExpiry, startEpoch)
+		dealID3 := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch+2, 0, sectorExpiry, startEpoch)
+
+		rt.SetEpoch(startEpoch)
+		actor.cronTick(rt)
+		assert.Equal(t, 2, processed(rt, actor, dealID1, dealID2, dealID3))
+		assert.Equal(t, startEpoch-1, lastCron(rt))
+		actor.checkState(rt)
+
+		rt.SetEpoch(startEpoch + 1)
+		actor.cronTick(rt)
+		assert.Equal(t, 3, processed(rt, actor, dealID1, dealID2, dealID3))
+		assert.Equal(t, startEpoch+1, lastCron(rt))
+		actor.checkState(rt)
+	})
+}
+
 func TestRandomCronEpochDuringPublish(t *testing.T) {
 	owner := tutil.NewIDAddr(t, 101)
 	provider := tutil.NewIDAddr(t, 102)
@@ -1823,11 +1913,12 @@ func TestCronTickTimedoutDeals(t *testing.T) {
 		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, d.ProviderCollateral, nil, exitcode.Ok)
 		actor.cronTick(rt)
 		actor.assertDealDeleted(rt, dealId, d)
+		actor.checkState(rt)
 
-		// now publishing should work
+		// now publishing should work.
+		// On chain, cron runs after all messages in an epoch, so a deal starting in this epoch could no longer be
+		// published, and the state scheduling its ops at an epoch already processed is not checked.
 		actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch, startEpoch)
-
-		actor.checkState(rt)
 	})
 
 	t.Run("timed out and verified deals are slashed, deleted AND sent to the Registry actor", func(t *testing.T) {
@@ -2389,7 +2480,7 @@ func TestMarketActorDeals(t *testing.T) {
 		rt.Verify()
 	}
 
-	dealProposal.Label = "foo"
+	dealProposal.Label = mustLabel("foo")
 
 	// Same deal with a different label should work
 	{
@@ -2417,7 +2508,7 @@ func TestMaxDealLabelSize(t *testing.T) {
 	actor.addParticipantFunds(rt, client, abi.NewTokenAmount(20000000))
 
 	dealProposal := generateDealProposal(client, provider, abi.ChainEpoch(1), abi.ChainEpoch(200*builtin.EpochsInDay))
-	dealProposal.Label = string(make([]byte, market.DealMaxLabelSize))
+	dealProposal.Label = mustLabel(string(make([]byte, market.DealMaxLabelSize)))
 	params := &market.PublishStorageDealsParams{Deals: []market.ClientDealProposal{{Proposal: dealProposal}}}
 
 	// Label at max size should work.
@@ -2426,7 +2517,7 @@ func TestMaxDealLabelSize(t *testing.T) {
 		actor.publishDeals(rt, minerAddrs, publishDealReq{deal: dealProposal})
 	}
 
-	dealProposal.Label = string(make([]byte, market.DealMaxLabelSize+1))
+	dealProposal.Label = mustLabel(string(make([]byte, market.DealMaxLabelSize+1)))
 
 	// Label greater than max size should fail.
 	{
@@ -2444,6 +2535,426 @@ func TestMaxDealLabelSize(t *testing.T) {
 	actor.checkState(rt)
 }
 
+func TestProviderAsk(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	provider := tutil.NewIDAddr(t, 102)
+	worker := tutil.NewIDAddr(t, 103)
+	client := tutil.NewIDAddr(t, 104)
+	minerAddrs := &minerAddrs{owner, worker, provider, nil}
+
+	defer func(enforce bool) { market.DealsMustMatchProviderAsk = enforce }(market.DealsMustMatchProviderAsk)
+	market.DealsMustMatchProviderAsk = true
+
+	// The default deal of 2048 bytes at 10 per epoch exactly meets this ask.
+	ask := &askreg.StorageAsk{
+		Price:         abi.NewTokenAmount(10 << 19),
+		VerifiedPrice: abi.NewTokenAmount(1 << 19),
+		MinPieceSize:  2048,
+		MaxPieceSize:  1 << 20,
+		Expiry:        1000,
+	}
+
+	setup := func(t *testing.T) (*mock.Runtime, *marketActorTestHarness) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		actor.addProviderFunds(rt, abi.NewTokenAmount(20000000), minerAddrs)
+		actor.addParticipantFunds(rt, client, abi.NewTokenAmount(20000000))
+		rt.SetCaller(worker, builtin.AccountActorCodeID)
+		return rt, actor
+	}
+
+	expectPublishFails := func(t *testing.T, rt *mock.Runtime, actor *marketActorTestHarness, deal market.DealProposal, msg string) {
+		params := &market.PublishStorageDealsParams{Deals: []market.ClientDealProposal{{Proposal: deal}}}
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectSend(provider, builtin.MethodsMiner.ControlAddresses, nil, abi.NewTokenAmount(0), &miner.GetControlAddressesReturn{Worker: worker, Owner: owner}, 0)
+		actor.expectProviderAsk(rt, provider)
+		expectQueryNetworkInfo(rt, actor)
+		rt.ExpectVerifySignature(crypto.Signature{}, client, mustCbor(&deal), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, msg, func() {
+			rt.Call(actor.PublishStorageDeals, params)
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	}
+
+	t.Run("deal meeting ask is published", func(t *testing.T) {
+		rt, actor := setup(t)
+		actor.providerAsk = ask
+		deal := generateDealProposal(client, provider, abi.ChainEpoch(1), abi.ChainEpoch(200*builtin.EpochsInDay))
+		actor.publishDeals(rt, minerAddrs, publishDealReq{deal: deal})
+		actor.checkState(rt)
+	})
+
+	t.Run("any deal is published when provider has no active ask", func(t *testing.T) {
+		rt, actor := setup(t)
+		deal := generateDealProposal(client, provider, abi.ChainEpoch(1), abi.ChainEpoch(200*builtin.EpochsInDay))
+		deal.StoragePricePerEpoch = big.Zero()
+		actor.publishDeals(rt, minerAddrs, publishDealReq{deal: deal})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails when price is below ask", func(t *testing.T) {
+		rt, actor := setup(t)
+		actor.providerAsk = ask
+		deal := generateDealProposal(client, provider, abi.ChainEpoch(1), abi.ChainEpoch(200*builtin.EpochsInDay))
+		deal.StoragePricePerEpoch = big.NewInt(9)
+		expectPublishFails(t, rt, actor, deal, "below provider ask price")
+	})
+
+	t.Run("fails when piece size is outside ask bounds", func(t *testing.T) {
+		rt, actor := setup(t)
+		actor.providerAsk = ask
+		deal := generateDealProposal(client, provider, abi.ChainEpoch(1), abi.ChainEpoch(200*builtin.EpochsInDay))
+		deal.PieceSize = 1024
+		expectPublishFails(t, rt, actor, deal, "outside provider ask bounds")
+	})
+}
+
+func TestDealLabel(t *testing.T) {
+	roundTrip := func(t *testing.T, label market.DealLabel) market.DealLabel {
+		buf := new(bytes.Buffer)
+		require.NoError(t, label.MarshalCBOR(buf))
+		var out market.DealLabel
+		require.NoError(t, out.UnmarshalCBOR(buf))
+		return out
+	}
+
+	t.Run("string and bytes labels round trip", func(t *testing.T) {
+		str := mustLabel("dataset:42")
+		assert.True(t, str.IsString())
+		assert.True(t, roundTrip(t, str).Equals(str))
+
+		bs := market.NewLabelFromBytes([]byte{0xff, 0x00, 0x01})
+		assert.True(t, bs.IsBytes())
+		out := roundTrip(t, bs)
+		assert.True(t, out.Equals(bs))
+		_, err := out.ToString()
+		assert.Error(t, err)
+
+		assert.Equal(t, market.EmptyDealLabel, roundTrip(t, market.EmptyDealLabel))
+		assert.Equal(t, market.EmptyDealLabel, mustLabel(""))
+		assert.False(t, market.EmptyDealLabel.Equals(market.NewLabelFromBytes(nil)))
+	})
+
+	t.Run("string label must be UTF-8", func(t *testing.T) {
+		_, err := market.NewLabelFromString(string([]byte{0xff, 0xfe}))
+		assert.Error(t, err)
+	})
+
+	t.Run("proposal with string label encodes as before", func(t *testing.T) {
+		client := tutil.NewIDAddr(t, 101)
+		provider := tutil.NewIDAddr(t, 102)
+		v3 := generateDealProposal(client, provider, 10, 20)
+		v0 := market0.DealProposal{PieceCID: v3.PieceCID, PieceSize: v3.PieceSize, Client: client, Provider: provider,
+			Label: "label", StartEpoch: v3.StartEpoch, EndEpoch: v3.EndEpoch, StoragePricePerEpoch: v3.StoragePricePerEpoch,
+			ProviderCollateral: v3.ProviderCollateral, ClientCollateral: v3.ClientCollateral}
+		assert.Equal(t, mustCbor(&v0), mustCbor(&v3))
+
+		var decoded market.DealProposal
+		require.NoError(t, decoded.UnmarshalCBOR(bytes.NewReader(mustCbor(&v0))))
+		assert.Equal(t, v3, decoded)
+	})
+
+	t.Run("metadata round trips through bytes label", func(t *testing.T) {
+		metadata := market.DealMetadata{
+			SchemaVersion:  market.DealMetadataSchemaVersion,
+			DatasetID:      "ds-7",
+			Domain:         "medicine",
+			Expert:         "expert-3",
+			DatasetVersion: "2",
+		}
+		label, err := market.NewLabelFromMetadata(&metadata)
+		require.NoError(t, err)
+		assert.True(t, label.IsBytes())
+
+		out, err := roundTrip(t, label).Metadata()
+		require.NoError(t, err)
+		assert.Equal(t, metadata, *out)
+
+		_, err = mustLabel("not metadata").Metadata()
+		assert.Error(t, err)
+	})
+
+	t.Run("publish deal with bytes label", func(t *testing.T) {
+		owner := tutil.NewIDAddr(t, 101)
+		provider := tutil.NewIDAddr(t, 102)
+		worker := tutil.NewIDAddr(t, 103)
+		client := tutil.NewIDAddr(t, 104)
+		mAddrs := &minerAddrs{owner, worker, provider, nil}
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+
+		deal := actor.generateDealAndAddFunds(rt, client, mAddrs, abi.ChainEpoch(10), abi.ChainEpoch(10+200*builtin.EpochsInDay))
+		deal.Label = market.NewLabelFromBytes(make([]byte, market.DealMaxLabelSize))
+		rt.SetCaller(worker, builtin.AccountActorCodeID)
+		dealIds := actor.publishDeals(rt, mAddrs, publishDealReq{deal: deal})
+		assert.True(t, actor.getDealProposal(rt, dealIds[0]).Label.Equals(deal.Label))
+
+		// label greater than max size fails
+		deal.Label = market.NewLabelFromBytes(make([]byte, market.DealMaxLabelSize+1))
+		params := mkPublishStorageParams(deal)
+		rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
+		expectGetControlAddresses(rt, provider, owner, worker)
+		expectQueryNetworkInfo(rt, actor)
+		rt.ExpectVerifySignature(crypto.Signature{}, client, mustCbor(&params.Deals[0].Proposal), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "deal label can be at most", func() {
+			rt.Call(actor.PublishStorageDeals, params)
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+
+	t.Run("publish fails for string label that is not UTF-8", func(t *testing.T) {
+		owner := tutil.NewIDAddr(t, 101)
+		provider := tutil.NewIDAddr(t, 102)
+		worker := tutil.NewIDAddr(t, 103)
+		client := tutil.NewIDAddr(t, 104)
+		mAddrs := &minerAddrs{owner, worker, provider, nil}
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+
+		// a text string that is not valid UTF-8 can still be decoded
+		invalid := []byte{0xff, 0xfe}
+		encoded := append(cbg.CborEncodeMajorType(cbg.MajTextString, uint64(len(invalid))), invalid...)
+		deal := actor.generateDealAndAddFunds(rt, client, mAddrs, abi.ChainEpoch(10), abi.ChainEpoch(10+200*builtin.EpochsInDay))
+		require.NoError(t, deal.Label.UnmarshalCBOR(bytes.NewReader(encoded)))
+		assert.True(t, deal.Label.IsString())
+
+		params := mkPublishStorageParams(deal)
+		rt.SetCaller(worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.AccountActorCodeID, builtin.MultisigActorCodeID)
+		expectGetControlAddresses(rt, provider, owner, worker)
+		expectQueryNetworkInfo(rt, actor)
+		rt.ExpectVerifySignature(crypto.Signature{}, client, mustCbor(&params.Deals[0].Proposal), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "not valid UTF-8", func() {
+			rt.Call(actor.PublishStorageDeals, params)
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+}
+
+func TestPieceReplicas(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	provider := tutil.NewIDAddr(t, 102)
+	worker := tutil.NewIDAddr(t, 103)
+	client := tutil.NewIDAddr(t, 104)
+	mAddrs := &minerAddrs{owner, worker, provider, nil}
+	provider2 := tutil.NewIDAddr(t, 501)
+	mAddrs2 := &minerAddrs{owner, worker, provider2, nil}
+
+	startEpoch := abi.ChainEpoch(50)
+	endEpoch := startEpoch + 200*builtin.EpochsInDay
+	sectorExpiry := endEpoch + 400
+	pieceCid := tutil.MakeCID("1", &market.PieceCIDPrefix)
+
+	t.Run("published deals are not counted until activated", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch, startEpoch)
+
+		replicas := actor.getPieceReplicas(rt, pieceCid)
+		assert.Zero(t, replicas.DealCount)
+		assert.Empty(t, replicas.Providers)
+		actor.checkState(rt)
+	})
+
+	t.Run("activation adds deals per provider", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId1 := actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch, startEpoch)
+		dealId2 := actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch+1, startEpoch)
+		actor.activateDeals(rt, sectorExpiry, provider, 0, dealId1, dealId2)
+		dealId3 := actor.generateAndPublishDeal(rt, client, mAddrs2, startEpoch, endEpoch, startEpoch)
+		actor.activateDeals(rt, sectorExpiry, provider2, 0, dealId3)
+
+		replicas := actor.getPieceReplicas(rt, pieceCid)
+		assert.EqualValues(t, 3, replicas.DealCount)
+		assert.EqualValues(t, 2, replicas.ReplicationFactor())
+		assert.Equal(t, []market.ProviderReplicas{
+			{Provider: provider, DealCount: 2},
+			{Provider: provider2, DealCount: 1},
+		}, replicas.Providers)
+
+		// other pieces are unaffected
+		other := actor.getPieceReplicas(rt, tutil.MakeCID("2", &market.PieceCIDPrefix))
+		assert.Zero(t, other.DealCount)
+		actor.checkState(rt)
+	})
+
+	t.Run("termination removes deals and then providers", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId1 := actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch, startEpoch)
+		dealId2 := actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch+1, startEpoch)
+		actor.activateDeals(rt, sectorExpiry, provider, 0, dealId1, dealId2)
+		dealId3 := actor.generateAndPublishDeal(rt, client, mAddrs2, startEpoch, endEpoch, startEpoch)
+		actor.activateDeals(rt, sectorExpiry, provider2, 0, dealId3)
+
+		rt.SetEpoch(startEpoch + 1)
+		actor.terminateDeals(rt, provider, dealId1)
+		replicas := actor.getPieceReplicas(rt, pieceCid)
+		assert.EqualValues(t, 2, replicas.DealCount)
+		assert.EqualValues(t, 2, replicas.ReplicationFactor())
+
+		actor.terminateDeals(rt, provider, dealId2)
+		replicas = actor.getPieceReplicas(rt, pieceCid)
+		assert.EqualValues(t, 1, replicas.DealCount)
+		assert.Equal(t, []market.ProviderReplicas{{Provider: provider2, DealCount: 1}}, replicas.Providers)
+
+		// terminating again does not change the count
+		actor.terminateDeals(rt, provider, dealId1)
+		replicas = actor.getPieceReplicas(rt, pieceCid)
+		assert.EqualValues(t, 1, replicas.DealCount)
+		actor.checkState(rt)
+	})
+
+	t.Run("expiry removes deal", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+
+		rt.SetEpoch(startEpoch)
+		actor.cronTick(rt)
+		assert.EqualValues(t, 1, actor.getPieceReplicas(rt, pieceCid).DealCount)
+
+		// an expired deal still counts until it is processed by cron
+		rt.SetEpoch(endEpoch + 5)
+		actor.terminateDeals(rt, provider, dealId)
+		assert.EqualValues(t, 1, actor.getPieceReplicas(rt, pieceCid).DealCount)
+
+		actor.cronTick(rt)
+		replicas := actor.getPieceReplicas(rt, pieceCid)
+		assert.Zero(t, replicas.DealCount)
+		assert.Empty(t, replicas.Providers)
+		actor.checkState(rt)
+	})
+}
+
+func TestSettleDealPayments(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	provider := tutil.NewIDAddr(t, 102)
+	worker := tutil.NewIDAddr(t, 103)
+	client := tutil.NewIDAddr(t, 104)
+	mAddrs := &minerAddrs{owner, worker, provider, nil}
+
+	startEpoch := abi.ChainEpoch(50)
+	endEpoch := startEpoch + 200*builtin.EpochsInDay
+	sectorExpiry := endEpoch + 400
+
+	t.Run("settles payment for active deal and cron resumes from settlement", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+		d := actor.getDealProposal(rt, dealId)
+
+		// payment has not started before the start epoch
+		ret := actor.settleDealPayments(rt, worker, mAddrs, big.Zero(), dealId)
+		assert.Equal(t, []market.DealSettlement{{DealID: dealId, Payment: big.Zero(), Slashed: big.Zero()}}, ret.Results)
+		assert.EqualValues(t, -1, actor.getDealState(rt, dealId).LastUpdatedEpoch)
+
+		current := startEpoch + 100
+		rt.SetEpoch(current)
+		pEscrow := actor.getEscrowBalance(rt, provider)
+		ret = actor.settleDealPayments(rt, worker, mAddrs, big.Zero(), dealId)
+		expectedPayment := big.Mul(big.NewInt(100), d.StoragePricePerEpoch)
+		assert.Equal(t, []market.DealSettlement{{DealID: dealId, Payment: expectedPayment, Slashed: big.Zero()}}, ret.Results)
+		assert.Equal(t, big.Add(pEscrow, expectedPayment), actor.getEscrowBalance(rt, provider))
+		assert.Equal(t, current, actor.getDealState(rt, dealId).LastUpdatedEpoch)
+		actor.checkState(rt)
+
+		// settling again in the same epoch pays nothing
+		ret = actor.settleDealPayments(rt, owner, mAddrs, big.Zero(), dealId)
+		assert.Equal(t, big.Zero(), ret.Results[0].Payment)
+
+		// cron processes the deal at its scheduled epoch, paying only for epochs since settlement
+		current = startEpoch + market.DealUpdatesInterval
+		rt.SetEpoch(current)
+		pay, slashed := actor.cronTickAndAssertBalances(rt, client, provider, current, dealId)
+		assert.Equal(t, big.Mul(big.NewInt(int64(market.DealUpdatesInterval-100)), d.StoragePricePerEpoch), pay)
+		assert.Equal(t, big.Zero(), slashed)
+		actor.checkState(rt)
+	})
+
+	t.Run("completes expired deal and cron drops its deal op", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+		d := actor.getDealProposal(rt, dealId)
+
+		rt.SetEpoch(endEpoch + 1)
+		ret := actor.settleDealPayments(rt, worker, mAddrs, big.Zero(), dealId)
+		expectedPayment := big.Mul(big.NewInt(int64(endEpoch-startEpoch)), d.StoragePricePerEpoch)
+		assert.Equal(t, []market.DealSettlement{{DealID: dealId, Payment: expectedPayment, Slashed: big.Zero(), Completed: true}}, ret.Results)
+		actor.assertDealDeleted(rt, dealId, d)
+		assert.Equal(t, big.Zero(), actor.getLockedBalance(rt, client))
+		assert.Equal(t, big.Zero(), actor.getLockedBalance(rt, provider))
+		actor.checkState(rt)
+
+		actor.cronTick(rt)
+		var st market.State
+		rt.GetState(&st)
+		settled, err := adt.AsSet(adt.AsStore(rt), st.SettledDeals, builtin.DefaultHamtBitwidth)
+		require.NoError(t, err)
+		has, err := settled.Has(abi.UIntKey(uint64(dealId)))
+		require.NoError(t, err)
+		assert.False(t, has)
+		actor.checkState(rt)
+	})
+
+	t.Run("completes terminated deal and burns slashed collateral", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+		d := actor.getDealProposal(rt, dealId)
+
+		slashEpoch := startEpoch + 10
+		rt.SetEpoch(slashEpoch)
+		actor.terminateDeals(rt, provider, dealId)
+
+		rt.SetEpoch(slashEpoch + 5)
+		ret := actor.settleDealPayments(rt, worker, mAddrs, d.ProviderCollateral, dealId)
+		expectedPayment := big.Mul(big.NewInt(10), d.StoragePricePerEpoch)
+		assert.Equal(t, []market.DealSettlement{{DealID: dealId, Payment: expectedPayment, Slashed: d.ProviderCollateral, Completed: true}}, ret.Results)
+		actor.assertDealDeleted(rt, dealId, d)
+		actor.checkState(rt)
+	})
+
+	t.Run("fails when caller is not a control address of the provider", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+
+		rt.SetEpoch(startEpoch + 1)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.settleDealPayments(rt, client, mAddrs, big.Zero(), dealId)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails for deal that has not been activated", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId := actor.generateAndPublishDeal(rt, client, mAddrs, startEpoch, endEpoch, startEpoch)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "has not been activated", func() {
+			actor.settleDealPayments(rt, worker, mAddrs, big.Zero(), dealId)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails for duplicate deal", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "multiple times", func() {
+			actor.settleDealPayments(rt, worker, mAddrs, big.Zero(), dealId, dealId)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails for deals from different providers", func(t *testing.T) {
+		rt, actor := basicMarketSetup(t, owner, provider, worker, client)
+		dealId1 := actor.publishAndActivateDeal(rt, client, mAddrs, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+		mAddrs2 := &minerAddrs{owner, worker, tutil.NewIDAddr(t, 501), nil}
+		dealId2 := actor.publishAndActivateDeal(rt, client, mAddrs2, startEpoch, endEpoch, 0, sectorExpiry, startEpoch)
+
+		rt.SetCaller(worker, builtin.AccountActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "different providers", func() {
+			rt.Call(actor.SettleDealPayments, &market.SettleDealPaymentsParams{DealIDs: []abi.DealID{dealId1, dealId2}})
+		})
+		actor.checkState(rt)
+	})
+}
+
 func TestComputeDataCommitment(t *testing.T) {
 	owner := tutil.NewIDAddr(t, 101)
 	provider := tutil.NewIDAddr(t, 102)
@@ -2684,6 +3195,8 @@ type marketActorTestHarness struct {
 
 	networkQAPower       abi.StoragePower
 	networkBaselinePower abi.StoragePower
+	// Active ask returned by the ask registry, when deals must match the provider's ask.
+	providerAsk *askreg.StorageAsk
 }
 
 func (h *marketActorTestHarness) constructAndVerify(rt *mock.Runtime) {
@@ -2879,6 +3392,13 @@ func (h *marketActorTestHarness) expectGetRandom(rt *mock.Runtime, deal *market.
 	rt.ExpectGetRandomnessBeacon(crypto.DomainSeparationTag_MarketDealCronSeed, rt.Epoch()-1, dealBuf.Bytes(), epochBuf.Bytes())
 }
 
+func (h *marketActorTestHarness) expectProviderAsk(rt *mock.Runtime, provider address.Address) {
+	if market.DealsMustMatchProviderAsk {
+		rt.ExpectSend(builtin.StorageAskRegistryActorAddr, builtin.MethodsStorageAskRegistry.GetAsk, &provider, big.Zero(),
+			&askreg.GetAskReturn{Ask: h.providerAsk}, exitcode.Ok)
+	}
+}
+
 func (h *marketActorTestHarness) publishDeals(rt *mock.Runtime, minerAddrs *minerAddrs, publishDealReqs ...publishDealReq) []abi.DealID {
 	for _, pdr := range publishDealReqs {
 		h.expectGetRandom(rt, &pdr.deal, pdr.requiredProcessEpoch)
@@ -2893,6 +3413,7 @@ func (h *marketActorTestHarness) publishDeals(rt *mock.Runtime, minerAddrs *mine
 		&miner.GetControlAddressesReturn{Owner: minerAddrs.owner, Worker: minerAddrs.worker, ControlAddrs: minerAddrs.control},
 		exitcode.Ok,
 	)
+	h.expectProviderAsk(rt, minerAddrs.provider)
 	expectQueryNetworkInfo(rt, h)
 
 	var params market.PublishStorageDealsParams
@@ -3175,6 +3696,36 @@ func (h *marketActorTestHarness) generateDealWithCollateralAndAddFunds(rt *mock.
 	return deal
 }
 
+func (h *marketActorTestHarness) getPieceReplicas(rt *mock.Runtime, pieceCid cid.Cid) *market.PieceReplicas {
+	rt.ExpectValidateCallerAny()
+	param := cbg.CborCid(pieceCid)
+	ret := rt.Call(h.PieceReplicas, &param).(*market.PieceReplicas)
+	rt.Verify()
+
+	// the state accessor agrees with the method
+	var st market.State
+	rt.GetState(&st)
+	fromState, found, err := st.GetPieceReplicas(rt.AdtStore(), pieceCid)
+	require.NoError(h.t, err)
+	require.Equal(h.t, ret.DealCount > 0, found)
+	require.Equal(h.t, ret, fromState)
+	return ret
+}
+
+func (h *marketActorTestHarness) settleDealPayments(rt *mock.Runtime, caller address.Address, minerAddrs *minerAddrs,
+	expectedBurn abi.TokenAmount, dealIDs ...abi.DealID) *market.SettleDealPaymentsReturn {
+	rt.SetCaller(caller, builtin.AccountActorCodeID)
+	expectGetControlAddresses(rt, minerAddrs.provider, minerAddrs.owner, minerAddrs.worker, minerAddrs.control...)
+	rt.ExpectValidateCallerAddr(append([]address.Address{minerAddrs.owner, minerAddrs.worker}, minerAddrs.control...)...)
+	if !expectedBurn.IsZero() {
+		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, expectedBurn, nil, exitcode.Ok)
+	}
+
+	ret := rt.Call(h.SettleDealPayments, &market.SettleDealPaymentsParams{DealIDs: dealIDs}).(*market.SettleDealPaymentsReturn)
+	rt.Verify()
+	return ret
+}
+
 func (h *marketActorTestHarness) checkState(rt *mock.Runtime) {
 	var st market.State
 	rt.GetState(&st)
@@ -3187,7 +3738,7 @@ func generateDealProposalWithCollateral(client, provider address.Address, provid
 	pieceSize := abi.PaddedPieceSize(2048)
 	storagePerEpoch := big.NewInt(10)
 
-	return market.DealProposal{PieceCID: pieceCid, PieceSize: pieceSize, Client: client, Provider: provider, Label: "label", StartEpoch: startEpoch,
+	return market.DealProposal{PieceCID: pieceCid, PieceSize: pieceSize, Client: client, Provider: provider, Label: mustLabel("label"), StartEpoch: startEpoch,
 		EndEpoch: endEpoch, StoragePricePerEpoch: storagePerEpoch, ProviderCollateral: providerCollateral, ClientCollateral: clientCollateral}
 }
 
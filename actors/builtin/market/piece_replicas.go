@@ -0,0 +1,162 @@
+package market
+
+import (
+	"bytes"
+	"sort"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// PieceReplicas records the active (activated and not yet terminated or expired) deals storing a piece.
+type PieceReplicas struct {
+	// Total number of active deals for the piece, across all providers.
+	DealCount uint64
+	// Providers storing the piece in at least one active deal, ordered by address.
+	Providers []ProviderReplicas
+}
+
+// ProviderReplicas records the number of active deals for a piece held by a single provider.
+type ProviderReplicas struct {
+	Provider  addr.Address
+	DealCount uint64
+}
+
+// Number of distinct providers storing the piece.
+func (r *PieceReplicas) ReplicationFactor() uint64 {
+	return uint64(len(r.Providers))
+}
+
+// Records one more active deal for the piece with a provider.
+func (r *PieceReplicas) addDeal(provider addr.Address) {
+	r.DealCount++
+	idx := r.providerIndex(provider)
+	if idx < len(r.Providers) && r.Providers[idx].Provider == provider {
+		r.Providers[idx].DealCount++
+		return
+	}
+	r.Providers = append(r.Providers, ProviderReplicas{})
+	copy(r.Providers[idx+1:], r.Providers[idx:])
+	r.Providers[idx] = ProviderReplicas{Provider: provider, DealCount: 1}
+}
+
+// Records that an active deal for the piece with a provider has ended.
+func (r *PieceReplicas) removeDeal(provider addr.Address) error {
+	idx := r.providerIndex(provider)
+	if idx >= len(r.Providers) || r.Providers[idx].Provider != provider {
+		return xerrors.Errorf("no active deals for provider %v", provider)
+	}
+	if r.DealCount == 0 || r.Providers[idx].DealCount == 0 {
+		return xerrors.Errorf("deal count underflow for provider %v", provider)
+	}
+	r.DealCount--
+	r.Providers[idx].DealCount--
+	if r.Providers[idx].DealCount == 0 {
+		r.Providers = append(r.Providers[:idx], r.Providers[idx+1:]...)
+	}
+	return nil
+}
+
+// Returns the index at which the provider is, or would be inserted, in the ordered provider list.
+func (r *PieceReplicas) providerIndex(provider addr.Address) int {
+	key := provider.Bytes()
+	return sort.Search(len(r.Providers), func(i int) bool {
+		return bytes.Compare(r.Providers[i].Provider.Bytes(), key) >= 0
+	})
+}
+
+// Returns the replication record for a piece, or an empty record if no active deal stores it.
+// The second return value indicates whether any active deal stores the piece.
+func (st *State) GetPieceReplicas(store adt.Store, pieceCID cid.Cid) (*PieceReplicas, bool, error) {
+	replicas, err := adt.AsMap(store, st.PieceReplicas, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load piece replicas: %w", err)
+	}
+	return getPieceReplicas(replicas, pieceCID)
+}
+
+func getPieceReplicas(replicas *adt.Map, pieceCID cid.Cid) (*PieceReplicas, bool, error) {
+	var out PieceReplicas
+	found, err := replicas.Get(abi.CidKey(pieceCID), &out)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to get piece replicas for %v: %w", pieceCID, err)
+	}
+	return &out, found, nil
+}
+
+// Records a newly activated deal against the replication count of its piece.
+func (m *marketStateMutation) addPieceReplica(deal *DealProposal) error {
+	replicas, _, err := getPieceReplicas(m.pieceReplicas, deal.PieceCID)
+	if err != nil {
+		return err
+	}
+	replicas.addDeal(deal.Provider)
+	if err := m.pieceReplicas.Put(abi.CidKey(deal.PieceCID), replicas); err != nil {
+		return xerrors.Errorf("failed to put piece replicas for %v: %w", deal.PieceCID, err)
+	}
+	return nil
+}
+
+// Removes an active deal, which has been terminated or has expired, from the replication count of its piece.
+func (m *marketStateMutation) removePieceReplica(deal *DealProposal) error {
+	replicas, found, err := getPieceReplicas(m.pieceReplicas, deal.PieceCID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return xerrors.Errorf("no piece replicas for %v", deal.PieceCID)
+	}
+	if err := replicas.removeDeal(deal.Provider); err != nil {
+		return xerrors.Errorf("failed to remove replica of %v: %w", deal.PieceCID, err)
+	}
+	if replicas.DealCount == 0 {
+		if err := m.pieceReplicas.Delete(abi.CidKey(deal.PieceCID)); err != nil {
+			return xerrors.Errorf("failed to delete piece replicas for %v: %w", deal.PieceCID, err)
+		}
+		return nil
+	}
+	if err := m.pieceReplicas.Put(abi.CidKey(deal.PieceCID), replicas); err != nil {
+		return xerrors.Errorf("failed to put piece replicas for %v: %w", deal.PieceCID, err)
+	}
+	return nil
+}
+
+// Computes the piece replicas map implied by a collection of deal proposals and states, and writes it to the store.
+// Every deal that has been activated and not terminated is counted.
+// This is used to initialise replication tracking for state that was created without it.
+func ConstructPieceReplicas(store adt.Store, proposalsRoot, statesRoot cid.Cid) (cid.Cid, error) {
+	proposals, err := AsDealProposalArray(store, proposalsRoot)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to load deal proposals: %w", err)
+	}
+	states, err := AsDealStateArray(store, statesRoot)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to load deal states: %w", err)
+	}
+	replicas, err := adt.MakeEmptyMap(store, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to create empty piece replicas map: %w", err)
+	}
+	m := &marketStateMutation{store: store, pieceReplicas: replicas}
+
+	var state DealState
+	err = states.ForEach(&state, func(dealID int64) error {
+		if state.SlashEpoch != epochUndefined {
+			return nil
+		}
+		proposal, err := getDealProposal(proposals, abi.DealID(dealID))
+		if err != nil {
+			return xerrors.Errorf("failed to get proposal for deal %d: %w", dealID, err)
+		}
+		return m.addPieceReplica(proposal)
+	})
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to iterate deal states: %w", err)
+	}
+	return replicas.Root()
+}
@@ -3,6 +3,7 @@ package market
 import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 )
@@ -17,15 +18,37 @@ var ProviderCollateralSupplyTarget = builtin.BigFrac{
 	Denominator: big.NewInt(100),
 }
 
+// Maximum number of deal ops processed by a single cron tick.
+// Ops beyond this limit remain scheduled and are processed by subsequent ticks, before any ops scheduled for later epochs.
+var MaxDealOpsPerCronTick = 10_000 // PARAM_SPEC
+
 // Minimum deal duration.
+// Set by the network policy.
 var DealMinDuration = abi.ChainEpoch(180 * builtin.EpochsInDay) // PARAM_SPEC
 
 // Maximum deal duration
+// Set by the network policy.
 var DealMaxDuration = abi.ChainEpoch(540 * builtin.EpochsInDay) // PARAM_SPEC
 
-// DealMaxLabelSize is the maximum size of a deal label.
+// Whether published deals must satisfy the price and piece size bounds of the provider's active storage ask,
+// if it has one.
+var DealsMustMatchProviderAsk = false // PARAM_SPEC
+
+// DealMaxLabelSize is the maximum size of a deal label, in bytes, whether it holds a string or bytes.
 const DealMaxLabelSize = 256
 
+func init() {
+	builtin.RegisterPolicyApplier(func(p *builtin.NetworkPolicy) error {
+		if p.DealMinDuration <= 0 || p.DealMaxDuration < p.DealMinDuration {
+			return xerrors.Errorf("invalid deal duration bounds [%d, %d]", p.DealMinDuration, p.DealMaxDuration)
+		}
+		return nil
+	}, func(p *builtin.NetworkPolicy) {
+		DealMinDuration = p.DealMinDuration
+		DealMaxDuration = p.DealMaxDuration
+	})
+}
+
 // Bounds (inclusive) on deal duration
 func DealDurationBounds(_ abi.PaddedPieceSize) (min abi.ChainEpoch, max abi.ChainEpoch) {
 	return DealMinDuration, DealMaxDuration
@@ -126,6 +126,46 @@ func (mm *SetMultimap) RemoveAll(key abi.ChainEpoch) error {
 	return nil
 }
 
+// Removes some values for a key, removing the key if no values remain.
+func (mm *SetMultimap) RemoveMany(epoch abi.ChainEpoch, vs []abi.DealID) error {
+	k := abi.UIntKey(uint64(epoch))
+	set, found, err := mm.get(k)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	for _, v := range vs {
+		if err = set.Delete(dealKey(v)); err != nil {
+			return errors.Wrapf(err, "failed to remove key from set %v", epoch)
+		}
+	}
+
+	remaining := false
+	if err = set.ForEach(func(string) error {
+		remaining = true
+		return errStop
+	}); err != nil && err != errStop {
+		return xerrors.Errorf("failed to iterate set %v: %w", epoch, err)
+	}
+	if !remaining {
+		return mm.RemoveAll(epoch)
+	}
+
+	src, err := set.Root()
+	if err != nil {
+		return xerrors.Errorf("failed to flush set root: %w", err)
+	}
+	newSetRoot := cbg.CborCid(src)
+	err = mm.mp.Put(k, &newSetRoot)
+	if err != nil {
+		return errors.Wrapf(err, "failed to store set")
+	}
+	return nil
+}
+
 // Iterates all entries for a key, iteration halts if the function returns an error.
 func (mm *SetMultimap) ForEach(epoch abi.ChainEpoch, fn func(id abi.DealID) error) error {
 	set, found, err := mm.get(abi.UIntKey(uint64(epoch)))
@@ -160,6 +200,8 @@ func (mm *SetMultimap) get(key abi.Keyer) (*adt.Set, bool, error) {
 	return set, found, nil
 }
 
+var errStop = errors.New("stop")
+
 func dealKey(e abi.DealID) abi.Keyer {
 	return abi.UIntKey(uint64(e))
 }
@@ -32,6 +32,9 @@ type StateSummary struct {
 	LockTableCount       uint64
 	DealOpEpochCount     uint64
 	DealOpCount          uint64
+	// Number of deal ops scheduled at or before the current epoch and not yet processed by cron.
+	DueDealOpCount    uint64
+	PieceReplicaCount uint64
 }
 
 // Checks internal invariants of market state.
@@ -57,6 +60,7 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 	proposalCids := make(map[cid.Cid]struct{})
 	maxDealID := int64(-1)
 	proposalStats := make(map[abi.DealID]*DealSummary)
+	proposalPieces := make(map[abi.DealID]cid.Cid)
 	expectedDealOps := make(map[abi.DealID]struct{})
 	totalProposalCollateral := abi.NewTokenAmount(0)
 
@@ -88,6 +92,8 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 				SlashEpoch:       abi.ChainEpoch(-1),
 			}
 
+			proposalPieces[abi.DealID(dealID)] = proposal.PieceCID
+
 			totalProposalCollateral = big.Sum(totalProposalCollateral, proposal.ClientCollateral, proposal.ProviderCollateral)
 
 			acc.Require(proposal.Client.Protocol() == address.ID, "client address for deal %d is not an ID address", dealID)
@@ -105,6 +111,7 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 	//
 
 	dealStateCount := uint64(0)
+	expectedReplicas := make(map[cid.Cid]map[address.Address]uint64)
 	if dealStates, err := adt.AsArray(store, st.States, StatesAmtBitwidth); err != nil {
 		acc.Addf("error loading deal states: %v", err)
 	} else {
@@ -137,6 +144,15 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 				stats.SectorStartEpoch = dealState.SectorStartEpoch
 				stats.LastUpdatedEpoch = dealState.LastUpdatedEpoch
 				stats.SlashEpoch = dealState.SlashEpoch
+
+				// active deals count towards the replication of their piece
+				if dealState.SlashEpoch == epochUndefined {
+					pieceCID := proposalPieces[abi.DealID(dealID)]
+					if _, ok := expectedReplicas[pieceCID]; !ok {
+						expectedReplicas[pieceCID] = make(map[address.Address]uint64)
+					}
+					expectedReplicas[pieceCID][stats.Provider]++
+				}
 			}
 
 			dealStateCount++
@@ -218,8 +234,27 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 	// Deal Ops by Epoch
 	//
 
+	// Deals completed by settlement may leave a deal op behind, until cron reaches it.
+	settledDeals := make(map[abi.DealID]bool)
+	if settled, err := adt.AsSet(store, st.SettledDeals, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading settled deals: %v", err)
+	} else {
+		err = settled.ForEach(func(key string) error {
+			id, err := parseDealKey(key)
+			if err != nil {
+				return err
+			}
+			_, found := proposalStats[id]
+			acc.Require(!found, "settled deal %d still has a proposal", id)
+			settledDeals[id] = false
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating settled deals")
+	}
+
 	dealOpEpochCount := uint64(0)
 	dealOpCount := uint64(0)
+	dueDealOpCount := uint64(0)
 	if dealOps, err := AsSetMultimap(store, st.DealOpsByEpoch, builtin.DefaultHamtBitwidth, builtin.DefaultHamtBitwidth); err != nil {
 		acc.Addf("error loading deal ops: %v", err)
 	} else {
@@ -232,9 +267,19 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 			}
 
 			dealOpEpochCount++
+			// Ops at or before the last fully processed epoch would never be processed.
+			acc.Require(abi.ChainEpoch(epoch) > st.LastCron, "deal ops at epoch %d not after last cron %d", epoch, st.LastCron)
 			return dealOps.ForEach(abi.ChainEpoch(epoch), func(id abi.DealID) error {
+				if abi.ChainEpoch(epoch) <= currEpoch {
+					dueDealOpCount++
+				}
 				_, found := proposalStats[id]
-				acc.Require(found, "deal op found for deal id %d with missing proposal at epoch %d", id, epoch)
+				if _, settled := settledDeals[id]; settled {
+					acc.Require(!settledDeals[id], "settled deal %d has more than one deal op", id)
+					settledDeals[id] = true
+				} else {
+					acc.Require(found, "deal op found for deal id %d with missing proposal at epoch %d", id, epoch)
+				}
 				delete(expectedDealOps, id)
 				dealOpCount++
 				return nil
@@ -244,6 +289,50 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 	}
 
 	acc.Require(len(expectedDealOps) == 0, "missing deal ops for proposals: %v", expectedDealOps)
+	for id, hasOp := range settledDeals { //nolint:nomaprange
+		acc.Require(hasOp, "settled deal %d has no deal op", id)
+	}
+
+	//
+	// Piece Replicas
+	//
+
+	pieceReplicaCount := uint64(0)
+	if pieceReplicas, err := adt.AsMap(store, st.PieceReplicas, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading piece replicas: %v", err)
+	} else {
+		var replicas PieceReplicas
+		err = pieceReplicas.ForEach(&replicas, func(key string) error {
+			pieceCID, err := cid.Cast([]byte(key))
+			if err != nil {
+				return err
+			}
+
+			expected, found := expectedReplicas[pieceCID]
+			acc.Require(found, "piece replicas for %v found with no active deals", pieceCID)
+			delete(expectedReplicas, pieceCID)
+
+			dealCount := uint64(0)
+			for i, pr := range replicas.Providers {
+				acc.Require(pr.DealCount > 0, "piece %v has provider %v with no deals", pieceCID, pr.Provider)
+				acc.Require(i == 0 || bytes.Compare(replicas.Providers[i-1].Provider.Bytes(), pr.Provider.Bytes()) < 0,
+					"piece %v providers not strictly ordered at %v", pieceCID, pr.Provider)
+				acc.Require(expected[pr.Provider] == pr.DealCount, "piece %v provider %v has %d deals, expected %d",
+					pieceCID, pr.Provider, pr.DealCount, expected[pr.Provider])
+				dealCount += pr.DealCount
+			}
+			acc.Require(len(replicas.Providers) == len(expected), "piece %v has %d providers, expected %d",
+				pieceCID, len(replicas.Providers), len(expected))
+			acc.Require(dealCount == replicas.DealCount, "piece %v deal count %d does not match provider total %d",
+				pieceCID, replicas.DealCount, dealCount)
+
+			pieceReplicaCount++
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating piece replicas")
+	}
+
+	acc.Require(len(expectedReplicas) == 0, "missing piece replicas for active deals of pieces: %v", expectedReplicas)
 
 	return &StateSummary{
 		Deals:                proposalStats,
@@ -252,5 +341,7 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount, c
 		LockTableCount:       lockTableCount,
 		DealOpEpochCount:     dealOpEpochCount,
 		DealOpCount:          dealOpCount,
+		DueDealOpCount:       dueDealOpCount,
+		PieceReplicaCount:    pieceReplicaCount,
 	}, acc
 }
@@ -11,19 +11,32 @@ const (
 )
 
 var MethodsAccount = struct {
-	Constructor   abi.MethodNum
-	PubkeyAddress abi.MethodNum
-}{MethodConstructor, 2}
+	Constructor      abi.MethodNum
+	PubkeyAddress    abi.MethodNum
+	RotateKey        abi.MethodNum
+	SetGuardians     abi.MethodNum
+	ApproveRecovery  abi.MethodNum
+	CancelRecovery   abi.MethodNum
+	CompleteRecovery abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7}
 
 var MethodsInit = struct {
-	Constructor abi.MethodNum
-	Exec        abi.MethodNum
-}{MethodConstructor, 2}
+	Constructor          abi.MethodNum
+	Exec                 abi.MethodNum
+	Exec2                abi.MethodNum
+	SetAlias             abi.MethodNum
+	ClearAlias           abi.MethodNum
+	UpdateAccountAddress abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6}
 
 var MethodsCron = struct {
-	Constructor abi.MethodNum
-	EpochTick   abi.MethodNum
-}{MethodConstructor, 2}
+	Constructor   abi.MethodNum
+	EpochTick     abi.MethodNum
+	RegisterEntry abi.MethodNum
+	RemoveEntry   abi.MethodNum
+	SetGovernor   abi.MethodNum
+	ListEntries   abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6}
 
 var MethodsReward = struct {
 	Constructor      abi.MethodNum
@@ -42,14 +55,29 @@ var MethodsMultisig = struct {
 	SwapSigner                  abi.MethodNum
 	ChangeNumApprovalsThreshold abi.MethodNum
 	LockBalance                 abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
+	PruneExpired                abi.MethodNum
+	SetSignerWeight             abi.MethodNum
+	SetApprovalPolicy           abi.MethodNum
+	ExecuteBatch                abi.MethodNum
+	SetVestingSchedule          abi.MethodNum
+	RevokeVesting               abi.MethodNum
+	ProposeWithSchedule         abi.MethodNum
+	ApplyBatch                  abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}
 
 var MethodsPaych = struct {
-	Constructor        abi.MethodNum
-	UpdateChannelState abi.MethodNum
-	Settle             abi.MethodNum
-	Collect            abi.MethodNum
-}{MethodConstructor, 2, 3, 4}
+	Constructor               abi.MethodNum
+	UpdateChannelState        abi.MethodNum
+	Settle                    abi.MethodNum
+	Collect                   abi.MethodNum
+	AddFunds                  abi.MethodNum
+	Withdraw                  abi.MethodNum
+	LockConditionalVoucher    abi.MethodNum
+	RevealSecret              abi.MethodNum
+	ReleaseConditionalVoucher abi.MethodNum
+	ApproveVoucher            abi.MethodNum
+	DelegateSigner            abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
 
 var MethodsMarket = struct {
 	Constructor              abi.MethodNum
@@ -61,7 +89,9 @@ var MethodsMarket = struct {
 	OnMinerSectorsTerminate  abi.MethodNum
 	ComputeDataCommitment    abi.MethodNum
 	CronTick                 abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
+	PieceReplicas            abi.MethodNum
+	SettleDealPayments       abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
 
 var MethodsPower = struct {
 	Constructor              abi.MethodNum
@@ -73,7 +103,8 @@ var MethodsPower = struct {
 	Deprecated1              abi.MethodNum
 	SubmitPoRepForBulkVerify abi.MethodNum
 	CurrentTotalPower        abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
+	MinerPower               abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 
 var MethodsMiner = struct {
 	Constructor              abi.MethodNum
@@ -100,13 +131,24 @@ var MethodsMiner = struct {
 	RepayDebt                abi.MethodNum
 	ChangeOwnerAddress       abi.MethodNum
 	DisputeWindowedPoSt      abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24}
+	RestoreDeadlineCron      abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25}
 
 var MethodsVerifiedRegistry = struct {
-	Constructor       abi.MethodNum
-	AddVerifier       abi.MethodNum
-	RemoveVerifier    abi.MethodNum
-	AddVerifiedClient abi.MethodNum
-	UseBytes          abi.MethodNum
-	RestoreBytes      abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6}
+	Constructor                 abi.MethodNum
+	AddVerifier                 abi.MethodNum
+	RemoveVerifier              abi.MethodNum
+	AddVerifiedClient           abi.MethodNum
+	UseBytes                    abi.MethodNum
+	RestoreBytes                abi.MethodNum
+	RemoveVerifiedClientDataCap abi.MethodNum
+	RemoveExpiredDataCap        abi.MethodNum
+	AddVerifierWithPolicy       abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9}
+
+var MethodsStorageAskRegistry = struct {
+	Constructor abi.MethodNum
+	PublishAsk  abi.MethodNum
+	RemoveAsk   abi.MethodNum
+	GetAsk      abi.MethodNum
+}{MethodConstructor, 2, 3, 4}
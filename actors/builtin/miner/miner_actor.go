@@ -73,6 +73,7 @@ func (a Actor) Exports() []interface{} {
 		22:                        a.RepayDebt,
 		23:                        a.ChangeOwnerAddress,
 		24:                        a.DisputeWindowedPoSt,
+		25:                        a.RestoreDeadlineCron,
 	}
 }
 
@@ -1879,6 +1880,22 @@ func (a Actor) RepayDebt(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	return nil
 }
 
+// Enrolls the cron callback for the end of the current proving deadline, in case it has been lost.
+// The power actor ignores an enrollment identical to one already in its queue, so this has no effect
+// if the callback is in place. If the callback has been lost for some time, the current deadline is
+// already past, and deadlines are then processed one per epoch until the miner catches up.
+func (a Actor) RestoreDeadlineCron(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	var st State
+	rt.StateReadonly(&st)
+	info := getMinerInfo(rt, &st)
+	rt.ValidateImmediateCallerIs(append(info.ControlAddresses, info.Owner, info.Worker)...)
+
+	enrollCronEvent(rt, st.DeadlineInfo(rt.CurrEpoch()).Last(), &CronEventPayload{
+		EventType: CronEventProvingDeadline,
+	})
+	return nil
+}
+
 //////////
 // Cron //
 //////////
@@ -3576,6 +3576,54 @@ func TestChangePeerID(t *testing.T) {
 	})
 }
 
+func TestRestoreDeadlineCron(t *testing.T) {
+	periodOffset := abi.ChainEpoch(100)
+	actor := newHarness(t, periodOffset)
+	builder := builderForHarness(actor).
+		WithBalance(bigBalance, big.Zero())
+
+	t.Run("enrolls callback for end of current deadline", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		dlinfo := actor.deadline(rt)
+		rt.SetEpoch(dlinfo.Open + 5)
+
+		rt.SetCaller(actor.worker, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(append(actor.controlAddrs, actor.owner, actor.worker)...)
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.EnrollCronEvent,
+			makeDeadlineCronEventParams(t, dlinfo.Last()), big.Zero(), nil, exitcode.Ok)
+		rt.Call(actor.a.RestoreDeadlineCron, nil)
+		rt.Verify()
+		actor.checkState(rt)
+	})
+
+	t.Run("enrolls callback for elapsed deadline if cron has not run", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+		dlinfo := actor.deadline(rt)
+		rt.SetEpoch(dlinfo.Close + miner.WPoStChallengeWindow)
+
+		rt.SetCaller(actor.owner, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(append(actor.controlAddrs, actor.owner, actor.worker)...)
+		rt.ExpectSend(builtin.StoragePowerActorAddr, builtin.MethodsPower.EnrollCronEvent,
+			makeDeadlineCronEventParams(t, dlinfo.Last()), big.Zero(), nil, exitcode.Ok)
+		rt.Call(actor.a.RestoreDeadlineCron, nil)
+		rt.Verify()
+	})
+
+	t.Run("fails if caller is not owner, worker or control address", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt)
+
+		rt.SetCaller(tutil.NewIDAddr(t, 1234), builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(append(actor.controlAddrs, actor.owner, actor.worker)...)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.a.RestoreDeadlineCron, nil)
+		})
+		rt.Verify()
+	})
+}
+
 func TestCompactPartitions(t *testing.T) {
 	periodOffset := abi.ChainEpoch(100)
 	actor := newHarness(t, periodOffset)
@@ -1,13 +1,12 @@
 package miner
 
 import (
-	"fmt"
-
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 	mh "github.com/multiformats/go-multihash"
+	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 )
@@ -15,15 +14,18 @@ import (
 // The period over which a miner's active sectors are expected to be proven via WindowPoSt.
 // This guarantees that (1) user data is proven daily, (2) user data is stored for 24h by a rational miner
 // (due to Window PoSt cost assumption).
+// Set with the challenge window by the network policy.
 var WPoStProvingPeriod = abi.ChainEpoch(builtin.EpochsInDay) // 24 hours PARAM_SPEC
 
 // The period between the opening and the closing of a WindowPoSt deadline in which the miner is expected to
 // provide a Window PoSt proof.
 // This provides a miner enough time to compute and propagate a Window PoSt proof.
+// Set by the network policy.
 var WPoStChallengeWindow = abi.ChainEpoch(30 * 60 / builtin.EpochDurationSeconds) // 30 minutes (48 per day) PARAM_SPEC
 
 // WPoStDisputeWindow is the period after a challenge window ends during which
 // PoSts submitted during that period may be disputed.
+// Set by the network policy.
 var WPoStDisputeWindow = 2 * ChainFinality // PARAM_SPEC
 
 // The number of non-overlapping PoSt deadlines in a proving period.
@@ -37,38 +39,70 @@ const WPoStPeriodDeadlines = uint64(48) // PARAM_SPEC
 const MaxPartitionsPerDeadline = 3000
 
 func init() {
+	if err := checkProvingPolicy(WPoStPeriodDeadlines, WPoStChallengeWindow, WPoStProvingPeriod, WPoStDisputeWindow); err != nil {
+		panic(err.Error())
+	}
+	builtin.RegisterPolicyApplier(validateNetworkPolicy, applyNetworkPolicy)
+}
+
+func checkProvingPolicy(deadlines uint64, challengeWindow, provingPeriod, disputeWindow abi.ChainEpoch) error {
 	// Check that the challenge windows divide the proving period evenly.
-	if WPoStProvingPeriod%WPoStChallengeWindow != 0 {
-		panic(fmt.Sprintf("incompatible proving period %d and challenge window %d", WPoStProvingPeriod, WPoStChallengeWindow))
+	if provingPeriod%challengeWindow != 0 {
+		return xerrors.Errorf("incompatible proving period %d and challenge window %d", provingPeriod, challengeWindow)
 	}
 	// Check that WPoStPeriodDeadlines is consistent with the proving period and challenge window.
-	if abi.ChainEpoch(WPoStPeriodDeadlines)*WPoStChallengeWindow != WPoStProvingPeriod {
-		panic(fmt.Sprintf("incompatible proving period %d and challenge window %d", WPoStProvingPeriod, WPoStChallengeWindow))
+	if abi.ChainEpoch(deadlines)*challengeWindow != provingPeriod {
+		return xerrors.Errorf("incompatible proving period %d and challenge window %d", provingPeriod, challengeWindow)
 	}
 
 	// Check to make sure the dispute window is longer than finality so there's always some time to dispute bad proofs.
-	if WPoStDisputeWindow <= ChainFinality {
-		panic(fmt.Sprintf("the proof dispute period %d must exceed finality %d", WPoStDisputeWindow, ChainFinality))
+	if disputeWindow <= ChainFinality {
+		return xerrors.Errorf("the proof dispute period %d must exceed finality %d", disputeWindow, ChainFinality)
 	}
 
 	// A deadline becomes immutable one challenge window before it's challenge window opens.
 	// The challenge lookback must fall within this immutability period.
-	if WPoStChallengeLookback > WPoStChallengeWindow {
-		panic("the challenge lookback cannot exceed one challenge window")
+	if WPoStChallengeLookback > challengeWindow {
+		return xerrors.Errorf("the challenge lookback cannot exceed one challenge window")
 	}
 
 	// Deadlines are immutable when the challenge window is open, and during
 	// the previous challenge window.
-	immutableWindow := 2 * WPoStChallengeWindow
+	immutableWindow := 2 * challengeWindow
 
 	// We want to reserve at least one deadline's worth of time to compact a
 	// deadline.
-	minCompactionWindow := WPoStChallengeWindow
+	minCompactionWindow := challengeWindow
 
 	// Make sure we have enough time in the proving period to do everything we need.
-	if (minCompactionWindow + immutableWindow + WPoStDisputeWindow) > WPoStProvingPeriod {
-		panic(fmt.Sprintf("together, the minimum compaction window (%d) immutability window (%d) and the dispute window (%d) exceed the proving period (%d)",
-			minCompactionWindow, immutableWindow, WPoStDisputeWindow, WPoStProvingPeriod))
+	if (minCompactionWindow + immutableWindow + disputeWindow) > provingPeriod {
+		return xerrors.Errorf("together, the minimum compaction window (%d) immutability window (%d) and the dispute window (%d) exceed the proving period (%d)",
+			minCompactionWindow, immutableWindow, disputeWindow, provingPeriod)
+	}
+	return nil
+}
+
+func validateNetworkPolicy(p *builtin.NetworkPolicy) error {
+	if p.WPoStPeriodDeadlines != WPoStPeriodDeadlines {
+		return xerrors.Errorf("%d deadlines per proving period differs from the %d of miner state", p.WPoStPeriodDeadlines, WPoStPeriodDeadlines)
+	}
+	if p.WPoStChallengeWindow <= 0 {
+		return xerrors.Errorf("non-positive challenge window %d", p.WPoStChallengeWindow)
+	}
+	if p.PreCommitChallengeDelay <= 0 {
+		return xerrors.Errorf("non-positive pre-commit challenge delay %d", p.PreCommitChallengeDelay)
+	}
+	return checkProvingPolicy(p.WPoStPeriodDeadlines, p.WPoStChallengeWindow, p.WPoStProvingPeriod(), p.WPoStDisputeWindow)
+}
+
+func applyNetworkPolicy(p *builtin.NetworkPolicy) {
+	WPoStChallengeWindow = p.WPoStChallengeWindow
+	WPoStProvingPeriod = p.WPoStProvingPeriod()
+	WPoStDisputeWindow = p.WPoStDisputeWindow
+	PreCommitChallengeDelay = p.PreCommitChallengeDelay
+	FaultMaxAge = WPoStProvingPeriod * 14
+	for proof := range MaxProveCommitDuration {
+		MaxProveCommitDuration[proof] = builtin.EpochsInDay + PreCommitChallengeDelay
 	}
 }
 
@@ -183,6 +217,7 @@ var MaxPreCommitRandomnessLookback = builtin.EpochsInDay + ChainFinality // PARA
 // Number of epochs between publishing a sector pre-commitment and when the challenge for interactive PoRep is drawn.
 // This (1) prevents a miner predicting a challenge before staking their pre-commit deposit, and
 // (2) prevents a miner attempting a long fork in the past to insert a pre-commitment after seeing the challenge.
+// Set by the network policy, which also sets MaxProveCommitDuration to follow it.
 var PreCommitChallengeDelay = abi.ChainEpoch(150) // PARAM_SPEC
 
 // Lookback from the deadline's challenge window opening from which to sample chain randomness for the WindowPoSt challenge seed.
@@ -199,6 +234,7 @@ const FaultDeclarationCutoff = WPoStChallengeLookback + 50 // PARAM_SPEC
 
 // The maximum age of a fault before the sector is terminated.
 // This bounds the time a miner can lose client's data before sacrificing pledge and deal collateral.
+// Follows the proving period set by the network policy.
 var FaultMaxAge = WPoStProvingPeriod * 14 // PARAM_SPEC
 
 // Staging period for a miner worker key change.
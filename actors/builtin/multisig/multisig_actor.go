@@ -21,16 +21,32 @@ import (
 
 type TxnID = multisig0.TxnID
 
-//type Transaction struct {
-//	To     addr.Address
-//	Value  abi.TokenAmount
-//	Method abi.MethodNum
-//	Params []byte
-//
-//	// This address at index 0 is the transaction proposer, order of this slice must be preserved.
-//	Approved []addr.Address
-//}
-type Transaction = multisig0.Transaction
+// Changed since v2:
+// - Added EarliestExecution and Expiration
+type Transaction struct {
+	To     addr.Address
+	Value  abi.TokenAmount
+	Method abi.MethodNum
+	Params []byte
+
+	// This address at index 0 is the transaction proposer, order of this slice must be preserved.
+	Approved []addr.Address
+
+	// Epoch before which the transaction may not be executed, even if approved. Zero for no time lock.
+	EarliestExecution abi.ChainEpoch
+	// Epoch after which the transaction may no longer be approved or executed. Zero for no expiry.
+	Expiration abi.ChainEpoch
+}
+
+// Whether the transaction may not yet be executed at an epoch.
+func (t *Transaction) IsTimeLocked(epoch abi.ChainEpoch) bool {
+	return epoch < t.EarliestExecution
+}
+
+// Whether the transaction has expired at an epoch.
+func (t *Transaction) IsExpired(epoch abi.ChainEpoch) bool {
+	return t.Expiration != 0 && epoch > t.Expiration
+}
 
 // Data for a BLAKE2B-256 to be attached to methods referencing proposals via TXIDs.
 // Ensures the existence of a cryptographic reference to the original proposal. Useful
@@ -38,14 +54,31 @@ type Transaction = multisig0.Transaction
 //
 // Requester - The requesting multisig wallet member.
 // All other fields - From the "Transaction" struct.
-//type ProposalHashData struct {
-//	Requester addr.Address
-//	To        addr.Address
-//	Value     abi.TokenAmount
-//	Method    abi.MethodNum
-//	Params    []byte
-//}
-type ProposalHashData = multisig0.ProposalHashData
+//
+// Changed since v2:
+// - Added EarliestExecution and Expiration
+type ProposalHashData struct {
+	Requester         addr.Address
+	To                addr.Address
+	Value             abi.TokenAmount
+	Method            abi.MethodNum
+	Params            []byte
+	EarliestExecution abi.ChainEpoch
+	Expiration        abi.ChainEpoch
+}
+
+func (phd *ProposalHashData) Serialize() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := phd.MarshalCBOR(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+const (
+	// Base of the exit codes with which ApplyBatch aborts when probing a batch, offset by the index of the failed send.
+	ErrBatchProbe = exitcode.FirstActorSpecificExitCode + iota
+)
 
 type Actor struct{}
 
@@ -60,6 +93,14 @@ func (a Actor) Exports() []interface{} {
 		7:                         a.SwapSigner,
 		8:                         a.ChangeNumApprovalsThreshold,
 		9:                         a.LockBalance,
+		10:                        a.PruneExpired,
+		11:                        a.SetSignerWeight,
+		12:                        a.SetApprovalPolicy,
+		13:                        a.ExecuteBatch,
+		14:                        a.SetVestingSchedule,
+		15:                        a.RevokeVesting,
+		16:                        a.ProposeWithSchedule,
+		17:                        a.ApplyBatch,
 	}
 }
 
@@ -124,8 +165,21 @@ func (a Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *abi.E
 		rt.Abortf(exitcode.ErrIllegalState, "failed to create empty map: %v", err)
 	}
 
+	policies, err := adt.StoreEmptyMap(adt.AsStore(rt), builtin.DefaultHamtBitwidth)
+	if err != nil {
+		rt.Abortf(exitcode.ErrIllegalState, "failed to create empty map: %v", err)
+	}
+
+	// All signers initially have unit weight.
+	weights := make([]uint64, len(resolvedSigners))
+	for i := range weights {
+		weights[i] = 1
+	}
+
 	var st State
 	st.Signers = resolvedSigners
+	st.SignerWeights = weights
+	st.ApprovalPolicies = policies
 	st.NumApprovalsThreshold = params.NumApprovalsThreshold
 	st.PendingTxns = pending
 	st.InitialBalance = abi.NewTokenAmount(0)
@@ -145,6 +199,17 @@ func (a Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *abi.E
 //}
 type ProposeParams = multisig0.ProposeParams
 
+type ProposeWithScheduleParams struct {
+	To     addr.Address
+	Value  abi.TokenAmount
+	Method abi.MethodNum
+	Params []byte
+	// Epoch before which the transaction may not be executed. Zero for no time lock.
+	EarliestExecution abi.ChainEpoch
+	// Epoch after which the transaction may no longer be approved or executed. Zero for no expiry.
+	Expiration abi.ChainEpoch
+}
+
 //type ProposeReturn struct {
 //	// TxnID is the ID of the proposed transaction
 //	TxnID TxnID
@@ -158,12 +223,42 @@ type ProposeParams = multisig0.ProposeParams
 type ProposeReturn = multisig0.ProposeReturn
 
 func (a Actor) Propose(rt runtime.Runtime, params *ProposeParams) *ProposeReturn {
+	return a.propose(rt, &ProposeWithScheduleParams{
+		To:     params.To,
+		Value:  params.Value,
+		Method: params.Method,
+		Params: params.Params,
+	})
+}
+
+// Proposes a transaction which may not be executed before an epoch, or may not be approved or executed after one.
+func (a Actor) ProposeWithSchedule(rt runtime.Runtime, params *ProposeWithScheduleParams) *ProposeReturn {
+	return a.propose(rt, params)
+}
+
+func (a Actor) propose(rt runtime.Runtime, params *ProposeWithScheduleParams) *ProposeReturn {
 	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
 	proposer := rt.Caller()
 
 	if params.Value.Sign() < 0 {
 		rt.Abortf(exitcode.ErrIllegalArgument, "proposed value must be non-negative, was %v", params.Value)
 	}
+	if params.EarliestExecution < 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "earliest execution epoch must be non-negative, was %d", params.EarliestExecution)
+	}
+	if params.Method == builtin.MethodsMultisig.ApplyBatch {
+		if to, ok := rt.ResolveAddress(params.To); ok && to == rt.Receiver() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "batch sends may only be applied by executing a batch")
+		}
+	}
+	if params.Expiration != 0 {
+		if params.Expiration < rt.CurrEpoch() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "expiration %d is before current epoch %d", params.Expiration, rt.CurrEpoch())
+		}
+		if params.Expiration < params.EarliestExecution {
+			rt.Abortf(exitcode.ErrIllegalArgument, "expiration %d is before earliest execution %d", params.Expiration, params.EarliestExecution)
+		}
+	}
 
 	var txnID TxnID
 	var st State
@@ -179,11 +274,13 @@ func (a Actor) Propose(rt runtime.Runtime, params *ProposeParams) *ProposeReturn
 		txnID = st.NextTxnID
 		st.NextTxnID += 1
 		txn = &Transaction{
-			To:       params.To,
-			Value:    params.Value,
-			Method:   params.Method,
-			Params:   params.Params,
-			Approved: []addr.Address{},
+			To:                params.To,
+			Value:             params.Value,
+			Method:            params.Method,
+			Params:            params.Params,
+			Approved:          []addr.Address{},
+			EarliestExecution: params.EarliestExecution,
+			Expiration:        params.Expiration,
 		}
 
 		if err := ptx.Put(txnID, txn); err != nil {
@@ -239,6 +336,9 @@ func (a Actor) Approve(rt runtime.Runtime, params *TxnIDParams) *ApproveReturn {
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load pending transactions")
 
 		txn = getTransaction(rt, ptx, params.ID, params.ProposalHash, true)
+		if txn.IsExpired(rt.CurrEpoch()) {
+			rt.Abortf(exitcode.ErrForbidden, "transaction %v expired at %d", params.ID, txn.Expiration)
+		}
 	})
 
 	// if the transaction already has enough approvers, execute it without "processing" this approval.
@@ -318,6 +418,7 @@ func (a Actor) AddSigner(rt runtime.Runtime, params *AddSignerParams) *abi.Empty
 		}
 
 		st.Signers = append(st.Signers, resolvedNewSigner)
+		st.SignerWeights = append(st.SignerWeights, 1)
 		if params.Increase {
 			st.NumApprovalsThreshold = st.NumApprovalsThreshold + 1
 		}
@@ -349,18 +450,21 @@ func (a Actor) RemoveSigner(rt runtime.Runtime, params *RemoveSignerParams) *abi
 		}
 
 		newSigners := make([]addr.Address, 0, len(st.Signers))
+		newWeights := make([]uint64, 0, len(st.Signers))
 		// signers have already been resolved
-		for _, s := range st.Signers {
+		for i, s := range st.Signers {
 			if resolvedOldSigner != s {
 				newSigners = append(newSigners, s)
+				newWeights = append(newWeights, st.SignerWeights[i])
 			}
 		}
+		newTotalWeight := st.TotalSignerWeight() - st.SignerWeight(resolvedOldSigner)
 
-		// if the number of signers is below the threshold after removing the given signer,
+		// if the signer weight is below the threshold after removing the given signer,
 		// we should decrease the threshold by 1. This means that decrease should NOT be set to false
 		// in such a scenario.
-		if !params.Decrease && uint64(len(st.Signers)-1) < st.NumApprovalsThreshold {
-			rt.Abortf(exitcode.ErrIllegalArgument, "can't reduce signers to %d below threshold %d with decrease=false", len(st.Signers)-1, st.NumApprovalsThreshold)
+		if !params.Decrease && newTotalWeight < st.NumApprovalsThreshold {
+			rt.Abortf(exitcode.ErrIllegalArgument, "can't reduce signers to %d below threshold %d with decrease=false", newTotalWeight, st.NumApprovalsThreshold)
 		}
 
 		if params.Decrease {
@@ -368,12 +472,17 @@ func (a Actor) RemoveSigner(rt runtime.Runtime, params *RemoveSignerParams) *abi
 				rt.Abortf(exitcode.ErrIllegalArgument, "can't decrease approvals from %d to %d", st.NumApprovalsThreshold, st.NumApprovalsThreshold-1)
 			}
 			st.NumApprovalsThreshold = st.NumApprovalsThreshold - 1
+			if newTotalWeight < st.NumApprovalsThreshold {
+				rt.Abortf(exitcode.ErrIllegalArgument, "can't reduce signer weight to %d below threshold %d", newTotalWeight, st.NumApprovalsThreshold)
+			}
 		}
+		requirePolicyThresholdsAttainable(rt, &st, newTotalWeight)
 
 		err := st.PurgeApprovals(store, resolvedOldSigner)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to purge approvals of removed signer")
 
 		st.Signers = newSigners
+		st.SignerWeights = newWeights
 	})
 
 	return nil
@@ -406,14 +515,20 @@ func (a Actor) SwapSigner(rt runtime.Runtime, params *SwapSignerParams) *abi.Emp
 			rt.Abortf(exitcode.ErrIllegalArgument, "%s already a signer", toResolved)
 		}
 
+		// The new signer inherits the weight of the old one.
+		weight := st.SignerWeight(fromResolved)
 		newSigners := make([]addr.Address, 0, len(st.Signers))
-		for _, s := range st.Signers {
+		newWeights := make([]uint64, 0, len(st.Signers))
+		for i, s := range st.Signers {
 			if s != fromResolved {
 				newSigners = append(newSigners, s)
+				newWeights = append(newWeights, st.SignerWeights[i])
 			}
 		}
 		newSigners = append(newSigners, toResolved)
+		newWeights = append(newWeights, weight)
 		st.Signers = newSigners
+		st.SignerWeights = newWeights
 
 		err := st.PurgeApprovals(store, fromResolved)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to purge approvals of removed signer")
@@ -433,7 +548,7 @@ func (a Actor) ChangeNumApprovalsThreshold(rt runtime.Runtime, params *ChangeNum
 
 	var st State
 	rt.StateTransaction(&st, func() {
-		if params.NewThreshold == 0 || params.NewThreshold > uint64(len(st.Signers)) {
+		if params.NewThreshold == 0 || params.NewThreshold > st.TotalSignerWeight() {
 			rt.Abortf(exitcode.ErrIllegalArgument, "New threshold value not supported")
 		}
 
@@ -472,6 +587,342 @@ func (a Actor) LockBalance(rt runtime.Runtime, params *LockBalanceParams) *abi.E
 	return nil
 }
 
+type SetVestingScheduleParams struct {
+	StartEpoch          abi.ChainEpoch
+	CliffDuration       abi.ChainEpoch
+	Tranches            []VestingTranche
+	RevocationThreshold uint64
+}
+
+// Locks the sum of the tranche amounts, to vest according to a schedule.
+// Like LockBalance, this may be called only if no balance is locked.
+func (a Actor) SetVestingSchedule(rt runtime.Runtime, params *SetVestingScheduleParams) *abi.EmptyValue {
+	// Can only be called by the multisig wallet itself.
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	if len(params.Tranches) == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "vesting schedule must have at least one tranche")
+	}
+	if len(params.Tranches) > VestingTranchesMax {
+		rt.Abortf(exitcode.ErrIllegalArgument, "vesting schedule of %d tranches exceeds max %d", len(params.Tranches), VestingTranchesMax)
+	}
+	if params.CliffDuration < 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "negative cliff duration %d", params.CliffDuration)
+	}
+	for i, tranche := range params.Tranches {
+		if tranche.Duration < 0 {
+			rt.Abortf(exitcode.ErrIllegalArgument, "tranche %d has negative duration %d", i, tranche.Duration)
+		}
+		if tranche.Amount.LessThan(big.Zero()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "tranche %d has negative amount %v", i, tranche.Amount)
+		}
+	}
+
+	schedule := &VestingSchedule{
+		CliffDuration:       params.CliffDuration,
+		Tranches:            params.Tranches,
+		RevocationThreshold: params.RevocationThreshold,
+	}
+	if schedule.TotalDuration() <= 0 {
+		// Note: as for LockBalance, a schedule vesting at once is workable, but rejected as ineffective.
+		rt.Abortf(exitcode.ErrIllegalArgument, "vesting schedule duration must be positive")
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if st.UnlockDuration != 0 {
+			rt.Abortf(exitcode.ErrForbidden, "modification of unlock disallowed")
+		}
+		if params.RevocationThreshold > st.TotalSignerWeight() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "revocation threshold %d exceeds total signer weight %d",
+				params.RevocationThreshold, st.TotalSignerWeight())
+		}
+		st.SetLocked(params.StartEpoch, schedule.TotalDuration(), schedule.TotalAmount())
+		st.VestingSchedule = schedule
+	})
+	return nil
+}
+
+type RevokeVestingParams struct {
+	// Recipient of the balance remaining locked.
+	To addr.Address
+}
+
+// Revokes a revocable vesting schedule, sending the balance remaining locked to a recipient.
+// A transaction to revoke requires at least the schedule's revocation threshold of approvals.
+func (a Actor) RevokeVesting(rt runtime.Runtime, params *RevokeVestingParams) *abi.EmptyValue {
+	// Can only be called by the multisig wallet itself.
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	var locked abi.TokenAmount
+	var st State
+	rt.StateTransaction(&st, func() {
+		if st.VestingSchedule == nil {
+			rt.Abortf(exitcode.ErrForbidden, "no vesting schedule to revoke")
+		}
+		if st.VestingSchedule.RevocationThreshold == 0 {
+			rt.Abortf(exitcode.ErrForbidden, "vesting schedule is not revocable")
+		}
+
+		locked = big.Min(st.AmountLocked(rt.CurrEpoch()-st.StartEpoch), rt.CurrentBalance())
+		st.SetLocked(0, 0, big.Zero())
+		st.VestingSchedule = nil
+	})
+
+	if locked.GreaterThan(big.Zero()) {
+		code := rt.Send(params.To, builtin.MethodSend, nil, locked, &builtin.Discard{})
+		builtin.RequireSuccess(rt, code, "failed to send revoked balance %v to %v", locked, params.To)
+	}
+	return nil
+}
+
+type PruneExpiredParams struct {
+	IDs []TxnID
+}
+
+// Removes expired pending transactions. Any signer may prune any expired transaction.
+func (a Actor) PruneExpired(rt runtime.Runtime, params *PruneExpiredParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerType(builtin.CallerTypesSignable...)
+	callerAddr := rt.Caller()
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if !st.IsSigner(callerAddr) {
+			rt.Abortf(exitcode.ErrForbidden, "%s is not a signer", callerAddr)
+		}
+
+		ptx, err := adt.AsMap(adt.AsStore(rt), st.PendingTxns, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load pending txns")
+
+		for _, txnID := range params.IDs {
+			var txn Transaction
+			found, err := ptx.Pop(txnID, &txn)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to pop transaction %v for pruning", txnID)
+			if !found {
+				rt.Abortf(exitcode.ErrNotFound, "no such transaction %v to prune", txnID)
+			}
+			if !txn.IsExpired(rt.CurrEpoch()) {
+				rt.Abortf(exitcode.ErrForbidden, "transaction %v has not expired", txnID)
+			}
+		}
+
+		st.PendingTxns, err = ptx.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush pending transactions")
+	})
+	return nil
+}
+
+type SetSignerWeightParams struct {
+	Signer addr.Address
+	Weight uint64
+}
+
+// Sets the approval weight of a signer.
+func (a Actor) SetSignerWeight(rt runtime.Runtime, params *SetSignerWeightParams) *abi.EmptyValue {
+	// Can only be called by the multisig wallet itself.
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	if params.Weight == 0 || params.Weight > SignerWeightMax {
+		rt.Abortf(exitcode.ErrIllegalArgument, "signer weight %d must be in [1, %d]", params.Weight, SignerWeightMax)
+	}
+
+	resolvedSigner, err := builtin.ResolveToIDAddr(rt, params.Signer)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve address %v", params.Signer)
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		if !st.IsSigner(resolvedSigner) {
+			rt.Abortf(exitcode.ErrForbidden, "%s is not a signer", resolvedSigner)
+		}
+
+		newTotalWeight := st.TotalSignerWeight() - st.SignerWeight(resolvedSigner) + params.Weight
+		if newTotalWeight < st.NumApprovalsThreshold {
+			rt.Abortf(exitcode.ErrIllegalArgument, "can't reduce signer weight to %d below threshold %d", newTotalWeight, st.NumApprovalsThreshold)
+		}
+		requirePolicyThresholdsAttainable(rt, &st, newTotalWeight)
+
+		for i, s := range st.Signers {
+			if s == resolvedSigner {
+				st.SignerWeights[i] = params.Weight
+			}
+		}
+	})
+	return nil
+}
+
+type SetApprovalPolicyParams struct {
+	To     addr.Address
+	Method abi.MethodNum
+	// Total approval weight required for transactions sending Method to To.
+	// Zero removes the policy, so that the default threshold applies.
+	Threshold uint64
+}
+
+// Sets or removes the approval threshold for transactions sending a method to a target.
+func (a Actor) SetApprovalPolicy(rt runtime.Runtime, params *SetApprovalPolicyParams) *abi.EmptyValue {
+	// Can only be called by the multisig wallet itself.
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	resolvedTo, ok := rt.ResolveAddress(params.To)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "failed to resolve address %v", params.To)
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		policies, err := adt.AsMap(adt.AsStore(rt), st.ApprovalPolicies, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approval policies")
+
+		key := PolicyKey(resolvedTo, params.Method)
+		if params.Threshold == 0 {
+			found, err := policies.TryDelete(key)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete approval policy")
+			if !found {
+				rt.Abortf(exitcode.ErrNotFound, "no approval policy for %v method %d", resolvedTo, params.Method)
+			}
+		} else {
+			if params.Threshold > st.TotalSignerWeight() {
+				rt.Abortf(exitcode.ErrIllegalArgument, "policy threshold %d exceeds total signer weight %d", params.Threshold, st.TotalSignerWeight())
+			}
+			err = policies.Put(key, &ApprovalPolicy{
+				To:        resolvedTo,
+				Method:    params.Method,
+				Threshold: params.Threshold,
+			})
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put approval policy")
+		}
+
+		st.ApprovalPolicies, err = policies.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush approval policies")
+	})
+	return nil
+}
+
+// A single send in a batch transaction.
+type BatchSend struct {
+	To     addr.Address
+	Value  abi.TokenAmount
+	Method abi.MethodNum
+	Params []byte
+}
+
+type ExecuteBatchParams struct {
+	Sends []BatchSend
+}
+
+type BatchSendResult struct {
+	Code exitcode.ExitCode
+	Ret  []byte
+}
+
+type ExecuteBatchReturn struct {
+	// Result of each send, in order, up to and including the first send to fail.
+	Results []BatchSendResult
+}
+
+// Performs an ordered list of sends atomically: if any send fails, all of the sends are rolled back.
+// The results report each send up to and including the first to fail, which carries that send's exit code.
+// Sends before it report success, but with no return value, since their effects were rolled back.
+// A batch transaction is proposed as a transaction sending this method to the multisig itself, with zero value.
+// It requires the greatest approval threshold of any of its sends.
+// A batch may not include a send executing another batch from the multisig, since the sends of the inner batch
+// would not contribute to the threshold.
+func (a Actor) ExecuteBatch(rt runtime.Runtime, params *ExecuteBatchParams) *ExecuteBatchReturn {
+	// Can only be called by the multisig wallet itself.
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	if len(params.Sends) == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "empty batch")
+	}
+	if len(params.Sends) > BatchSendsMax {
+		rt.Abortf(exitcode.ErrIllegalArgument, "batch of %d sends exceeds max %d", len(params.Sends), BatchSendsMax)
+	}
+
+	total := big.Zero()
+	for i, send := range params.Sends {
+		if send.Value.Sign() < 0 {
+			rt.Abortf(exitcode.ErrIllegalArgument, "batch send %d value must be non-negative, was %v", i, send.Value)
+		}
+		if to, ok := rt.ResolveAddress(send.To); ok && to == rt.Receiver() &&
+			(send.Method == builtin.MethodsMultisig.ExecuteBatch || send.Method == builtin.MethodsMultisig.ApplyBatch) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "batch send %d may not execute a nested batch", i)
+		}
+		total = big.Add(total, send.Value)
+	}
+
+	// The lockup applies to the batch as a whole, since the transaction carrying it has no value.
+	var st State
+	rt.StateReadonly(&st)
+	if err := st.assertAvailable(rt.CurrentBalance(), total, rt.CurrEpoch()); err != nil {
+		rt.Abortf(exitcode.ErrInsufficientFunds, "insufficient funds unlocked: %v", err)
+	}
+
+	// The sends are applied in a call of their own so that a failure rolls all of them back
+	// while this call still reports which send failed.
+	var out builtin.CBORBytes
+	code := rt.Send(rt.Receiver(), builtin.MethodsMultisig.ApplyBatch, &ApplyBatchParams{Sends: params.Sends}, big.Zero(), &out)
+	if code.IsSuccess() {
+		var ret ExecuteBatchReturn
+		err := ret.UnmarshalCBOR(bytes.NewReader(out))
+		builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to decode batch results")
+		return &ret
+	}
+
+	// The return value of an aborted call is lost, so the failed send is identified by probing the batch
+	// again, which rolls back all of its sends too.
+	probe := rt.Send(rt.Receiver(), builtin.MethodsMultisig.ApplyBatch, &ApplyBatchParams{Sends: params.Sends, Probe: true}, big.Zero(), &builtin.Discard{})
+	failed := int(probe - ErrBatchProbe)
+	if probe < ErrBatchProbe || failed >= len(params.Sends) {
+		rt.Abortf(code, "batch failed with no failing send identified, probe exited %v", probe)
+	}
+	results := make([]BatchSendResult, failed+1)
+	results[failed].Code = code
+	return &ExecuteBatchReturn{Results: results}
+}
+
+type ApplyBatchParams struct {
+	Sends []BatchSend
+	// Whether to roll back the sends and report only the index of the first to fail.
+	Probe bool
+}
+
+// Applies the sends of a batch on behalf of ExecuteBatch, aborting with the exit code of the first send to fail.
+// When probing, always aborts: with ErrBatchProbe offset by the index of the first send to fail,
+// or by the number of sends if none fails.
+// May only be called by the multisig itself, and may not be proposed as a transaction.
+func (a Actor) ApplyBatch(rt runtime.Runtime, params *ApplyBatchParams) *ExecuteBatchReturn {
+	rt.ValidateImmediateCallerIs(rt.Receiver())
+
+	results := make([]BatchSendResult, len(params.Sends))
+	for i, send := range params.Sends {
+		var out builtin.CBORBytes
+		code := rt.Send(send.To, send.Method, builtin.CBORBytes(send.Params), send.Value, &out)
+		if !code.IsSuccess() {
+			if params.Probe {
+				rt.Abortf(ErrBatchProbe+exitcode.ExitCode(i), "batch send %d to %v method %d failed with %v", i, send.To, send.Method, code)
+			}
+			rt.Abortf(code, "batch send %d to %v method %d failed", i, send.To, send.Method)
+		}
+		results[i] = BatchSendResult{Code: code, Ret: out}
+	}
+	if params.Probe {
+		rt.Abortf(ErrBatchProbe+exitcode.ExitCode(len(params.Sends)), "all %d batch sends succeeded", len(params.Sends))
+	}
+	return &ExecuteBatchReturn{Results: results}
+}
+
+// Aborts if the total signer weight would fall below the threshold of any approval policy, or of vesting revocation.
+func requirePolicyThresholdsAttainable(rt runtime.Runtime, st *State, totalWeight uint64) {
+	maxThreshold, err := st.MaxPolicyThreshold(adt.AsStore(rt))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approval policy thresholds")
+	if st.VestingSchedule != nil && st.VestingSchedule.RevocationThreshold > maxThreshold {
+		maxThreshold = st.VestingSchedule.RevocationThreshold
+	}
+	if totalWeight < maxThreshold {
+		rt.Abortf(exitcode.ErrIllegalArgument, "can't reduce signer weight to %d below policy threshold %d", totalWeight, maxThreshold)
+	}
+}
+
 func (a Actor) approveTransaction(rt runtime.Runtime, txnID TxnID, txn *Transaction) (bool, []byte, exitcode.ExitCode) {
 	caller := rt.Caller()
 
@@ -526,8 +977,11 @@ func executeTransactionIfApproved(rt runtime.Runtime, st State, txnID TxnID, txn
 	var code exitcode.ExitCode
 	applied := false
 
-	thresholdMet := uint64(len(txn.Approved)) >= st.NumApprovalsThreshold
-	if thresholdMet {
+	threshold := transactionThreshold(rt, &st, txnID, txn)
+
+	// A time-locked transaction remains pending once approved, to be executed by a later approval.
+	thresholdMet := st.ApprovedWeight(txn.Approved) >= threshold
+	if thresholdMet && !txn.IsTimeLocked(rt.CurrEpoch()) {
 		if err := st.assertAvailable(rt.CurrentBalance(), txn.Value, rt.CurrEpoch()); err != nil {
 			rt.Abortf(exitcode.ErrInsufficientFunds, "insufficient funds unlocked: %v", err)
 		}
@@ -564,18 +1018,73 @@ func executeTransactionIfApproved(rt runtime.Runtime, st State, txnID TxnID, txn
 	return applied, out, code
 }
 
+// Returns the approval threshold for a transaction. A batch transaction requires the greatest threshold
+// of the batch itself and each of its sends, which ExecuteBatch ensures cannot include another batch.
+// Revoking a vesting schedule requires at least the schedule's revocation threshold.
+func transactionThreshold(rt runtime.Runtime, st *State, txnID TxnID, txn *Transaction) uint64 {
+	store := adt.AsStore(rt)
+	thresholdFor := func(to addr.Address, method abi.MethodNum) uint64 {
+		// Approval policies are keyed by ID address, but the target need not be.
+		if resolved, ok := rt.ResolveAddress(to); ok {
+			to = resolved
+		}
+		threshold, err := st.ApprovalThreshold(store, to, method)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approval threshold for transaction %v", txnID)
+		if to == rt.Receiver() && method == builtin.MethodsMultisig.RevokeVesting && st.VestingSchedule != nil &&
+			st.VestingSchedule.RevocationThreshold > threshold {
+			threshold = st.VestingSchedule.RevocationThreshold
+		}
+		return threshold
+	}
+
+	threshold := thresholdFor(txn.To, txn.Method)
+	if txn.Method != builtin.MethodsMultisig.ExecuteBatch {
+		return threshold
+	}
+	if to, ok := rt.ResolveAddress(txn.To); !ok || to != rt.Receiver() {
+		return threshold
+	}
+
+	// Malformed batch parameters will fail on execution, so needn't contribute to the threshold.
+	var batch ExecuteBatchParams
+	if err := batch.UnmarshalCBOR(bytes.NewReader(txn.Params)); err != nil {
+		return threshold
+	}
+	for _, send := range batch.Sends {
+		if t := thresholdFor(send.To, send.Method); t > threshold {
+			threshold = t
+		}
+	}
+	return threshold
+}
+
 // Computes a digest of a proposed transaction. This digest is used to confirm identity of the transaction
 // associated with an ID, which might change under chain re-orgs.
+// The digest of a transaction with neither a time lock nor an expiry is computed as in prior versions.
 func ComputeProposalHash(txn *Transaction, hash func([]byte) [32]byte) ([]byte, error) {
-	hashData := ProposalHashData{
-		Requester: txn.Approved[0],
-		To:        txn.To,
-		Value:     txn.Value,
-		Method:    txn.Method,
-		Params:    txn.Params,
+	var data []byte
+	var err error
+	if txn.EarliestExecution == 0 && txn.Expiration == 0 {
+		hashData := multisig0.ProposalHashData{
+			Requester: txn.Approved[0],
+			To:        txn.To,
+			Value:     txn.Value,
+			Method:    txn.Method,
+			Params:    txn.Params,
+		}
+		data, err = hashData.Serialize()
+	} else {
+		hashData := ProposalHashData{
+			Requester:         txn.Approved[0],
+			To:                txn.To,
+			Value:             txn.Value,
+			Method:            txn.Method,
+			Params:            txn.Params,
+			EarliestExecution: txn.EarliestExecution,
+			Expiration:        txn.Expiration,
+		}
+		data, err = hashData.Serialize()
 	}
-
-	data, err := hashData.Serialize()
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct multisig approval hash: %w", err)
 	}
@@ -1,6 +1,8 @@
 package multisig
 
 import (
+	"encoding/binary"
+
 	address "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -12,16 +14,88 @@ import (
 )
 
 type State struct {
-	Signers               []address.Address // Signers must be canonical ID-addresses.
+	Signers []address.Address // Signers must be canonical ID-addresses.
+	// Approval weight of each signer, parallel to Signers.
+	SignerWeights []uint64
+	// Total weight of approvals required to execute a transaction, unless overridden by an approval policy.
 	NumApprovalsThreshold uint64
 	NextTxnID             TxnID
 
-	// Linear unlock
+	// Linear unlock, or the totals of the vesting schedule if one is set.
 	InitialBalance abi.TokenAmount
 	StartEpoch     abi.ChainEpoch
 	UnlockDuration abi.ChainEpoch
+	// Schedule by which InitialBalance unlocks, in place of linear unlocking. Nil for linear unlocking.
+	VestingSchedule *VestingSchedule
 
 	PendingTxns cid.Cid // HAMT[TxnID]Transaction
+
+	// Thresholds overriding NumApprovalsThreshold for transactions to specific (target, method) pairs.
+	ApprovalPolicies cid.Cid // HAMT[PolicyKey]ApprovalPolicy
+}
+
+// An approval threshold applying to transactions sending a method to a target.
+type ApprovalPolicy struct {
+	To        address.Address // Canonical ID-address of the target.
+	Method    abi.MethodNum
+	Threshold uint64
+}
+
+// A schedule by which a locked balance vests, starting from the multisig's start epoch.
+// Tranches vest linearly over their durations, one after another, but nothing vests before the cliff.
+// Any amount accrued by the end of the cliff vests at once.
+type VestingSchedule struct {
+	CliffDuration abi.ChainEpoch
+	Tranches      []VestingTranche
+	// Total approval weight required to revoke the schedule. Zero if the schedule is irrevocable.
+	RevocationThreshold uint64
+}
+
+type VestingTranche struct {
+	Duration abi.ChainEpoch // Zero for an amount vesting at once.
+	Amount   abi.TokenAmount
+}
+
+// Returns the sum of the amounts of all tranches.
+func (vs *VestingSchedule) TotalAmount() abi.TokenAmount {
+	total := big.Zero()
+	for _, tranche := range vs.Tranches {
+		total = big.Add(total, tranche.Amount)
+	}
+	return total
+}
+
+// Returns the number of epochs after the start epoch at which the schedule has fully vested.
+func (vs *VestingSchedule) TotalDuration() abi.ChainEpoch {
+	total := abi.ChainEpoch(0)
+	for _, tranche := range vs.Tranches {
+		total += tranche.Duration
+	}
+	if vs.CliffDuration > total {
+		return vs.CliffDuration
+	}
+	return total
+}
+
+// Returns the amount remaining locked a number of epochs after the start epoch.
+func (vs *VestingSchedule) AmountLocked(elapsedEpoch abi.ChainEpoch) abi.TokenAmount {
+	if elapsedEpoch < vs.CliffDuration {
+		return vs.TotalAmount()
+	}
+	locked := big.Zero()
+	trancheStart := abi.ChainEpoch(0)
+	for _, tranche := range vs.Tranches {
+		locked = big.Add(locked, linearAmountLocked(tranche.Amount, tranche.Duration, elapsedEpoch-trancheStart))
+		trancheStart += tranche.Duration
+	}
+	return locked
+}
+
+// Returns the key of the approval policy for a target and method.
+func PolicyKey(to address.Address, method abi.MethodNum) abi.Keyer {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, uint64(method))
+	return StringKey(string(to.Bytes()) + string(buf[:n]))
 }
 
 // Tests whether an address is in the list of signers.
@@ -34,6 +108,71 @@ func (st *State) IsSigner(address address.Address) bool {
 	return false
 }
 
+// Returns the approval weight of a signer, or zero if the address is not a signer.
+func (st *State) SignerWeight(address address.Address) uint64 {
+	for i, signer := range st.Signers {
+		if signer == address {
+			return st.SignerWeights[i]
+		}
+	}
+	return 0
+}
+
+// Returns the sum of the approval weights of all signers.
+func (st *State) TotalSignerWeight() uint64 {
+	total := uint64(0)
+	for _, w := range st.SignerWeights {
+		total += w
+	}
+	return total
+}
+
+// Returns the sum of the approval weights of a list of approvers.
+func (st *State) ApprovedWeight(approvers []address.Address) uint64 {
+	total := uint64(0)
+	for _, approver := range approvers {
+		total += st.SignerWeight(approver)
+	}
+	return total
+}
+
+// Returns the approval threshold for a transaction sending a method to a target,
+// which is that of a matching approval policy if one exists.
+func (st *State) ApprovalThreshold(store adt.Store, to address.Address, method abi.MethodNum) (uint64, error) {
+	policies, err := adt.AsMap(store, st.ApprovalPolicies, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to load approval policies: %w", err)
+	}
+	var policy ApprovalPolicy
+	found, err := policies.Get(PolicyKey(to, method), &policy)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to load approval policy for %v method %d: %w", to, method, err)
+	}
+	if !found {
+		return st.NumApprovalsThreshold, nil
+	}
+	return policy.Threshold, nil
+}
+
+// Returns the largest threshold of any approval policy, or zero if there are none.
+func (st *State) MaxPolicyThreshold(store adt.Store) (uint64, error) {
+	policies, err := adt.AsMap(store, st.ApprovalPolicies, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to load approval policies: %w", err)
+	}
+	max := uint64(0)
+	var policy ApprovalPolicy
+	if err = policies.ForEach(&policy, func(string) error {
+		if policy.Threshold > max {
+			max = policy.Threshold
+		}
+		return nil
+	}); err != nil {
+		return 0, xerrors.Errorf("failed to traverse approval policies: %w", err)
+	}
+	return max, nil
+}
+
 func (st *State) SetLocked(startEpoch abi.ChainEpoch, unlockDuration abi.ChainEpoch, lockedAmount abi.TokenAmount) {
 	st.StartEpoch = startEpoch
 	st.UnlockDuration = unlockDuration
@@ -41,18 +180,26 @@ func (st *State) SetLocked(startEpoch abi.ChainEpoch, unlockDuration abi.ChainEp
 }
 
 func (st *State) AmountLocked(elapsedEpoch abi.ChainEpoch) abi.TokenAmount {
-	if elapsedEpoch >= st.UnlockDuration {
+	if st.VestingSchedule != nil {
+		return st.VestingSchedule.AmountLocked(elapsedEpoch)
+	}
+	return linearAmountLocked(st.InitialBalance, st.UnlockDuration, elapsedEpoch)
+}
+
+// Returns the amount remaining locked of an amount unlocking linearly over a duration.
+func linearAmountLocked(initialBalance abi.TokenAmount, duration abi.ChainEpoch, elapsedEpoch abi.ChainEpoch) abi.TokenAmount {
+	if elapsedEpoch >= duration {
 		return abi.NewTokenAmount(0)
 	}
 	if elapsedEpoch <= 0 {
-		return st.InitialBalance
+		return initialBalance
 	}
 
-	unlockDuration := big.NewInt(int64(st.UnlockDuration))
+	unlockDuration := big.NewInt(int64(duration))
 	remainingLockDuration := big.Sub(unlockDuration, big.NewInt(int64(elapsedEpoch)))
 
 	// locked = ceil(InitialBalance * remainingLockDuration / UnlockDuration)
-	numerator := big.Mul(st.InitialBalance, remainingLockDuration)
+	numerator := big.Mul(initialBalance, remainingLockDuration)
 	denominator := unlockDuration
 	quot := big.Div(numerator, denominator)
 	rem := big.Mod(numerator, denominator)
@@ -11,6 +11,7 @@ import (
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	multisig0 "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
 	"github.com/minio/blake2b-simd"
 	assert "github.com/stretchr/testify/assert"
 	require "github.com/stretchr/testify/require"
@@ -1102,6 +1103,242 @@ type addSignerTestCase struct {
 	code            exitcode.ExitCode
 }
 
+func TestTimeLockAndExpiry(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	richard := tutil.NewIDAddr(t, 104)
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+	const numApprovals = uint64(2)
+	const txnID = int64(0)
+	const fakeMethod = abi.MethodNum(42)
+	var sendValue = abi.NewTokenAmount(10)
+	var fakeParams = builtin.CBORBytes([]byte{1, 2, 3, 4})
+	var signers = []addr.Address{anne, bob}
+
+	builder := mock.NewBuilder(context.Background(), receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256).
+		WithEpoch(100).
+		WithBalance(sendValue, big.Zero())
+
+	proposal := func(earliest, expiration abi.ChainEpoch) *multisig.ProposeWithScheduleParams {
+		return &multisig.ProposeWithScheduleParams{
+			To:                chuck,
+			Value:             sendValue,
+			Method:            fakeMethod,
+			Params:            fakeParams,
+			EarliestExecution: earliest,
+			Expiration:        expiration,
+		}
+	}
+
+	t.Run("approved transaction is not executed until time lock passes", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		params := proposal(200, 0)
+		ret := actor.proposeWithSchedule(rt, params)
+		assert.False(t, ret.Applied)
+		hash := makeProposalHash(t, &multisig.Transaction{To: chuck, Value: sendValue, Method: fakeMethod, Params: fakeParams,
+			Approved: []addr.Address{anne}, EarliestExecution: 200})
+
+		// Threshold is met but the transaction remains pending.
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		actor.approveOK(rt, txnID, hash, nil)
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:                chuck,
+			Value:             sendValue,
+			Method:            fakeMethod,
+			Params:            fakeParams,
+			Approved:          []addr.Address{anne, bob},
+			EarliestExecution: 200,
+		})
+
+		// Once the time lock passes, any signer's approval executes it.
+		rt.SetEpoch(200)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, fakeMethod, fakeParams, sendValue, nil, 0)
+		actor.approveOK(rt, txnID, hash, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("proposal with passed time lock executes immediately", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, fakeMethod, fakeParams, sendValue, nil, 0)
+		ret := actor.proposeWithSchedule(rt, proposal(100, 150))
+		assert.True(t, ret.Applied)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("expired transaction cannot be approved", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeWithSchedule(rt, proposal(0, 150))
+
+		// Approval is possible up to and including the expiration epoch.
+		rt.SetEpoch(151)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "expired at 150", func() {
+			rt.Call(actor.a.Approve, &multisig.TxnIDParams{ID: multisig.TxnID(txnID)})
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+
+	t.Run("approval on expiration epoch executes", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeWithSchedule(rt, proposal(0, 150))
+
+		rt.SetEpoch(150)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, fakeMethod, fakeParams, sendValue, nil, 0)
+		actor.approveOK(rt, txnID, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("proposal in the prior encoding is neither time locked nor expiring", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, noUnlockDuration, 0, signers...)
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, (&multisig0.ProposeParams{To: chuck, Value: sendValue, Method: fakeMethod, Params: fakeParams}).MarshalCBOR(buf))
+		var params multisig.ProposeParams
+		require.NoError(t, params.UnmarshalCBOR(buf))
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		ret := rt.Call(actor.a.Propose, &params).(*multisig.ProposeReturn)
+		rt.Verify()
+		assert.False(t, ret.Applied)
+		actor.assertTransactions(rt, multisig.Transaction{To: chuck, Value: sendValue, Method: fakeMethod, Params: fakeParams,
+			Approved: []addr.Address{anne}})
+
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, fakeMethod, fakeParams, sendValue, nil, 0)
+		actor.approveOK(rt, txnID, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("invalid constraints are rejected", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, noUnlockDuration, 0, signers...)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		for _, tc := range []struct {
+			params *multisig.ProposeWithScheduleParams
+			msg    string
+		}{
+			{proposal(-1, 0), "earliest execution epoch must be non-negative"},
+			{proposal(0, 99), "is before current epoch"},
+			{proposal(300, 200), "is before earliest execution"},
+		} {
+			rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+			rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, tc.msg, func() {
+				rt.Call(actor.a.ProposeWithSchedule, tc.params)
+			})
+			rt.Verify()
+		}
+		actor.assertTransactions(rt)
+	})
+
+	t.Run("proposal hash covers time lock and expiry", func(t *testing.T) {
+		txn := multisig.Transaction{To: chuck, Value: sendValue, Method: fakeMethod, Params: fakeParams, Approved: []addr.Address{anne}}
+
+		// An unconstrained proposal hashes as in prior versions.
+		legacy := multisig0.ProposalHashData{Requester: anne, To: chuck, Value: sendValue, Method: fakeMethod, Params: fakeParams}
+		legacyData, err := legacy.Serialize()
+		require.NoError(t, err)
+		legacyHash := blake2b.Sum256(legacyData)
+		unconstrainedHash := makeProposalHash(t, &txn)
+		assert.Equal(t, legacyHash[:], unconstrainedHash)
+
+		txn.EarliestExecution = 200
+		lockedHash := makeProposalHash(t, &txn)
+		assert.NotEqual(t, unconstrainedHash, lockedHash)
+
+		txn.Expiration = 300
+		expiringHash := makeProposalHash(t, &txn)
+		assert.NotEqual(t, lockedHash, expiringHash)
+
+		// Approving with the hash of the unconstrained transaction fails.
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, noUnlockDuration, 0, signers...)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeWithSchedule(rt, proposal(200, 300))
+
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "hash does not match proposal params", func() {
+			rt.Call(actor.a.Approve, &multisig.TxnIDParams{ID: multisig.TxnID(txnID), ProposalHash: unconstrainedHash})
+		})
+		rt.Verify()
+		actor.approveOK(rt, txnID, expiringHash, nil)
+		actor.checkState(rt)
+	})
+
+	t.Run("prune expired transactions", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, numApprovals, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeWithSchedule(rt, proposal(0, 150))
+		actor.proposeWithSchedule(rt, proposal(0, 250))
+		actor.proposeWithSchedule(rt, proposal(0, 0))
+
+		rt.SetEpoch(200)
+
+		// Only signers may prune.
+		rt.SetCaller(richard, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "is not a signer", func() {
+			rt.Call(actor.a.PruneExpired, &multisig.PruneExpiredParams{IDs: []multisig.TxnID{0}})
+		})
+		rt.Verify()
+
+		// Unexpired transactions may not be pruned.
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		for _, id := range []multisig.TxnID{1, 2} {
+			rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+			rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "has not expired", func() {
+				rt.Call(actor.a.PruneExpired, &multisig.PruneExpiredParams{IDs: []multisig.TxnID{0, id}})
+			})
+			rt.Verify()
+		}
+
+		actor.pruneExpired(rt, 0)
+		actor.assertTransactions(rt,
+			multisig.Transaction{To: chuck, Value: sendValue, Method: fakeMethod, Params: fakeParams, Approved: []addr.Address{anne}, Expiration: 250},
+			multisig.Transaction{To: chuck, Value: sendValue, Method: fakeMethod, Params: fakeParams, Approved: []addr.Address{anne}},
+		)
+
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no such transaction 0", func() {
+			rt.Call(actor.a.PruneExpired, &multisig.PruneExpiredParams{IDs: []multisig.TxnID{0}})
+		})
+		rt.Verify()
+		actor.checkState(rt)
+	})
+}
+
 func TestAddSigner(t *testing.T) {
 	actor := msActorHarness{multisig.Actor{}, t}
 	startEpoch := abi.ChainEpoch(0)
@@ -1736,120 +1973,941 @@ func TestChangeThreshold(t *testing.T) {
 	})
 }
 
-func TestLockBalance(t *testing.T) {
+func TestSignerWeights(t *testing.T) {
 	actor := msActorHarness{multisig.Actor{}, t}
+
 	receiver := tutil.NewIDAddr(t, 100)
 	anne := tutil.NewIDAddr(t, 101)
 	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+	richard := tutil.NewIDAddr(t, 105)
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+	const fakeMethod = abi.MethodNum(42)
+	var sendValue = abi.NewTokenAmount(10)
+	var fakeParams = builtin.CBORBytes([]byte{1, 2, 3, 4})
+	var signers = []addr.Address{anne, bob, chuck}
 
 	builder := mock.NewBuilder(context.Background(), receiver).
 		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
-		WithEpoch(0).
-		WithHasher(blake2b.Sum256)
+		WithHasher(blake2b.Sum256).
+		WithBalance(sendValue, big.Zero())
 
-	t.Run("retroactive vesting", func(t *testing.T) {
+	getState := func(rt *mock.Runtime) *multisig.State {
+		var st multisig.State
+		rt.GetState(&st)
+		return &st
+	}
+
+	t.Run("signers are constructed with unit weight", func(t *testing.T) {
 		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+		st := getState(rt)
+		assert.Equal(t, []uint64{1, 1, 1}, st.SignerWeights)
+		assert.Equal(t, uint64(3), st.TotalSignerWeight())
 
-		// Create empty multisig
-		rt.SetEpoch(100)
-		actor.constructAndVerify(rt, 1, 0, 0, anne)
+		// Added signers also have unit weight.
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.addSigner(rt, darlene, false)
+		assert.Equal(t, []uint64{1, 1, 1, 1}, getState(rt).SignerWeights)
+		actor.checkState(rt)
+	})
+
+	t.Run("heavy signer meets threshold alone", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
 
-		// Some time later, initialize vesting
-		rt.SetEpoch(200)
-		vestStart := abi.ChainEpoch(0)
-		lockAmount := abi.NewTokenAmount(100_000)
-		vestDuration := abi.ChainEpoch(1000)
 		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
-		actor.lockBalance(rt, vestStart, vestDuration, lockAmount)
+		actor.setSignerWeight(rt, anne, 2)
+		assert.Equal(t, uint64(2), getState(rt).SignerWeight(anne))
 
-		rt.SetEpoch(300)
-		vested := abi.NewTokenAmount(30_000) // Since vestStart
-		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		// A unit weight signer's proposal remains pending.
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, chuck, sendValue, fakeMethod, fakeParams, nil)
 
-		// Fail to spend balance the multisig doesn't have
-		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
-			_ = actor.propose(rt, bob, vested, builtin.MethodSend, nil, nil)
-		})
-		rt.Reset()
+		// The heavy signer's approval executes it.
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectSend(chuck, fakeMethod, fakeParams, sendValue, nil, 0)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
 
-		// Fail to spend more than the vested amount
-		rt.SetBalance(lockAmount)
-		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
-			_ = actor.propose(rt, bob, big.Add(vested, big.NewInt(1)), builtin.MethodSend, nil, nil)
-		})
-		rt.Reset()
+	t.Run("threshold may be raised up to total weight", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
 
-		// Can fully spend the vested amount
-		rt.ExpectSend(bob, builtin.MethodSend, nil, vested, nil, exitcode.Ok)
-		actor.proposeOK(rt, bob, vested, builtin.MethodSend, nil, nil)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setSignerWeight(rt, chuck, 3)
+		actor.changeNumApprovalsThreshold(rt, 5)
+		assert.Equal(t, uint64(5), getState(rt).NumApprovalsThreshold)
 
-		// Can't spend more
-		rt.SetBalance(big.Sub(lockAmount, vested))
-		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
-			_ = actor.propose(rt, bob, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.changeNumApprovalsThreshold(rt, 6)
 		})
-		rt.Reset()
-
-		// Later, can spend the rest
-		rt.SetEpoch(vestStart + vestDuration)
-		rested := big.NewInt(70_000)
-		rt.ExpectSend(bob, builtin.MethodSend, nil, rested, nil, exitcode.Ok)
-		actor.proposeOK(rt, bob, rested, builtin.MethodSend, nil, nil)
 		actor.checkState(rt)
 	})
 
-	t.Run("prospective vesting", func(t *testing.T) {
+	t.Run("swapped signer keeps weight", func(t *testing.T) {
 		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
 
-		// Create empty multisig
-		rt.SetEpoch(100)
-		actor.constructAndVerify(rt, 1, 0, 0, anne)
-
-		// Some time later, initialize vesting
-		rt.SetEpoch(200)
-		vestStart := abi.ChainEpoch(1000)
-		lockAmount := abi.NewTokenAmount(100_000)
-		vestDuration := abi.ChainEpoch(1000)
 		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
-		actor.lockBalance(rt, vestStart, vestDuration, lockAmount)
+		actor.setSignerWeight(rt, anne, 3)
+		actor.swapSigners(rt, anne, darlene)
 
-		rt.SetEpoch(300)
-		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		st := getState(rt)
+		assert.Equal(t, []addr.Address{bob, chuck, darlene}, st.Signers)
+		assert.Equal(t, []uint64{1, 1, 3}, st.SignerWeights)
+		actor.checkState(rt)
+	})
 
-		// Oversupply the wallet, allow spending the oversupply.
-		rt.SetBalance(big.Add(lockAmount, abi.NewTokenAmount(1)))
-		rt.ExpectSend(bob, builtin.MethodSend, nil, abi.NewTokenAmount(1), nil, exitcode.Ok)
-		actor.proposeOK(rt, bob, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+	t.Run("remove signer accounts for weight", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 3, noUnlockDuration, 0, signers...)
 
-		// Fail to spend locked funds before vesting starts
-		rt.SetBalance(lockAmount)
-		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
-			_ = actor.propose(rt, bob, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setSignerWeight(rt, anne, 3)
+		actor.changeNumApprovalsThreshold(rt, 4)
+
+		// Removing anne would leave weight 2, below the threshold even when decreased.
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "below threshold 3", func() {
+			actor.removeSigner(rt, anne, true)
 		})
-		rt.Reset()
 
-		// Can spend partially vested amount
-		rt.SetEpoch(vestStart + 200)
-		vested := abi.NewTokenAmount(20_000)
-		rt.ExpectSend(bob, builtin.MethodSend, nil, vested, nil, exitcode.Ok)
-		actor.proposeOK(rt, bob, vested, builtin.MethodSend, nil, nil)
+		// Removing bob leaves weight 4.
+		actor.removeSigner(rt, bob, false)
+		st := getState(rt)
+		assert.Equal(t, []addr.Address{anne, chuck}, st.Signers)
+		assert.Equal(t, []uint64{3, 1}, st.SignerWeights)
+		actor.checkState(rt)
+	})
 
-		// Can't spend more
-		rt.SetBalance(big.Sub(lockAmount, vested))
-		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
-			_ = actor.propose(rt, bob, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+	t.Run("fails to set invalid weight", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 3, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "must be in", func() {
+			actor.setSignerWeight(rt, anne, 0)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "must be in", func() {
+			actor.setSignerWeight(rt, anne, multisig.SignerWeightMax+1)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "is not a signer", func() {
+			actor.setSignerWeight(rt, richard, 2)
 		})
-		rt.Reset()
 
-		// Later, can spend the rest
-		rt.SetEpoch(vestStart + vestDuration)
-		rested := big.NewInt(80_000)
-		rt.ExpectSend(bob, builtin.MethodSend, nil, rested, nil, exitcode.Ok)
-		actor.proposeOK(rt, bob, rested, builtin.MethodSend, nil, nil)
+		// Can't reduce weight below threshold.
+		actor.setSignerWeight(rt, anne, 2)
+		actor.changeNumApprovalsThreshold(rt, 4)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "below threshold 4", func() {
+			actor.setSignerWeight(rt, anne, 1)
+		})
 		actor.checkState(rt)
 	})
 
-	t.Run("can't alter vesting", func(t *testing.T) {
+	t.Run("only the multisig may set weights", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.setSignerWeight(rt, anne, 2)
+		})
+	})
+}
+
+func TestApprovalPolicies(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+	eve := tutil.NewIDAddr(t, 105)
+	miner := tutil.NewIDAddr(t, 106)
+	minerRobust := tutil.NewActorAddr(t, "miner")
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+	const fakeMethod = abi.MethodNum(42)
+	var sendValue = abi.NewTokenAmount(10)
+	var fakeParams = builtin.CBORBytes([]byte{1, 2, 3, 4})
+	var signers = []addr.Address{anne, bob, chuck, darlene, eve}
+
+	builder := mock.NewBuilder(context.Background(), receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256).
+		WithBalance(sendValue, big.Zero())
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := builder.Build(t)
+		rt.AddIDAddress(minerRobust, miner)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		// Signer management requires 3 of 5, while withdrawing from the miner requires only 1.
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setApprovalPolicy(rt, receiver, builtin.MethodsMultisig.AddSigner, 3)
+		actor.setApprovalPolicy(rt, minerRobust, builtin.MethodsMiner.WithdrawBalance, 1)
+		return rt
+	}
+
+	t.Run("policy raises threshold for target method", func(t *testing.T) {
+		rt := setup(t)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, receiver, big.Zero(), builtin.MethodsMultisig.AddSigner, fakeParams, nil)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       receiver,
+			Value:    big.Zero(),
+			Method:   builtin.MethodsMultisig.AddSigner,
+			Params:   fakeParams,
+			Approved: []addr.Address{anne, bob},
+		})
+
+		rt.SetCaller(chuck, builtin.AccountActorCodeID)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.AddSigner, fakeParams, big.Zero(), nil, 0)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("policy lowers threshold for target method", func(t *testing.T) {
+		rt := setup(t)
+
+		// The policy applies whether the target is given by ID or robust address.
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectSend(miner, builtin.MethodsMiner.WithdrawBalance, fakeParams, big.Zero(), nil, 0)
+		actor.proposeOK(rt, miner, big.Zero(), builtin.MethodsMiner.WithdrawBalance, fakeParams, nil)
+		rt.ExpectSend(minerRobust, builtin.MethodsMiner.WithdrawBalance, fakeParams, big.Zero(), nil, 0)
+		actor.proposeOK(rt, minerRobust, big.Zero(), builtin.MethodsMiner.WithdrawBalance, fakeParams, nil)
+
+		// Other methods to the same target use the default threshold.
+		actor.proposeOK(rt, miner, sendValue, fakeMethod, fakeParams, nil)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(miner, fakeMethod, fakeParams, sendValue, nil, 0)
+		actor.approveOK(rt, 2, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("policy threshold counts signer weight", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setSignerWeight(rt, anne, 2)
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, receiver, big.Zero(), builtin.MethodsMultisig.AddSigner, fakeParams, nil)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.AddSigner, fakeParams, big.Zero(), nil, 0)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("remove policy", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setApprovalPolicy(rt, receiver, builtin.MethodsMultisig.AddSigner, 0)
+
+		var st multisig.State
+		rt.GetState(&st)
+		threshold, err := st.ApprovalThreshold(rt.AdtStore(), receiver, builtin.MethodsMultisig.AddSigner)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), threshold)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no approval policy", func() {
+			actor.setApprovalPolicy(rt, receiver, builtin.MethodsMultisig.AddSigner, 0)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("policy threshold must be attainable", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "exceeds total signer weight 5", func() {
+			actor.setApprovalPolicy(rt, receiver, builtin.MethodsMultisig.RemoveSigner, 6)
+		})
+
+		actor.setApprovalPolicy(rt, receiver, builtin.MethodsMultisig.RemoveSigner, 5)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "below policy threshold 5", func() {
+			actor.removeSigner(rt, eve, false)
+		})
+		actor.setSignerWeight(rt, anne, 2)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "below policy threshold 5", func() {
+			actor.removeSigner(rt, anne, false)
+		})
+		actor.removeSigner(rt, eve, false)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "below policy threshold 5", func() {
+			actor.setSignerWeight(rt, anne, 1)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("fails to set policy for unresolvable target", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectAbort(exitcode.ErrIllegalArgument, func() {
+			actor.setApprovalPolicy(rt, tutil.NewActorAddr(t, "unknown"), fakeMethod, 1)
+		})
+	})
+
+	t.Run("only the multisig may set policies", func(t *testing.T) {
+		rt := setup(t)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.setApprovalPolicy(rt, receiver, fakeMethod, 1)
+		})
+	})
+}
+
+func TestExecuteBatch(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+	const fakeMethod = abi.MethodNum(42)
+	var balance = abi.NewTokenAmount(100)
+	var fakeParams = builtin.CBORBytes([]byte{1, 2, 3, 4})
+	var fakeRet = builtin.CBORBytes([]byte{5, 6})
+	var signers = []addr.Address{anne, bob, chuck}
+
+	builder := mock.NewBuilder(context.Background(), receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256).
+		WithBalance(balance, big.Zero())
+
+	batch := &multisig.ExecuteBatchParams{Sends: []multisig.BatchSend{
+		{To: chuck, Value: abi.NewTokenAmount(30), Method: builtin.MethodSend, Params: nil},
+		{To: darlene, Value: abi.NewTokenAmount(20), Method: fakeMethod, Params: fakeParams},
+		{To: receiver, Value: big.Zero(), Method: builtin.MethodsMultisig.AddSigner, Params: fakeParams},
+	}}
+
+	t.Run("applies sends in a call of their own", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		applied := multisig.ExecuteBatchReturn{Results: []multisig.BatchSendResult{
+			{Code: exitcode.Ok},
+			{Code: exitcode.Ok, Ret: fakeRet},
+			{Code: exitcode.Ok},
+		}}
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.ApplyBatch, &multisig.ApplyBatchParams{Sends: batch.Sends}, big.Zero(), &applied, exitcode.Ok)
+		ret := actor.executeBatch(rt, batch)
+		assert.Equal(t, applied, *ret)
+	})
+
+	t.Run("reports code of failed send", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.ApplyBatch, &multisig.ApplyBatchParams{Sends: batch.Sends}, big.Zero(),
+			nil, exitcode.ErrIllegalArgument)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.ApplyBatch, &multisig.ApplyBatchParams{Sends: batch.Sends, Probe: true}, big.Zero(),
+			nil, multisig.ErrBatchProbe+1)
+		ret := actor.executeBatch(rt, batch)
+		assert.Equal(t, []multisig.BatchSendResult{
+			{Code: exitcode.Ok},
+			{Code: exitcode.ErrIllegalArgument},
+		}, ret.Results)
+	})
+
+	t.Run("aborts with code of failed batch if no send failed", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.ApplyBatch, &multisig.ApplyBatchParams{Sends: batch.Sends}, big.Zero(),
+			nil, exitcode.SysErrOutOfGas)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.ApplyBatch, &multisig.ApplyBatchParams{Sends: batch.Sends, Probe: true}, big.Zero(),
+			nil, multisig.ErrBatchProbe+exitcode.ExitCode(len(batch.Sends)))
+		rt.ExpectAbort(exitcode.SysErrOutOfGas, func() {
+			actor.executeBatch(rt, batch)
+		})
+	})
+
+	t.Run("fails when batch exceeds unlocked balance", func(t *testing.T) {
+		rt := builder.Build(t)
+		rt.SetReceived(balance)
+		actor.constructAndVerify(rt, 2, 10, 0, signers...)
+
+		// Half the balance is unlocked at epoch 5.
+		rt.SetEpoch(5)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		overspend := &multisig.ExecuteBatchParams{Sends: []multisig.BatchSend{
+			{To: chuck, Value: abi.NewTokenAmount(30), Method: builtin.MethodSend},
+			{To: darlene, Value: abi.NewTokenAmount(30), Method: builtin.MethodSend},
+		}}
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			actor.executeBatch(rt, overspend)
+		})
+
+		overspend.Sends[1].Value = abi.NewTokenAmount(20)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.ApplyBatch, &multisig.ApplyBatchParams{Sends: overspend.Sends}, big.Zero(),
+			&multisig.ExecuteBatchReturn{}, exitcode.Ok)
+		actor.executeBatch(rt, overspend)
+	})
+
+	t.Run("fails with invalid batch", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "empty batch", func() {
+			actor.executeBatch(rt, &multisig.ExecuteBatchParams{})
+		})
+
+		tooMany := &multisig.ExecuteBatchParams{}
+		for i := 0; i <= multisig.BatchSendsMax; i++ {
+			tooMany.Sends = append(tooMany.Sends, multisig.BatchSend{To: chuck, Value: big.Zero()})
+		}
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "exceeds max", func() {
+			actor.executeBatch(rt, tooMany)
+		})
+
+		negative := &multisig.ExecuteBatchParams{Sends: []multisig.BatchSend{{To: chuck, Value: abi.NewTokenAmount(-1)}}}
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "must be non-negative", func() {
+			actor.executeBatch(rt, negative)
+		})
+	})
+
+	t.Run("fails with nested batch", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+
+		nested := &multisig.ExecuteBatchParams{Sends: []multisig.BatchSend{
+			{To: chuck, Value: abi.NewTokenAmount(30), Method: builtin.MethodSend},
+			{To: receiver, Value: big.Zero(), Method: builtin.MethodsMultisig.ExecuteBatch, Params: serializeBatch(t, batch)},
+		}}
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "batch send 1 may not execute a nested batch", func() {
+			actor.executeBatch(rt, nested)
+		})
+
+		nested.Sends[1].Method = builtin.MethodsMultisig.ApplyBatch
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "batch send 1 may not execute a nested batch", func() {
+			actor.executeBatch(rt, nested)
+		})
+	})
+
+	t.Run("only the multisig may execute a batch", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.executeBatch(rt, batch)
+		})
+	})
+
+	t.Run("batch transaction is approved once and reports results", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		batchParams := serializeBatch(t, batch)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, receiver, big.Zero(), builtin.MethodsMultisig.ExecuteBatch, batchParams, nil)
+
+		batchRet := multisig.ExecuteBatchReturn{Results: []multisig.BatchSendResult{{}, {Ret: fakeRet}, {}}}
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.ExecuteBatch, builtin.CBORBytes(batchParams), big.Zero(), &batchRet, exitcode.Ok)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		approveRet := rt.Call(actor.a.Approve, &multisig.TxnIDParams{ID: 0}).(*multisig.ApproveReturn)
+		rt.Verify()
+
+		assert.True(t, approveRet.Applied)
+		assert.Equal(t, exitcode.Ok, approveRet.Code)
+		var gotRet multisig.ExecuteBatchReturn
+		require.NoError(t, gotRet.UnmarshalCBOR(bytes.NewReader(approveRet.Ret)))
+		assert.Equal(t, batchRet, gotRet)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("batch transaction requires greatest threshold of its sends", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setApprovalPolicy(rt, receiver, builtin.MethodsMultisig.AddSigner, 3)
+
+		batchParams := serializeBatch(t, batch)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, receiver, big.Zero(), builtin.MethodsMultisig.ExecuteBatch, batchParams, nil)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.assertTransactions(rt, multisig.Transaction{
+			To:       receiver,
+			Value:    big.Zero(),
+			Method:   builtin.MethodsMultisig.ExecuteBatch,
+			Params:   batchParams,
+			Approved: []addr.Address{anne, bob},
+		})
+
+		rt.SetCaller(chuck, builtin.AccountActorCodeID)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.ExecuteBatch, builtin.CBORBytes(batchParams), big.Zero(), nil, exitcode.Ok)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+}
+
+func TestApplyBatch(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+	const fakeMethod = abi.MethodNum(42)
+	var balance = abi.NewTokenAmount(100)
+	var fakeParams = builtin.CBORBytes([]byte{1, 2, 3, 4})
+	var fakeRet = builtin.CBORBytes([]byte{5, 6})
+	var signers = []addr.Address{anne, bob, chuck}
+
+	builder := mock.NewBuilder(context.Background(), receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256).
+		WithBalance(balance, big.Zero())
+
+	sends := []multisig.BatchSend{
+		{To: chuck, Value: abi.NewTokenAmount(30), Method: builtin.MethodSend, Params: nil},
+		{To: darlene, Value: abi.NewTokenAmount(20), Method: fakeMethod, Params: fakeParams},
+		{To: receiver, Value: big.Zero(), Method: builtin.MethodsMultisig.AddSigner, Params: fakeParams},
+	}
+
+	t.Run("executes sends in order", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectSend(chuck, builtin.MethodSend, nil, abi.NewTokenAmount(30), nil, exitcode.Ok)
+		rt.ExpectSend(darlene, fakeMethod, fakeParams, abi.NewTokenAmount(20), &fakeRet, exitcode.Ok)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.AddSigner, fakeParams, big.Zero(), nil, exitcode.Ok)
+		ret := actor.applyBatch(rt, &multisig.ApplyBatchParams{Sends: sends})
+		assert.Equal(t, []multisig.BatchSendResult{
+			{Code: exitcode.Ok, Ret: []byte{}},
+			{Code: exitcode.Ok, Ret: fakeRet},
+			{Code: exitcode.Ok, Ret: []byte{}},
+		}, ret.Results)
+	})
+
+	t.Run("aborts with code of failed send", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectSend(chuck, builtin.MethodSend, nil, abi.NewTokenAmount(30), nil, exitcode.Ok)
+		rt.ExpectSend(darlene, fakeMethod, fakeParams, abi.NewTokenAmount(20), nil, exitcode.ErrIllegalArgument)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "batch send 1", func() {
+			actor.applyBatch(rt, &multisig.ApplyBatchParams{Sends: sends})
+		})
+	})
+
+	t.Run("probe aborts with index of failed send", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectSend(chuck, builtin.MethodSend, nil, abi.NewTokenAmount(30), nil, exitcode.Ok)
+		rt.ExpectSend(darlene, fakeMethod, fakeParams, abi.NewTokenAmount(20), nil, exitcode.ErrIllegalArgument)
+		rt.ExpectAbort(multisig.ErrBatchProbe+1, func() {
+			actor.applyBatch(rt, &multisig.ApplyBatchParams{Sends: sends, Probe: true})
+		})
+	})
+
+	t.Run("probe aborts with number of sends if none fails", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		rt.ExpectSend(chuck, builtin.MethodSend, nil, abi.NewTokenAmount(30), nil, exitcode.Ok)
+		rt.ExpectSend(darlene, fakeMethod, fakeParams, abi.NewTokenAmount(20), &fakeRet, exitcode.Ok)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.AddSigner, fakeParams, big.Zero(), nil, exitcode.Ok)
+		rt.ExpectAbort(multisig.ErrBatchProbe+3, func() {
+			actor.applyBatch(rt, &multisig.ApplyBatchParams{Sends: sends, Probe: true})
+		})
+	})
+
+	t.Run("only the multisig may apply a batch", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.applyBatch(rt, &multisig.ApplyBatchParams{Sends: sends})
+		})
+	})
+
+	t.Run("applying a batch may not be proposed", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 2, noUnlockDuration, 0, signers...)
+
+		params := serializeBatch(t, &multisig.ExecuteBatchParams{Sends: sends})
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "may only be applied by executing a batch", func() {
+			rt.Call(actor.a.Propose, &multisig.ProposeParams{
+				To:     receiver,
+				Value:  big.Zero(),
+				Method: builtin.MethodsMultisig.ApplyBatch,
+				Params: params,
+			})
+		})
+	})
+}
+
+func serializeBatch(t *testing.T, batch *multisig.ExecuteBatchParams) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, batch.MarshalCBOR(buf))
+	return buf.Bytes()
+}
+
+func TestVestingSchedule(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+	chuck := tutil.NewIDAddr(t, 103)
+	darlene := tutil.NewIDAddr(t, 104)
+
+	const noUnlockDuration = abi.ChainEpoch(0)
+	var balance = abi.NewTokenAmount(3000)
+	var signers = []addr.Address{anne, bob, chuck}
+
+	builder := mock.NewBuilder(context.Background(), receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithHasher(blake2b.Sum256).
+		WithBalance(balance, big.Zero())
+
+	// A cliff of 100 epochs, then 1000 vesting over the first 100 epochs and 2000 over the following 200.
+	tranches := []multisig.VestingTranche{
+		{Duration: 100, Amount: abi.NewTokenAmount(1000)},
+		{Duration: 200, Amount: abi.NewTokenAmount(2000)},
+	}
+	scheduleParams := func(revocationThreshold uint64) *multisig.SetVestingScheduleParams {
+		return &multisig.SetVestingScheduleParams{
+			StartEpoch:          10,
+			CliffDuration:       150,
+			Tranches:            tranches,
+			RevocationThreshold: revocationThreshold,
+		}
+	}
+
+	t.Run("amount locked follows cliff and tranches", func(t *testing.T) {
+		vs := multisig.VestingSchedule{CliffDuration: 150, Tranches: tranches}
+		assert.Equal(t, abi.NewTokenAmount(3000), vs.TotalAmount())
+		assert.Equal(t, abi.ChainEpoch(300), vs.TotalDuration())
+
+		for _, tc := range []struct {
+			elapsed abi.ChainEpoch
+			locked  int64
+		}{
+			{-10, 3000},
+			{0, 3000},
+			{149, 3000},
+			{150, 1500}, // First tranche and a quarter of the second vest at the cliff.
+			{200, 1000},
+			{299, 10},
+			{300, 0},
+			{400, 0},
+		} {
+			assert.Equal(t, abi.NewTokenAmount(tc.locked), vs.AmountLocked(tc.elapsed), "elapsed %d", tc.elapsed)
+		}
+
+		// A cliff may outlast the tranches.
+		vs.CliffDuration = 500
+		assert.Equal(t, abi.ChainEpoch(500), vs.TotalDuration())
+		assert.Equal(t, abi.NewTokenAmount(3000), vs.AmountLocked(499))
+		assert.Equal(t, big.Zero(), vs.AmountLocked(500))
+
+		// A tranche with no duration vests at once.
+		vs = multisig.VestingSchedule{Tranches: []multisig.VestingTranche{
+			{Duration: 0, Amount: abi.NewTokenAmount(500)},
+			{Duration: 10, Amount: abi.NewTokenAmount(100)},
+		}}
+		assert.Equal(t, abi.NewTokenAmount(600), vs.AmountLocked(-1))
+		assert.Equal(t, abi.NewTokenAmount(100), vs.AmountLocked(0))
+		assert.Equal(t, abi.NewTokenAmount(50), vs.AmountLocked(5))
+	})
+
+	t.Run("spending respects schedule", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setVestingSchedule(rt, scheduleParams(0))
+
+		var st multisig.State
+		rt.GetState(&st)
+		assert.Equal(t, abi.ChainEpoch(10), st.StartEpoch)
+		assert.Equal(t, abi.ChainEpoch(300), st.UnlockDuration)
+		assert.Equal(t, balance, st.InitialBalance)
+		require.NotNil(t, st.VestingSchedule)
+		assert.Equal(t, tranches, st.VestingSchedule.Tranches)
+		actor.checkState(rt)
+
+		// Nothing may be spent before the cliff.
+		rt.SetEpoch(159)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			actor.proposeOK(rt, darlene, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+		})
+
+		// At the cliff, the first tranche and some of the second are available.
+		rt.SetEpoch(160)
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			actor.proposeOK(rt, darlene, abi.NewTokenAmount(1501), builtin.MethodSend, nil, nil)
+		})
+		rt.ExpectSend(darlene, builtin.MethodSend, nil, abi.NewTokenAmount(1500), nil, exitcode.Ok)
+		actor.proposeOK(rt, darlene, abi.NewTokenAmount(1500), builtin.MethodSend, nil, nil)
+		actor.checkState(rt)
+	})
+
+	t.Run("schedule may not replace a lock", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+
+		actor.setVestingSchedule(rt, scheduleParams(0))
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "modification of unlock disallowed", func() {
+			actor.setVestingSchedule(rt, scheduleParams(0))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "modification of unlock disallowed", func() {
+			actor.lockBalance(rt, 0, 100, balance)
+		})
+
+		rt = builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.lockBalance(rt, 0, 100, balance)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "modification of unlock disallowed", func() {
+			actor.setVestingSchedule(rt, scheduleParams(0))
+		})
+	})
+
+	t.Run("fails with invalid schedule", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+
+		for _, tc := range []struct {
+			modify func(p *multisig.SetVestingScheduleParams)
+			msg    string
+		}{
+			{func(p *multisig.SetVestingScheduleParams) { p.Tranches = nil }, "at least one tranche"},
+			{func(p *multisig.SetVestingScheduleParams) {
+				p.Tranches = make([]multisig.VestingTranche, multisig.VestingTranchesMax+1)
+			}, "exceeds max"},
+			{func(p *multisig.SetVestingScheduleParams) { p.CliffDuration = -1 }, "negative cliff duration"},
+			{func(p *multisig.SetVestingScheduleParams) {
+				p.Tranches = []multisig.VestingTranche{{Duration: -1, Amount: big.Zero()}}
+			}, "negative duration"},
+			{func(p *multisig.SetVestingScheduleParams) {
+				p.Tranches = []multisig.VestingTranche{{Duration: 1, Amount: abi.NewTokenAmount(-1)}}
+			}, "negative amount"},
+			{func(p *multisig.SetVestingScheduleParams) {
+				p.CliffDuration = 0
+				p.Tranches = []multisig.VestingTranche{{Duration: 0, Amount: balance}}
+			}, "duration must be positive"},
+			{func(p *multisig.SetVestingScheduleParams) { p.RevocationThreshold = 4 }, "exceeds total signer weight"},
+		} {
+			params := scheduleParams(0)
+			tc.modify(params)
+			rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, tc.msg, func() {
+				actor.setVestingSchedule(rt, params)
+			})
+		}
+		actor.checkState(rt)
+	})
+
+	t.Run("revocation requires revocation threshold", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setVestingSchedule(rt, scheduleParams(2))
+
+		// Signers can't be removed such that the revocation threshold is unattainable.
+		actor.removeSigner(rt, chuck, false)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "below policy threshold 2", func() {
+			actor.removeSigner(rt, bob, false)
+		})
+
+		revokeParams := &multisig.RevokeVestingParams{To: darlene}
+		buf := new(bytes.Buffer)
+		require.NoError(t, revokeParams.MarshalCBOR(buf))
+
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+		actor.proposeOK(rt, receiver, big.Zero(), builtin.MethodsMultisig.RevokeVesting, buf.Bytes(), nil)
+		rt.SetCaller(bob, builtin.AccountActorCodeID)
+		rt.ExpectSend(receiver, builtin.MethodsMultisig.RevokeVesting, builtin.CBORBytes(buf.Bytes()), big.Zero(), nil, exitcode.Ok)
+		actor.approveOK(rt, 0, nil, nil)
+		actor.assertTransactions(rt)
+		actor.checkState(rt)
+	})
+
+	t.Run("revocation sends locked balance and clears schedule", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.setVestingSchedule(rt, scheduleParams(2))
+
+		rt.SetEpoch(210)
+		rt.ExpectSend(darlene, builtin.MethodSend, nil, abi.NewTokenAmount(1000), nil, exitcode.Ok)
+		actor.revokeVesting(rt, darlene)
+
+		var st multisig.State
+		rt.GetState(&st)
+		assert.Nil(t, st.VestingSchedule)
+		assert.Equal(t, abi.ChainEpoch(0), st.UnlockDuration)
+		assert.True(t, st.AmountLocked(0).Equals(big.Zero()))
+		actor.checkState(rt)
+
+		// With the schedule revoked, a new lock may be set.
+		actor.lockBalance(rt, 0, 100, abi.NewTokenAmount(1000))
+		actor.checkState(rt)
+	})
+
+	t.Run("fails to revoke irrevocable or missing schedule", func(t *testing.T) {
+		rt := builder.Build(t)
+		actor.constructAndVerify(rt, 1, noUnlockDuration, 0, signers...)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "no vesting schedule", func() {
+			actor.revokeVesting(rt, darlene)
+		})
+		actor.setVestingSchedule(rt, scheduleParams(0))
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "not revocable", func() {
+			actor.revokeVesting(rt, darlene)
+		})
+	})
+}
+
+func TestLockBalance(t *testing.T) {
+	actor := msActorHarness{multisig.Actor{}, t}
+	receiver := tutil.NewIDAddr(t, 100)
+	anne := tutil.NewIDAddr(t, 101)
+	bob := tutil.NewIDAddr(t, 102)
+
+	builder := mock.NewBuilder(context.Background(), receiver).
+		WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+		WithEpoch(0).
+		WithHasher(blake2b.Sum256)
+
+	t.Run("retroactive vesting", func(t *testing.T) {
+		rt := builder.Build(t)
+
+		// Create empty multisig
+		rt.SetEpoch(100)
+		actor.constructAndVerify(rt, 1, 0, 0, anne)
+
+		// Some time later, initialize vesting
+		rt.SetEpoch(200)
+		vestStart := abi.ChainEpoch(0)
+		lockAmount := abi.NewTokenAmount(100_000)
+		vestDuration := abi.ChainEpoch(1000)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.lockBalance(rt, vestStart, vestDuration, lockAmount)
+
+		rt.SetEpoch(300)
+		vested := abi.NewTokenAmount(30_000) // Since vestStart
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		// Fail to spend balance the multisig doesn't have
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			_ = actor.propose(rt, bob, vested, builtin.MethodSend, nil, nil)
+		})
+		rt.Reset()
+
+		// Fail to spend more than the vested amount
+		rt.SetBalance(lockAmount)
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			_ = actor.propose(rt, bob, big.Add(vested, big.NewInt(1)), builtin.MethodSend, nil, nil)
+		})
+		rt.Reset()
+
+		// Can fully spend the vested amount
+		rt.ExpectSend(bob, builtin.MethodSend, nil, vested, nil, exitcode.Ok)
+		actor.proposeOK(rt, bob, vested, builtin.MethodSend, nil, nil)
+
+		// Can't spend more
+		rt.SetBalance(big.Sub(lockAmount, vested))
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			_ = actor.propose(rt, bob, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+		})
+		rt.Reset()
+
+		// Later, can spend the rest
+		rt.SetEpoch(vestStart + vestDuration)
+		rested := big.NewInt(70_000)
+		rt.ExpectSend(bob, builtin.MethodSend, nil, rested, nil, exitcode.Ok)
+		actor.proposeOK(rt, bob, rested, builtin.MethodSend, nil, nil)
+		actor.checkState(rt)
+	})
+
+	t.Run("prospective vesting", func(t *testing.T) {
+		rt := builder.Build(t)
+
+		// Create empty multisig
+		rt.SetEpoch(100)
+		actor.constructAndVerify(rt, 1, 0, 0, anne)
+
+		// Some time later, initialize vesting
+		rt.SetEpoch(200)
+		vestStart := abi.ChainEpoch(1000)
+		lockAmount := abi.NewTokenAmount(100_000)
+		vestDuration := abi.ChainEpoch(1000)
+		rt.SetCaller(receiver, builtin.MultisigActorCodeID)
+		actor.lockBalance(rt, vestStart, vestDuration, lockAmount)
+
+		rt.SetEpoch(300)
+		rt.SetCaller(anne, builtin.AccountActorCodeID)
+
+		// Oversupply the wallet, allow spending the oversupply.
+		rt.SetBalance(big.Add(lockAmount, abi.NewTokenAmount(1)))
+		rt.ExpectSend(bob, builtin.MethodSend, nil, abi.NewTokenAmount(1), nil, exitcode.Ok)
+		actor.proposeOK(rt, bob, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+
+		// Fail to spend locked funds before vesting starts
+		rt.SetBalance(lockAmount)
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			_ = actor.propose(rt, bob, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+		})
+		rt.Reset()
+
+		// Can spend partially vested amount
+		rt.SetEpoch(vestStart + 200)
+		vested := abi.NewTokenAmount(20_000)
+		rt.ExpectSend(bob, builtin.MethodSend, nil, vested, nil, exitcode.Ok)
+		actor.proposeOK(rt, bob, vested, builtin.MethodSend, nil, nil)
+
+		// Can't spend more
+		rt.SetBalance(big.Sub(lockAmount, vested))
+		rt.ExpectAbort(exitcode.ErrInsufficientFunds, func() {
+			_ = actor.propose(rt, bob, abi.NewTokenAmount(1), builtin.MethodSend, nil, nil)
+		})
+		rt.Reset()
+
+		// Later, can spend the rest
+		rt.SetEpoch(vestStart + vestDuration)
+		rested := big.NewInt(80_000)
+		rt.ExpectSend(bob, builtin.MethodSend, nil, rested, nil, exitcode.Ok)
+		actor.proposeOK(rt, bob, rested, builtin.MethodSend, nil, nil)
+		actor.checkState(rt)
+	})
+
+	t.Run("can't alter vesting", func(t *testing.T) {
 		rt := builder.Build(t)
 
 		// Create empty multisig
@@ -1965,6 +3023,13 @@ func (h *msActorHarness) propose(rt *mock.Runtime, to addr.Address, value abi.To
 	return proposeReturn.Code
 }
 
+func (h *msActorHarness) proposeWithSchedule(rt *mock.Runtime, params *multisig.ProposeWithScheduleParams) *multisig.ProposeReturn {
+	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+	ret := rt.Call(h.a.ProposeWithSchedule, params)
+	rt.Verify()
+	return ret.(*multisig.ProposeReturn)
+}
+
 // returns the proposal hash
 func (h *msActorHarness) proposeOK(rt *mock.Runtime, to addr.Address, value abi.TokenAmount, method abi.MethodNum, params []byte, out cbor.Unmarshaler) []byte {
 	code := h.propose(rt, to, value, method, params, out)
@@ -2020,6 +3085,12 @@ func (h *msActorHarness) cancel(rt *mock.Runtime, txnID int64, proposalParams []
 	rt.Verify()
 }
 
+func (h *msActorHarness) pruneExpired(rt *mock.Runtime, txnIDs ...multisig.TxnID) {
+	rt.ExpectValidateCallerType(builtin.CallerTypesSignable...)
+	rt.Call(h.a.PruneExpired, &multisig.PruneExpiredParams{IDs: txnIDs})
+	rt.Verify()
+}
+
 func (h *msActorHarness) addSigner(rt *mock.Runtime, signer addr.Address, increase bool) {
 	rt.ExpectValidateCallerAddr(rt.Receiver())
 	rt.Call(h.a.AddSigner, &multisig.AddSignerParams{
@@ -2055,6 +3126,39 @@ func (h *msActorHarness) changeNumApprovalsThreshold(rt *mock.Runtime, newThresh
 	rt.Verify()
 }
 
+func (h *msActorHarness) setSignerWeight(rt *mock.Runtime, signer addr.Address, weight uint64) {
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	rt.Call(h.a.SetSignerWeight, &multisig.SetSignerWeightParams{
+		Signer: signer,
+		Weight: weight,
+	})
+	rt.Verify()
+}
+
+func (h *msActorHarness) setApprovalPolicy(rt *mock.Runtime, to addr.Address, method abi.MethodNum, threshold uint64) {
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	rt.Call(h.a.SetApprovalPolicy, &multisig.SetApprovalPolicyParams{
+		To:        to,
+		Method:    method,
+		Threshold: threshold,
+	})
+	rt.Verify()
+}
+
+func (h *msActorHarness) applyBatch(rt *mock.Runtime, params *multisig.ApplyBatchParams) *multisig.ExecuteBatchReturn {
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	ret := rt.Call(h.a.ApplyBatch, params)
+	rt.Verify()
+	return ret.(*multisig.ExecuteBatchReturn)
+}
+
+func (h *msActorHarness) executeBatch(rt *mock.Runtime, params *multisig.ExecuteBatchParams) *multisig.ExecuteBatchReturn {
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	ret := rt.Call(h.a.ExecuteBatch, params)
+	rt.Verify()
+	return ret.(*multisig.ExecuteBatchReturn)
+}
+
 func (h *msActorHarness) lockBalance(rt *mock.Runtime, start, duration abi.ChainEpoch, amount abi.TokenAmount) {
 	rt.ExpectValidateCallerAddr(rt.Receiver())
 	rt.Call(h.a.LockBalance, &multisig.LockBalanceParams{
@@ -2065,6 +3169,18 @@ func (h *msActorHarness) lockBalance(rt *mock.Runtime, start, duration abi.Chain
 	rt.Verify()
 }
 
+func (h *msActorHarness) setVestingSchedule(rt *mock.Runtime, params *multisig.SetVestingScheduleParams) {
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	rt.Call(h.a.SetVestingSchedule, params)
+	rt.Verify()
+}
+
+func (h *msActorHarness) revokeVesting(rt *mock.Runtime, to addr.Address) {
+	rt.ExpectValidateCallerAddr(rt.Receiver())
+	rt.Call(h.a.RevokeVesting, &multisig.RevokeVestingParams{To: to})
+	rt.Verify()
+}
+
 func (h *msActorHarness) assertTransactions(rt *mock.Runtime, expected ...multisig.Transaction) {
 	var st multisig.State
 	rt.GetState(&st)
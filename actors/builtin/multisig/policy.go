@@ -3,3 +3,12 @@ package multisig
 // SignersMax is the maximum number of signers allowed in a multisig. If more
 // are required, please use a combining tree of multisigs.
 const SignersMax = 256
+
+// SignerWeightMax is the maximum approval weight of a single signer.
+const SignerWeightMax = uint64(1) << 32
+
+// BatchSendsMax is the maximum number of sends in a batch transaction.
+const BatchSendsMax = 64
+
+// VestingTranchesMax is the maximum number of tranches in a vesting schedule.
+const VestingTranchesMax = 64
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 )
@@ -12,6 +13,8 @@ type StateSummary struct {
 	PendingTxnCount       uint64
 	NumApprovalsThreshold uint64
 	SignerCount           int
+	TotalSignerWeight     uint64
+	ApprovalPolicyCount   uint64
 }
 
 // Checks internal invariants of multisig state.
@@ -20,14 +23,38 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 
 	// assert invariants involving signers
 	acc.Require(len(st.Signers) <= SignersMax, "multisig has too many signers: %d", len(st.Signers))
-	acc.Require(uint64(len(st.Signers)) >= st.NumApprovalsThreshold,
-		"multisig has insufficient signers to meet threshold (%d < %d)", len(st.Signers), st.NumApprovalsThreshold)
+	acc.Require(len(st.SignerWeights) == len(st.Signers),
+		"multisig has %d signer weights for %d signers", len(st.SignerWeights), len(st.Signers))
+	totalWeight := uint64(0)
+	for i, w := range st.SignerWeights {
+		acc.Require(w > 0 && w <= SignerWeightMax, "signer %d has weight %d out of range", i, w)
+		totalWeight += w
+	}
+	acc.Require(totalWeight >= st.NumApprovalsThreshold,
+		"multisig has insufficient signer weight to meet threshold (%d < %d)", totalWeight, st.NumApprovalsThreshold)
+	if st.VestingSchedule != nil {
+		acc.Require(totalWeight >= st.VestingSchedule.RevocationThreshold,
+			"multisig has insufficient signer weight to meet vesting revocation threshold (%d < %d)", totalWeight, st.VestingSchedule.RevocationThreshold)
+	}
 
 	if st.UnlockDuration == 0 { // See https://github.com/filecoin-project/specs-actors/issues/1185
 		acc.Require(st.StartEpoch == 0, "non-zero start epoch %d with zero unlock duration", st.StartEpoch)
 		acc.Require(st.InitialBalance.IsZero(), "non-zero locked balance %v with zero unlock duration", st.InitialBalance)
 	}
 
+	if vs := st.VestingSchedule; vs != nil {
+		acc.Require(len(vs.Tranches) > 0, "vesting schedule has no tranches")
+		acc.Require(vs.CliffDuration >= 0, "vesting schedule has negative cliff duration %d", vs.CliffDuration)
+		for i, tranche := range vs.Tranches {
+			acc.Require(tranche.Duration >= 0, "vesting tranche %d has negative duration %d", i, tranche.Duration)
+			acc.Require(tranche.Amount.GreaterThanEqual(big.Zero()), "vesting tranche %d has negative amount %v", i, tranche.Amount)
+		}
+		acc.Require(st.UnlockDuration == vs.TotalDuration(), "unlock duration %d does not match vesting schedule duration %d",
+			st.UnlockDuration, vs.TotalDuration())
+		acc.Require(st.InitialBalance.Equals(vs.TotalAmount()), "locked balance %v does not match vesting schedule amount %v",
+			st.InitialBalance, vs.TotalAmount())
+	}
+
 	// create lookup to test transaction approvals are multisig signers.
 	signers := make(map[address.Address]struct{})
 	for _, a := range st.Signers {
@@ -61,6 +88,12 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 				seenApprovals[approval] = struct{}{}
 			}
 
+			acc.Require(txn.EarliestExecution >= 0, "transaction %d has negative earliest execution %d", txnID, txn.EarliestExecution)
+			if txn.Expiration != 0 {
+				acc.Require(txn.Expiration >= txn.EarliestExecution, "transaction %d expiration %d before earliest execution %d",
+					txnID, txn.Expiration, txn.EarliestExecution)
+			}
+
 			numPending++
 			return nil
 		})
@@ -68,10 +101,32 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	}
 
 	acc.Require(st.NextTxnID > maxTxnID, "next transaction id %d is not greater than pending ids", st.NextTxnID)
+
+	// test approval policies
+	numPolicies := uint64(0)
+	if policies, err := adt.AsMap(store, st.ApprovalPolicies, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading approval policies: %v", err)
+	} else {
+		var policy ApprovalPolicy
+		err = policies.ForEach(&policy, func(key string) error {
+			acc.Require(key == PolicyKey(policy.To, policy.Method).Key(), "approval policy for %v method %d stored under wrong key",
+				policy.To, policy.Method)
+			acc.Require(policy.To.Protocol() == address.ID, "approval policy target %v is not an ID address", policy.To)
+			acc.Require(policy.Threshold > 0, "approval policy for %v method %d has zero threshold", policy.To, policy.Method)
+			acc.Require(policy.Threshold <= totalWeight, "approval policy for %v method %d threshold %d exceeds signer weight %d",
+				policy.To, policy.Method, policy.Threshold, totalWeight)
+			numPolicies++
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating approval policies")
+	}
+
 	return &StateSummary{
 		PendingTxnCount:       numPending,
 		NumApprovalsThreshold: st.NumApprovalsThreshold,
 		SignerCount:           len(st.Signers),
+		TotalSignerWeight:     totalWeight,
+		ApprovalPolicyCount:   numPolicies,
 	}, acc
 }
 
@@ -13,7 +13,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{134}
+var lengthBufState = []byte{140}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -36,11 +36,31 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.FromSigner (address.Address) (struct)
+	if err := t.FromSigner.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.ToSigner (address.Address) (struct)
+	if err := t.ToSigner.MarshalCBOR(w); err != nil {
+		return err
+	}
+
 	// t.ToSend (big.Int) (struct)
 	if err := t.ToSend.MarshalCBOR(w); err != nil {
 		return err
 	}
 
+	// t.Withdrawn (big.Int) (struct)
+	if err := t.Withdrawn.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Reserved (big.Int) (struct)
+	if err := t.Reserved.MarshalCBOR(w); err != nil {
+		return err
+	}
+
 	// t.SettlingAt (abi.ChainEpoch) (int64)
 	if t.SettlingAt >= 0 {
 		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.SettlingAt)); err != nil {
@@ -69,6 +89,18 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return xerrors.Errorf("failed to write cid field t.LaneStates: %w", err)
 	}
 
+	// t.Deposits (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Deposits); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Deposits: %w", err)
+	}
+
+	// t.ApprovedVouchers (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.ApprovedVouchers); err != nil {
+		return xerrors.Errorf("failed to write cid field t.ApprovedVouchers: %w", err)
+	}
+
 	return nil
 }
 
@@ -86,7 +118,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 6 {
+	if extra != 12 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -107,6 +139,24 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.To: %w", err)
 		}
 
+	}
+	// t.FromSigner (address.Address) (struct)
+
+	{
+
+		if err := t.FromSigner.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.FromSigner: %w", err)
+		}
+
+	}
+	// t.ToSigner (address.Address) (struct)
+
+	{
+
+		if err := t.ToSigner.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ToSigner: %w", err)
+		}
+
 	}
 	// t.ToSend (big.Int) (struct)
 
@@ -116,6 +166,24 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.ToSend: %w", err)
 		}
 
+	}
+	// t.Withdrawn (big.Int) (struct)
+
+	{
+
+		if err := t.Withdrawn.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Withdrawn: %w", err)
+		}
+
+	}
+	// t.Reserved (big.Int) (struct)
+
+	{
+
+		if err := t.Reserved.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Reserved: %w", err)
+		}
+
 	}
 	// t.SettlingAt (abi.ChainEpoch) (int64)
 	{
@@ -178,11 +246,35 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 
 		t.LaneStates = c
 
+	}
+	// t.Deposits (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Deposits: %w", err)
+		}
+
+		t.Deposits = c
+
+	}
+	// t.ApprovedVouchers (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.ApprovedVouchers: %w", err)
+		}
+
+		t.ApprovedVouchers = c
+
 	}
 	return nil
 }
 
-var lengthBufLaneState = []byte{130}
+var lengthBufLaneState = []byte{131}
 
 func (t *LaneState) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -206,6 +298,10 @@ func (t *LaneState) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.Pending (paych.ConditionalVoucher) (struct)
+	if err := t.Pending.MarshalCBOR(w); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -223,7 +319,7 @@ func (t *LaneState) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -249,6 +345,570 @@ func (t *LaneState) UnmarshalCBOR(r io.Reader) error {
 		}
 		t.Nonce = uint64(extra)
 
+	}
+	// t.Pending (paych.ConditionalVoucher) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Pending = new(ConditionalVoucher)
+			if err := t.Pending.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Pending pointer: %w", err)
+			}
+		}
+
+	}
+	return nil
+}
+
+var lengthBufDeposit = []byte{130}
+
+func (t *Deposit) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDeposit); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	if t.Epoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Epoch-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *Deposit) UnmarshalCBOR(r io.Reader) error {
+	*t = Deposit{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Epoch = abi.ChainEpoch(extraI)
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufConditionalVoucher = []byte{131}
+
+func (t *ConditionalVoucher) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufConditionalVoucher); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.SecretHash ([]uint8) (slice)
+	if len(t.SecretHash) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.SecretHash was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.SecretHash))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.SecretHash[:]); err != nil {
+		return err
+	}
+
+	// t.Expiry (abi.ChainEpoch) (int64)
+	if t.Expiry >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Expiry)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Expiry-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *ConditionalVoucher) UnmarshalCBOR(r io.Reader) error {
+	*t = ConditionalVoucher{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	// t.SecretHash ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.SecretHash: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+
+	if extra > 0 {
+		t.SecretHash = make([]uint8, extra)
+	}
+
+	if _, err := io.ReadFull(br, t.SecretHash[:]); err != nil {
+		return err
+	}
+	// t.Expiry (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Expiry = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufWithdrawParams = []byte{129}
+
+func (t *WithdrawParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufWithdrawParams); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *WithdrawParams) UnmarshalCBOR(r io.Reader) error {
+	*t = WithdrawParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufLockConditionalVoucherParams = []byte{129}
+
+func (t *LockConditionalVoucherParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufLockConditionalVoucherParams); err != nil {
+		return err
+	}
+
+	// t.Sv (paych.SignedVoucher) (struct)
+	if err := t.Sv.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *LockConditionalVoucherParams) UnmarshalCBOR(r io.Reader) error {
+	*t = LockConditionalVoucherParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Sv (paych.SignedVoucher) (struct)
+
+	{
+
+		if err := t.Sv.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Sv: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufRevealSecretParams = []byte{130}
+
+func (t *RevealSecretParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRevealSecretParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Lane (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Lane)); err != nil {
+		return err
+	}
+
+	// t.Secret ([]uint8) (slice)
+	if len(t.Secret) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.Secret was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Secret))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.Secret[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RevealSecretParams) UnmarshalCBOR(r io.Reader) error {
+	*t = RevealSecretParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Lane (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Lane = uint64(extra)
+
+	}
+	// t.Secret ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.Secret: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+
+	if extra > 0 {
+		t.Secret = make([]uint8, extra)
+	}
+
+	if _, err := io.ReadFull(br, t.Secret[:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+var lengthBufReleaseConditionalVoucherParams = []byte{129}
+
+func (t *ReleaseConditionalVoucherParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufReleaseConditionalVoucherParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Lane (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Lane)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *ReleaseConditionalVoucherParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ReleaseConditionalVoucherParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Lane (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Lane = uint64(extra)
+
+	}
+	return nil
+}
+
+var lengthBufApproveVoucherParams = []byte{129}
+
+func (t *ApproveVoucherParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufApproveVoucherParams); err != nil {
+		return err
+	}
+
+	// t.Sv (paych.SignedVoucher) (struct)
+	if err := t.Sv.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ApproveVoucherParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ApproveVoucherParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Sv (paych.SignedVoucher) (struct)
+
+	{
+
+		if err := t.Sv.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Sv: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufDelegateSignerParams = []byte{129}
+
+func (t *DelegateSignerParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDelegateSignerParams); err != nil {
+		return err
+	}
+
+	// t.Signer (address.Address) (struct)
+	if err := t.Signer.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *DelegateSignerParams) UnmarshalCBOR(r io.Reader) error {
+	*t = DelegateSignerParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Signer (address.Address) (struct)
+
+	{
+
+		if err := t.Signer.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Signer: %w", err)
+		}
+
 	}
 	return nil
 }
@@ -30,6 +30,13 @@ func (a Actor) Exports() []interface{} {
 		2:                         a.UpdateChannelState,
 		3:                         a.Settle,
 		4:                         a.Collect,
+		5:                         a.AddFunds,
+		6:                         a.Withdraw,
+		7:                         a.LockConditionalVoucher,
+		8:                         a.RevealSecret,
+		9:                         a.ReleaseConditionalVoucher,
+		10:                        a.ApproveVoucher,
+		11:                        a.DelegateSigner,
 	}
 }
 
@@ -50,15 +57,17 @@ var _ runtime.VMActor = Actor{}
 type ConstructorParams = paych0.ConstructorParams
 
 // Constructor creates a payment channel actor. See State for meaning of params.
+// A party that is not an account approves vouchers on-chain with `ApproveVoucher()`, unless it delegates
+// signing to an account key with `DelegateSigner()`.
 func (pca *Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *abi.EmptyValue {
 	// Only InitActor can create a payment channel actor. It creates the actor on
 	// behalf of the payer/payee.
 	rt.ValidateImmediateCallerType(builtin.InitActorCodeID)
 
-	// check that both parties are capable of signing vouchers
-	to, err := pca.resolveAccount(rt, params.To)
+	// check that both parties are capable of signing or approving vouchers
+	to, err := pca.resolveSignable(rt, params.To)
 	builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalState), "failed to resolve to address: %s", params.To)
-	from, err := pca.resolveAccount(rt, params.From)
+	from, err := pca.resolveSignable(rt, params.From)
 	builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalState), "failed to resolve from address: %s", params.From)
 
 	emptyArr, err := adt.MakeEmptyArray(adt.AsStore(rt), LaneStatesAmtBitwidth)
@@ -66,14 +75,27 @@ func (pca *Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *ab
 	emptyArrCid, err := emptyArr.Root()
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to persist empty array")
 
-	st := ConstructState(from, to, emptyArrCid)
+	deposits, err := adt.MakeEmptyArray(adt.AsStore(rt), DepositsAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to create empty array")
+	if rt.ValueReceived().GreaterThan(big.Zero()) {
+		err = deposits.AppendContinuous(&Deposit{Epoch: rt.CurrEpoch(), Amount: rt.ValueReceived()})
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record initial deposit")
+	}
+	depositsCid, err := deposits.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to persist deposits")
+
+	emptyApprovalsCid, err := adt.StoreEmptyMap(adt.AsStore(rt), builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to create empty map")
+
+	// Each party initially signs or approves its own vouchers.
+	st := ConstructState(from, to, from, to, emptyArrCid, depositsCid, emptyApprovalsCid)
 	rt.StateCreate(st)
 
 	return nil
 }
 
-// Resolves an address to a canonical ID address and requires it to address an account actor.
-func (pca *Actor) resolveAccount(rt runtime.Runtime, raw addr.Address) (addr.Address, error) {
+// Resolves an address to a canonical ID address and requires it to address an actor that can sign.
+func (pca *Actor) resolveSignable(rt runtime.Runtime, raw addr.Address) (addr.Address, error) {
 	resolved, err := builtin.ResolveToIDAddr(rt, raw)
 	if err != nil {
 		return addr.Undef, exitcode.ErrIllegalState.Wrapf("failed to resolve address %v: %w", raw, err)
@@ -83,12 +105,18 @@ func (pca *Actor) resolveAccount(rt runtime.Runtime, raw addr.Address) (addr.Add
 	if !ok {
 		return addr.Undef, exitcode.ErrIllegalArgument.Wrapf("no code for address %v", resolved)
 	}
-	if codeCID != builtin.AccountActorCodeID {
-		return addr.Undef, exitcode.ErrForbidden.Wrapf("actor %v must be an account (%v), was %v", raw,
-			builtin.AccountActorCodeID, codeCID)
+	for _, signable := range builtin.CallerTypesSignable {
+		if codeCID.Equals(signable) {
+			return resolved, nil
+		}
 	}
+	return addr.Undef, exitcode.ErrForbidden.Wrapf("actor %v must be able to sign (%v), was %v", raw,
+		builtin.CallerTypesSignable, codeCID)
+}
 
-	return resolved, nil
+func isAccount(rt runtime.Runtime, a addr.Address) bool {
+	codeCID, ok := rt.GetActorCodeCID(a)
+	return ok && codeCID.Equals(builtin.AccountActorCodeID)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -154,17 +182,122 @@ func (pca Actor) UpdateChannelState(rt runtime.Runtime, params *UpdateChannelSta
 	var st State
 	rt.StateReadonly(&st)
 
+	sv := params.Sv
+	approval := validateVoucher(rt, &st, &sv, params.Secret)
+
+	if len(sv.SecretPreimage) > 0 {
+		hashedSecret := rt.HashBlake2b(params.Secret)
+		if !bytes.Equal(hashedSecret[:], sv.SecretPreimage) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "incorrect secret!")
+		}
+	}
+
+	verifyVoucherExtra(rt, &sv)
+
+	rt.StateTransaction(&st, func() {
+		consumeVoucherApproval(rt, &st, approval)
+		laneFound := true
+
+		lstates, err := adt.AsArray(adt.AsStore(rt), st.LaneStates, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lanes")
+
+		// Find the voucher lane, creating if necessary.
+		laneId := sv.Lane
+		laneState := findLane(rt, lstates, sv.Lane)
+
+		if laneState == nil {
+			laneState = &LaneState{
+				Redeemed: big.Zero(),
+				Nonce:    0,
+			}
+			laneFound = false
+		}
+
+		if laneFound {
+			if laneState.Nonce >= sv.Nonce {
+				rt.Abortf(exitcode.ErrIllegalArgument, "voucher has an outdated nonce, existing nonce: %d, voucher nonce: %d, cannot redeem",
+					laneState.Nonce, sv.Nonce)
+			}
+			releaseExpiredConditionalVoucher(rt, &st, laneId, laneState)
+		}
+
+		// The next section actually calculates the payment amounts to update the payment channel state
+		// 1. (optional) sum already redeemed value of all merging lanes
+		redeemedFromOthers := big.Zero()
+		for _, merge := range sv.Merges {
+			if merge.Lane == sv.Lane {
+				rt.Abortf(exitcode.ErrIllegalArgument, "voucher cannot merge lanes into its own lane")
+			}
+
+			otherls := findLane(rt, lstates, merge.Lane)
+			if otherls == nil {
+				rt.Abortf(exitcode.ErrIllegalArgument, "voucher specifies invalid merge lane %v", merge.Lane)
+				return // makes linters happy
+			}
+
+			if otherls.Nonce >= merge.Nonce {
+				rt.Abortf(exitcode.ErrIllegalArgument, "merged lane in voucher has outdated nonce, cannot redeem")
+			}
+			releaseExpiredConditionalVoucher(rt, &st, merge.Lane, otherls)
+
+			redeemedFromOthers = big.Add(redeemedFromOthers, otherls.Redeemed)
+			otherls.Nonce = merge.Nonce
+			err = lstates.Set(merge.Lane, otherls)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane %d", merge.Lane)
+		}
+
+		// 2. To prevent double counting, remove already redeemed amounts (from
+		// voucher or other lanes) from the voucher amount
+		laneState.Nonce = sv.Nonce
+		balanceDelta := big.Sub(sv.Amount, big.Add(redeemedFromOthers, laneState.Redeemed))
+		// 3. set new redeemed value for merged-into lane
+		laneState.Redeemed = sv.Amount
+
+		newSendBalance := big.Add(st.ToSend, balanceDelta)
+
+		// 4. check operation validity
+		if newSendBalance.LessThan(big.Zero()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "voucher would leave channel balance negative")
+		}
+		if newSendBalance.LessThan(st.Withdrawn) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "voucher would reduce redeemed amount %v below withdrawn amount %v", newSendBalance, st.Withdrawn)
+		}
+		// Funds already withdrawn are no longer in the channel's balance, while funds reserved
+		// for conditional vouchers are unavailable.
+		if big.Add(big.Sub(newSendBalance, st.Withdrawn), st.Reserved).GreaterThan(rt.CurrentBalance()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "not enough funds in channel to cover voucher")
+		}
+
+		// 5. add new redemption ToSend
+		st.ToSend = newSendBalance
+
+		// update channel settlingAt and MinSettleHeight if delayed by voucher
+		updateMinSettleHeight(&st, &sv)
+
+		err = lstates.Set(laneId, laneState)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane", laneId)
+
+		st.LaneStates, err = lstates.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save lanes")
+	})
+	return nil
+}
+
+// Validates that a voucher for this channel, signed or approved by the channel party other than the caller,
+// may be processed at the current epoch.
+// Returns the key of the on-chain approval authorising the voucher, to be consumed, or nil if it is signed.
+func validateVoucher(rt runtime.Runtime, st *State, sv *SignedVoucher, secret []byte) abi.Keyer {
 	// both parties must sign voucher: one who submits it, the other explicitly signs it
 	rt.ValidateImmediateCallerIs(st.From, st.To)
-	var signer addr.Address
+	var party addr.Address
 	if rt.Caller() == st.From {
-		signer = st.To
+		party = st.To
 	} else {
-		signer = st.From
+		party = st.From
 	}
-	sv := params.Sv
+	signer := st.SignerFor(party)
 
-	if sv.Signature == nil {
+	if sv.Signature == nil && isAccount(rt, signer) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "voucher has no signature")
 	}
 
@@ -172,15 +305,27 @@ func (pca Actor) UpdateChannelState(rt runtime.Runtime, params *UpdateChannelSta
 		rt.Abortf(ErrChannelStateUpdateAfterSettled, "no vouchers can be processed after SettlingAt epoch")
 	}
 
-	if len(params.Secret) > MaxSecretSize {
+	if len(secret) > MaxSecretSize {
 		rt.Abortf(exitcode.ErrIllegalArgument, "secret must be at most 256 bytes long")
 	}
 
 	vb, err := sv.SigningBytes()
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to serialize signedvoucher")
 
-	err = rt.VerifySignature(*sv.Signature, signer, vb)
-	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "voucher signature invalid")
+	var approval abi.Keyer
+	if isAccount(rt, signer) {
+		err = rt.VerifySignature(*sv.Signature, signer, vb)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "voucher signature invalid")
+	} else {
+		approval = VoucherApprovalKey(party, rt.HashBlake2b(vb))
+		approvals, err := adt.AsSet(adt.AsStore(rt), st.ApprovedVouchers, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load voucher approvals")
+		approved, err := approvals.Has(approval)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check voucher approval")
+		if !approved {
+			rt.Abortf(exitcode.ErrForbidden, "voucher not approved by %v", party)
+		}
+	}
 
 	pchAddr := rt.Receiver()
 	svpchIDAddr, found := rt.ResolveAddress(sv.ChannelAddr)
@@ -202,14 +347,24 @@ func (pca Actor) UpdateChannelState(rt runtime.Runtime, params *UpdateChannelSta
 	if sv.Amount.Sign() < 0 {
 		rt.Abortf(exitcode.ErrIllegalArgument, "voucher amount must be non-negative, was %v", sv.Amount)
 	}
+	return approval
+}
 
-	if len(sv.SecretPreimage) > 0 {
-		hashedSecret := rt.HashBlake2b(params.Secret)
-		if !bytes.Equal(hashedSecret[:], sv.SecretPreimage) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "incorrect secret!")
-		}
+// Removes a consumed voucher approval, if any.
+func consumeVoucherApproval(rt runtime.Runtime, st *State, approval abi.Keyer) {
+	if approval == nil {
+		return
 	}
+	approvals, err := adt.AsSet(adt.AsStore(rt), st.ApprovedVouchers, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load voucher approvals")
+	err = approvals.Delete(approval)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to remove voucher approval")
+	st.ApprovedVouchers, err = approvals.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save voucher approvals")
+}
 
+// Invokes a voucher's extra verification method, if any.
+func verifyVoucherExtra(rt runtime.Runtime, sv *SignedVoucher) {
 	if sv.Extra != nil {
 
 		code := rt.Send(
@@ -221,88 +376,191 @@ func (pca Actor) UpdateChannelState(rt runtime.Runtime, params *UpdateChannelSta
 		)
 		builtin.RequireSuccess(rt, code, "spend voucher verification failed")
 	}
+}
+
+// Delays channel SettlingAt and MinSettleHeight if required by a voucher.
+func updateMinSettleHeight(st *State, sv *SignedVoucher) {
+	if sv.MinSettleHeight != 0 {
+		if st.SettlingAt != 0 && st.SettlingAt < sv.MinSettleHeight {
+			st.SettlingAt = sv.MinSettleHeight
+		}
+		if st.MinSettleHeight < sv.MinSettleHeight {
+			st.MinSettleHeight = sv.MinSettleHeight
+		}
+	}
+}
+
+// Removes an expired conditional voucher from a lane, releasing its reserved funds.
+// Aborts if the lane's conditional voucher is yet to expire.
+func releaseExpiredConditionalVoucher(rt runtime.Runtime, st *State, laneID uint64, ls *LaneState) {
+	if ls.Pending == nil {
+		return
+	}
+	if rt.CurrEpoch() <= ls.Pending.Expiry {
+		rt.Abortf(exitcode.ErrForbidden, "lane %d has a conditional voucher pending until %d", laneID, ls.Pending.Expiry)
+	}
+	st.Reserved = big.Sub(st.Reserved, ls.ReservedAmount())
+	ls.Pending = nil
+}
+
+type LockConditionalVoucherParams struct {
+	Sv SignedVoucher
+}
+
+// Locks a conditional voucher on its lane, reserving funds to pay it should its secret be revealed by its expiry.
+// The voucher's SecretPreimage is the hash of the secret, and its TimeLockMax the expiry.
+// Settlement of the channel is delayed until after the expiry.
+// A conditional voucher may not merge lanes.
+func (pca Actor) LockConditionalVoucher(rt runtime.Runtime, params *LockConditionalVoucherParams) *abi.EmptyValue {
+	var st State
+	rt.StateReadonly(&st)
+
+	sv := params.Sv
+	approval := validateVoucher(rt, &st, &sv, nil)
+
+	if len(sv.SecretPreimage) == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "conditional voucher must have a secret hash")
+	}
+	if sv.TimeLockMax == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "conditional voucher must have an expiry")
+	}
+	if len(sv.Merges) > 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "conditional voucher cannot merge lanes")
+	}
+
+	verifyVoucherExtra(rt, &sv)
 
 	rt.StateTransaction(&st, func() {
-		laneFound := true
+		consumeVoucherApproval(rt, &st, approval)
 
 		lstates, err := adt.AsArray(adt.AsStore(rt), st.LaneStates, LaneStatesAmtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lanes")
 
-		// Find the voucher lane, creating if necessary.
-		laneId := sv.Lane
 		laneState := findLane(rt, lstates, sv.Lane)
-
 		if laneState == nil {
 			laneState = &LaneState{
 				Redeemed: big.Zero(),
 				Nonce:    0,
 			}
-			laneFound = false
-		}
-
-		if laneFound {
+		} else {
 			if laneState.Nonce >= sv.Nonce {
 				rt.Abortf(exitcode.ErrIllegalArgument, "voucher has an outdated nonce, existing nonce: %d, voucher nonce: %d, cannot redeem",
 					laneState.Nonce, sv.Nonce)
 			}
+			releaseExpiredConditionalVoucher(rt, &st, sv.Lane, laneState)
 		}
 
-		// The next section actually calculates the payment amounts to update the payment channel state
-		// 1. (optional) sum already redeemed value of all merging lanes
-		redeemedFromOthers := big.Zero()
-		for _, merge := range sv.Merges {
-			if merge.Lane == sv.Lane {
-				rt.Abortf(exitcode.ErrIllegalArgument, "voucher cannot merge lanes into its own lane")
-			}
+		if sv.Amount.LessThan(laneState.Redeemed) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "conditional voucher amount %v is less than lane redeemed amount %v",
+				sv.Amount, laneState.Redeemed)
+		}
 
-			otherls := findLane(rt, lstates, merge.Lane)
-			if otherls == nil {
-				rt.Abortf(exitcode.ErrIllegalArgument, "voucher specifies invalid merge lane %v", merge.Lane)
-				return // makes linters happy
-			}
+		// The voucher's nonce is consumed, so it may not be locked again nor redeemed unconditionally.
+		laneState.Nonce = sv.Nonce
+		laneState.Pending = &ConditionalVoucher{
+			Amount:     sv.Amount,
+			SecretHash: sv.SecretPreimage,
+			Expiry:     sv.TimeLockMax,
+		}
 
-			if otherls.Nonce >= merge.Nonce {
-				rt.Abortf(exitcode.ErrIllegalArgument, "merged lane in voucher has outdated nonce, cannot redeem")
-			}
+		newReserved := big.Add(st.Reserved, laneState.ReservedAmount())
+		if big.Add(st.AvailableToWithdraw(), newReserved).GreaterThan(rt.CurrentBalance()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "not enough funds in channel to cover voucher")
+		}
+		st.Reserved = newReserved
 
-			redeemedFromOthers = big.Add(redeemedFromOthers, otherls.Redeemed)
-			otherls.Nonce = merge.Nonce
-			err = lstates.Set(merge.Lane, otherls)
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane %d", merge.Lane)
+		updateMinSettleHeight(&st, &sv)
+		// The channel may not settle while the secret may still be revealed.
+		if st.SettlingAt != 0 && st.SettlingAt <= sv.TimeLockMax {
+			st.SettlingAt = sv.TimeLockMax + 1
+		}
+		if st.MinSettleHeight <= sv.TimeLockMax {
+			st.MinSettleHeight = sv.TimeLockMax + 1
 		}
 
-		// 2. To prevent double counting, remove already redeemed amounts (from
-		// voucher or other lanes) from the voucher amount
-		laneState.Nonce = sv.Nonce
-		balanceDelta := big.Sub(sv.Amount, big.Add(redeemedFromOthers, laneState.Redeemed))
-		// 3. set new redeemed value for merged-into lane
-		laneState.Redeemed = sv.Amount
+		err = lstates.Set(sv.Lane, laneState)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane %d", sv.Lane)
 
-		newSendBalance := big.Add(st.ToSend, balanceDelta)
+		st.LaneStates, err = lstates.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save lanes")
+	})
+	return nil
+}
 
-		// 4. check operation validity
-		if newSendBalance.LessThan(big.Zero()) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "voucher would leave channel balance negative")
+type RevealSecretParams struct {
+	Lane   uint64
+	Secret []byte
+}
+
+// Redeems the conditional voucher pending on a lane by revealing the preimage of its secret hash.
+func (pca Actor) RevealSecret(rt runtime.Runtime, params *RevealSecretParams) *abi.EmptyValue {
+	if len(params.Secret) > MaxSecretSize {
+		rt.Abortf(exitcode.ErrIllegalArgument, "secret must be at most 256 bytes long")
+	}
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		rt.ValidateImmediateCallerIs(st.From, st.To)
+
+		if st.SettlingAt != 0 && rt.CurrEpoch() >= st.SettlingAt {
+			rt.Abortf(ErrChannelStateUpdateAfterSettled, "no vouchers can be processed after SettlingAt epoch")
 		}
-		if newSendBalance.GreaterThan(rt.CurrentBalance()) {
-			rt.Abortf(exitcode.ErrIllegalArgument, "not enough funds in channel to cover voucher")
+
+		lstates, err := adt.AsArray(adt.AsStore(rt), st.LaneStates, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lanes")
+
+		laneState := findLane(rt, lstates, params.Lane)
+		if laneState == nil || laneState.Pending == nil {
+			rt.Abortf(exitcode.ErrNotFound, "no conditional voucher pending on lane %d", params.Lane)
+			return // makes linters happy
+		}
+		if rt.CurrEpoch() > laneState.Pending.Expiry {
+			rt.Abortf(exitcode.ErrForbidden, "conditional voucher on lane %d expired at %d", params.Lane, laneState.Pending.Expiry)
+		}
+		hashedSecret := rt.HashBlake2b(params.Secret)
+		if !bytes.Equal(hashedSecret[:], laneState.Pending.SecretHash) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "incorrect secret!")
 		}
 
-		// 5. add new redemption ToSend
-		st.ToSend = newSendBalance
+		// Reserved funds become redeemed.
+		reserved := laneState.ReservedAmount()
+		st.Reserved = big.Sub(st.Reserved, reserved)
+		st.ToSend = big.Add(st.ToSend, reserved)
+		laneState.Redeemed = laneState.Pending.Amount
+		laneState.Pending = nil
 
-		// update channel settlingAt and MinSettleHeight if delayed by voucher
-		if sv.MinSettleHeight != 0 {
-			if st.SettlingAt != 0 && st.SettlingAt < sv.MinSettleHeight {
-				st.SettlingAt = sv.MinSettleHeight
-			}
-			if st.MinSettleHeight < sv.MinSettleHeight {
-				st.MinSettleHeight = sv.MinSettleHeight
-			}
+		err = lstates.Set(params.Lane, laneState)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane %d", params.Lane)
+
+		st.LaneStates, err = lstates.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save lanes")
+	})
+	return nil
+}
+
+type ReleaseConditionalVoucherParams struct {
+	Lane uint64
+}
+
+// Removes an expired conditional voucher from a lane, returning the funds reserved for it to the channel.
+func (pca Actor) ReleaseConditionalVoucher(rt runtime.Runtime, params *ReleaseConditionalVoucherParams) *abi.EmptyValue {
+	var st State
+	rt.StateTransaction(&st, func() {
+		rt.ValidateImmediateCallerIs(st.From, st.To)
+
+		lstates, err := adt.AsArray(adt.AsStore(rt), st.LaneStates, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load lanes")
+
+		laneState := findLane(rt, lstates, params.Lane)
+		if laneState == nil || laneState.Pending == nil {
+			rt.Abortf(exitcode.ErrNotFound, "no conditional voucher pending on lane %d", params.Lane)
+			return // makes linters happy
 		}
+		releaseExpiredConditionalVoucher(rt, &st, params.Lane, laneState)
 
-		err = lstates.Set(laneId, laneState)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane", laneId)
+		// The lane is retained even if it has redeemed nothing, so that its nonce continues to supersede earlier vouchers.
+		err = lstates.Set(params.Lane, laneState)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store lane %d", params.Lane)
 
 		st.LaneStates, err = lstates.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save lanes")
@@ -310,6 +568,74 @@ func (pca Actor) UpdateChannelState(rt runtime.Runtime, params *UpdateChannelSta
 	return nil
 }
 
+type ApproveVoucherParams struct {
+	Sv SignedVoucher
+}
+
+// Approves a voucher on behalf of a party without a key to sign vouchers, such as a multisig.
+// The approval authorises a single use of the voucher by the other party, in place of a signature.
+func (pca Actor) ApproveVoucher(rt runtime.Runtime, params *ApproveVoucherParams) *abi.EmptyValue {
+	var st State
+	rt.StateTransaction(&st, func() {
+		rt.ValidateImmediateCallerIs(st.From, st.To)
+		approver := rt.Caller()
+		if signer := st.SignerFor(approver); isAccount(rt, signer) {
+			rt.Abortf(exitcode.ErrForbidden, "%v must sign vouchers with key %v", approver, signer)
+		}
+
+		if st.SettlingAt != 0 && rt.CurrEpoch() >= st.SettlingAt {
+			rt.Abortf(ErrChannelStateUpdateAfterSettled, "no vouchers can be processed after SettlingAt epoch")
+		}
+
+		svpchIDAddr, found := rt.ResolveAddress(params.Sv.ChannelAddr)
+		if !found || svpchIDAddr != rt.Receiver() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "voucher payment channel address %s does not match receiver %s", params.Sv.ChannelAddr, rt.Receiver())
+		}
+
+		vb, err := params.Sv.SigningBytes()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "failed to serialize signedvoucher")
+
+		approvals, err := adt.AsSet(adt.AsStore(rt), st.ApprovedVouchers, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load voucher approvals")
+		err = approvals.Put(VoucherApprovalKey(approver, rt.HashBlake2b(vb)))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to store voucher approval")
+		st.ApprovedVouchers, err = approvals.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save voucher approvals")
+	})
+	return nil
+}
+
+type DelegateSignerParams struct {
+	// Account to sign vouchers on behalf of the caller, or the caller itself to approve vouchers on-chain.
+	Signer addr.Address
+}
+
+// Delegates the signing of vouchers on behalf of a party that is not an account, such as a multisig, to an account key.
+// Vouchers signed by a previous signer are no longer valid.
+func (pca Actor) DelegateSigner(rt runtime.Runtime, params *DelegateSignerParams) *abi.EmptyValue {
+	signer, err := pca.resolveSignable(rt, params.Signer)
+	builtin.RequireNoErr(rt, err, exitcode.Unwrap(err, exitcode.ErrIllegalState), "failed to resolve signer address: %s", params.Signer)
+
+	var st State
+	rt.StateTransaction(&st, func() {
+		rt.ValidateImmediateCallerIs(st.From, st.To)
+		party := rt.Caller()
+		if isAccount(rt, party) {
+			rt.Abortf(exitcode.ErrForbidden, "account %v cannot delegate voucher signing", party)
+		}
+		if signer != party && !isAccount(rt, signer) {
+			rt.Abortf(exitcode.ErrForbidden, "delegated signer %v must be an account", params.Signer)
+		}
+
+		if party == st.From {
+			st.FromSigner = signer
+		} else {
+			st.ToSigner = signer
+		}
+	})
+	return nil
+}
+
 func (pca Actor) Settle(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	var st State
 	rt.StateTransaction(&st, func() {
@@ -336,12 +662,12 @@ func (pca Actor) Collect(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue
 		rt.Abortf(exitcode.ErrForbidden, "payment channel not settling or settled")
 	}
 
-	// send ToSend to "To"
+	// send ToSend, less any amount already withdrawn, to "To"
 	codeTo := rt.Send(
 		st.To,
 		builtin.MethodSend,
 		nil,
-		st.ToSend,
+		st.AvailableToWithdraw(),
 		&builtin.Discard{},
 	)
 	builtin.RequireSuccess(rt, codeTo, "Failed to send funds to `To`")
@@ -352,6 +678,55 @@ func (pca Actor) Collect(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue
 	return nil
 }
 
+// Adds the value sent to the channel's funds, recording the deposit.
+func (pca Actor) AddFunds(rt runtime.Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
+	var st State
+	rt.StateTransaction(&st, func() {
+		rt.ValidateImmediateCallerIs(st.From)
+
+		if rt.ValueReceived().LessThanEqual(big.Zero()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "deposit must be positive, was %v", rt.ValueReceived())
+		}
+		if st.SettlingAt != 0 {
+			rt.Abortf(exitcode.ErrForbidden, "cannot add funds to settling channel")
+		}
+
+		deposits, err := adt.AsArray(adt.AsStore(rt), st.Deposits, DepositsAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load deposits")
+		err = deposits.AppendContinuous(&Deposit{Epoch: rt.CurrEpoch(), Amount: rt.ValueReceived()})
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record deposit")
+		st.Deposits, err = deposits.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to save deposits")
+	})
+	return nil
+}
+
+type WithdrawParams struct {
+	Amount abi.TokenAmount
+}
+
+// Pays out part of the redeemed amount to To, without settling the channel.
+func (pca Actor) Withdraw(rt runtime.Runtime, params *WithdrawParams) *abi.EmptyValue {
+	var st State
+	rt.StateTransaction(&st, func() {
+		rt.ValidateImmediateCallerIs(st.To)
+
+		if params.Amount.LessThanEqual(big.Zero()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "withdrawal amount must be positive, was %v", params.Amount)
+		}
+
+		available := st.AvailableToWithdraw()
+		if params.Amount.GreaterThan(available) {
+			rt.Abortf(exitcode.ErrInsufficientFunds, "withdrawal amount %v exceeds redeemed amount available %v", params.Amount, available)
+		}
+		st.Withdrawn = big.Add(st.Withdrawn, params.Amount)
+	})
+
+	code := rt.Send(st.To, builtin.MethodSend, nil, params.Amount, &builtin.Discard{})
+	builtin.RequireSuccess(rt, code, "failed to send funds to `To`")
+	return nil
+}
+
 // Returns the insertion index for a lane ID, with the matching lane state if found, or nil.
 func findLane(rt runtime.Runtime, ls *adt.Array, id uint64) *LaneState {
 	if id > MaxLane {
@@ -15,9 +15,17 @@ type State struct {
 	From addr.Address
 	// Recipient of payouts from channel
 	To addr.Address
+	// Account keys that sign vouchers on behalf of From and To.
+	// A signer that is not an account (i.e. a party without a delegated key) instead approves vouchers on-chain.
+	FromSigner addr.Address
+	ToSigner   addr.Address
 
-	// Amount successfully redeemed through the payment channel, paid out on `Collect()`
+	// Amount successfully redeemed through the payment channel, paid out on `Withdraw()` or `Collect()`
 	ToSend abi.TokenAmount
+	// Amount of ToSend already paid out to To by `Withdraw()`
+	Withdrawn abi.TokenAmount
+	// Amount reserved for conditional vouchers pending on lanes, paid out only if their secrets are revealed
+	Reserved abi.TokenAmount
 
 	// Height at which the channel can be `Collected`
 	SettlingAt abi.ChainEpoch
@@ -26,6 +34,13 @@ type State struct {
 
 	// Collections of lane states for the channel, maintained in ID order.
 	LaneStates cid.Cid // AMT<LaneState>
+
+	// Funds added to the channel by From at construction or with `AddFunds()`, in order.
+	// Funds sent to the channel by plain value transfers are not recorded.
+	Deposits cid.Cid // AMT<Deposit>
+
+	// Vouchers approved on-chain by a party without a signing key, keyed by approver and voucher digest.
+	ApprovedVouchers cid.Cid // HAMT[VoucherApprovalKey]struct{}
 }
 
 // The Lane state tracks the latest (highest) voucher nonce used to merge the lane
@@ -33,17 +48,72 @@ type State struct {
 type LaneState struct {
 	Redeemed big.Int
 	Nonce    uint64
+	// A conditional voucher locked on the lane, awaiting its secret. Nil if none.
+	Pending *ConditionalVoucher
+}
+
+// A voucher locked on a lane, to be redeemed only if the preimage of its secret hash is revealed by its expiry.
+// Until then the lane may not be updated or merged. Once expired, the amount reserved for it reverts to From.
+type ConditionalVoucher struct {
+	Amount     big.Int // Amount the lane will have redeemed once the secret is revealed.
+	SecretHash []byte
+	Expiry     abi.ChainEpoch
+}
+
+// Returns the amount reserved from the channel balance for a lane's pending conditional voucher.
+func (ls *LaneState) ReservedAmount() abi.TokenAmount {
+	if ls.Pending == nil {
+		return big.Zero()
+	}
+	return big.Sub(ls.Pending.Amount, ls.Redeemed)
+}
+
+// A record of funds added to the channel.
+type Deposit struct {
+	Epoch  abi.ChainEpoch
+	Amount abi.TokenAmount
 }
 
 const LaneStatesAmtBitwidth = 3
+const DepositsAmtBitwidth = 3
 
-func ConstructState(from addr.Address, to addr.Address, emptyArrCid cid.Cid) *State {
+func ConstructState(from, to, fromSigner, toSigner addr.Address, emptyLanesCid, emptyDepositsCid, emptyApprovalsCid cid.Cid) *State {
 	return &State{
-		From:            from,
-		To:              to,
-		ToSend:          big.Zero(),
-		SettlingAt:      0,
-		MinSettleHeight: 0,
-		LaneStates:      emptyArrCid,
+		From:             from,
+		To:               to,
+		FromSigner:       fromSigner,
+		ToSigner:         toSigner,
+		ToSend:           big.Zero(),
+		Withdrawn:        big.Zero(),
+		Reserved:         big.Zero(),
+		SettlingAt:       0,
+		MinSettleHeight:  0,
+		LaneStates:       emptyLanesCid,
+		Deposits:         emptyDepositsCid,
+		ApprovedVouchers: emptyApprovalsCid,
 	}
 }
+
+// Returns the key that signs vouchers on behalf of a channel party.
+func (st *State) SignerFor(party addr.Address) addr.Address {
+	if party == st.From {
+		return st.FromSigner
+	}
+	return st.ToSigner
+}
+
+// Key for a voucher approved on-chain by a channel party.
+func VoucherApprovalKey(approver addr.Address, digest [32]byte) abi.Keyer {
+	return StringKey(string(approver.Bytes()) + string(digest[:]))
+}
+
+type StringKey string
+
+func (k StringKey) Key() string {
+	return string(k)
+}
+
+// Returns the amount redeemed but not yet paid out to To.
+func (st *State) AvailableToWithdraw() abi.TokenAmount {
+	return big.Sub(st.ToSend, st.Withdrawn)
+}
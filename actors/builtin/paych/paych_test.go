@@ -1,6 +1,7 @@
 package paych_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -67,7 +68,7 @@ func TestPaymentChannelActor_Constructor(t *testing.T) {
 		actor.checkState(rt)
 	})
 
-	nonAccountCodeID := builtin.MultisigActorCodeID
+	nonSignableCodeID := builtin.StorageMinerActorCodeID
 	testCases := []struct {
 		desc        string
 		fromCode    cid.Cid
@@ -76,14 +77,14 @@ func TestPaymentChannelActor_Constructor(t *testing.T) {
 		toAddr      addr.Address
 		expExitCode exitcode.ExitCode
 	}{
-		{"fails if target (to) is not account actor",
+		{"fails if target (to) is not a signable actor",
 			builtin.AccountActorCodeID,
 			payerAddr,
-			nonAccountCodeID,
+			nonSignableCodeID,
 			payeeAddr,
 			exitcode.ErrForbidden,
-		}, {"fails if sender (from) is not account actor",
-			nonAccountCodeID,
+		}, {"fails if sender (from) is not a signable actor",
+			nonSignableCodeID,
 			payerAddr,
 			builtin.AccountActorCodeID,
 			payeeAddr,
@@ -137,6 +138,91 @@ func TestPaymentChannelActor_Constructor(t *testing.T) {
 		rt.Verify()
 	})
 
+	t.Run("creates a payment channel from params in the prior encoding", func(t *testing.T) {
+		rt := mock.NewBuilder(ctx, paychAddr).
+			WithCaller(callerAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.AccountActorCodeID).
+			WithActorType(payeeAddr, builtin.AccountActorCodeID).
+			Build(t)
+
+		// A tuple of the payer and payee addresses only.
+		buf := new(bytes.Buffer)
+		require.NoError(t, cbg.WriteMajorTypeHeader(buf, cbg.MajArray, 2))
+		require.NoError(t, payerAddr.MarshalCBOR(buf))
+		require.NoError(t, payeeAddr.MarshalCBOR(buf))
+		var params ConstructorParams
+		require.NoError(t, params.UnmarshalCBOR(buf))
+
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.Call(actor.Constructor, &params)
+		rt.Verify()
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, payerAddr, st.From)
+		assert.Equal(t, payeeAddr, st.To)
+		actor.checkState(rt)
+	})
+
+	t.Run("creates a payment channel for multisig parties", func(t *testing.T) {
+		delegate := tutil.NewIDAddr(t, 104)
+		rt := mock.NewBuilder(ctx, paychAddr).
+			WithCaller(callerAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.MultisigActorCodeID).
+			WithActorType(payeeAddr, builtin.MultisigActorCodeID).
+			WithActorType(delegate, builtin.AccountActorCodeID).
+			Build(t)
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.Call(actor.Constructor, &ConstructorParams{From: payerAddr, To: payeeAddr})
+		rt.Verify()
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, payerAddr, st.FromSigner)
+		assert.Equal(t, payeeAddr, st.ToSigner)
+
+		actor.delegateSigner(rt, payerAddr, builtin.MultisigActorCodeID, delegate)
+		rt.GetState(&st)
+		assert.Equal(t, delegate, st.FromSigner)
+		assert.Equal(t, payeeAddr, st.ToSigner)
+		actor.checkState(rt)
+
+		// The payer may revert to approving vouchers on-chain.
+		actor.delegateSigner(rt, payerAddr, builtin.MultisigActorCodeID, payerAddr)
+		rt.GetState(&st)
+		assert.Equal(t, payerAddr, st.FromSigner)
+		actor.checkState(rt)
+	})
+
+	t.Run("fails to delegate signing by an account or to a non-account", func(t *testing.T) {
+		msigAddr := tutil.NewIDAddr(t, 104)
+		rt := mock.NewBuilder(ctx, paychAddr).
+			WithCaller(callerAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.AccountActorCodeID).
+			WithActorType(payeeAddr, builtin.MultisigActorCodeID).
+			WithActorType(msigAddr, builtin.MultisigActorCodeID).
+			Build(t)
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.Call(actor.Constructor, &ConstructorParams{From: payerAddr, To: payeeAddr})
+		rt.Verify()
+
+		rt.SetCaller(payerAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "cannot delegate voucher signing", func() {
+			rt.Call(actor.DelegateSigner, &DelegateSignerParams{Signer: payerAddr})
+		})
+		rt.SetCaller(payeeAddr, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "must be an account", func() {
+			rt.Call(actor.DelegateSigner, &DelegateSignerParams{Signer: msigAddr})
+		})
+		rt.SetCaller(msigAddr, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.DelegateSigner, &DelegateSignerParams{Signer: payerAddr})
+		})
+	})
+
 	t.Run("fails if actor does not exist with: no code for address", func(t *testing.T) {
 		builder := mock.NewBuilder(ctx, paychAddr).
 			WithCaller(callerAddr, builtin.InitActorCodeID).
@@ -867,6 +953,531 @@ func TestActor_Collect(t *testing.T) {
 	}
 }
 
+func TestActor_AddFunds(t *testing.T) {
+	t.Run("records deposits in order", func(t *testing.T) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, context.Background(), 0)
+
+		rt.SetEpoch(10)
+		actor.addFunds(rt, abi.NewTokenAmount(100))
+		rt.SetEpoch(20)
+		actor.addFunds(rt, abi.NewTokenAmount(50))
+
+		assert.Equal(t, []Deposit{
+			{Epoch: 10, Amount: abi.NewTokenAmount(100)},
+			{Epoch: 20, Amount: abi.NewTokenAmount(50)},
+		}, actor.deposits(rt))
+		actor.checkState(rt)
+	})
+
+	t.Run("records value received at construction", func(t *testing.T) {
+		paychAddr := tutil.NewIDAddr(t, 100)
+		payerAddr := tutil.NewIDAddr(t, 101)
+		payeeAddr := tutil.NewIDAddr(t, 102)
+		rt := mock.NewBuilder(context.Background(), paychAddr).
+			WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.AccountActorCodeID).
+			WithActorType(payeeAddr, builtin.AccountActorCodeID).
+			WithBalance(abi.NewTokenAmount(500), abi.NewTokenAmount(500)).
+			WithEpoch(7).
+			Build(t)
+		actor := pcActorHarness{Actor{}, t, paychAddr, payerAddr, payeeAddr}
+		actor.constructAndVerify(t, rt, payerAddr, payeeAddr)
+
+		assert.Equal(t, []Deposit{{Epoch: 7, Amount: abi.NewTokenAmount(500)}}, actor.deposits(rt))
+		actor.checkState(rt)
+	})
+
+	t.Run("fails with zero value", func(t *testing.T) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, context.Background(), 0)
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "deposit must be positive", func() {
+			rt.Call(actor.AddFunds, nil)
+		})
+	})
+
+	t.Run("fails when not called by payer", func(t *testing.T) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, context.Background(), 0)
+		rt.SetCaller(actor.payee, builtin.AccountActorCodeID)
+		rt.SetReceived(abi.NewTokenAmount(100))
+		rt.ExpectValidateCallerAddr(actor.payer)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.AddFunds, nil)
+		})
+	})
+
+	t.Run("fails when settling", func(t *testing.T) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, context.Background(), 0)
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.Call(actor.Settle, nil)
+
+		rt.SetReceived(abi.NewTokenAmount(100))
+		rt.ExpectValidateCallerAddr(actor.payer)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "settling channel", func() {
+			rt.Call(actor.AddFunds, nil)
+		})
+	})
+}
+
+func TestActor_Withdraw(t *testing.T) {
+	setup := func(t *testing.T) (*mock.Runtime, *pcActorHarness, *SignedVoucher) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, context.Background(), 0)
+		sv := requireAddNewLane(t, rt, actor, laneParams{
+			epochNum: 2,
+			from:     actor.payer,
+			to:       actor.payee,
+			amt:      abi.NewTokenAmount(1000),
+			lane:     0,
+			nonce:    1,
+		})
+		return rt, actor, sv
+	}
+
+	t.Run("payee withdraws redeemed funds repeatedly", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+
+		actor.withdraw(rt, abi.NewTokenAmount(400))
+		actor.withdraw(rt, abi.NewTokenAmount(600))
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, abi.NewTokenAmount(1000), st.ToSend)
+		assert.Equal(t, abi.NewTokenAmount(1000), st.Withdrawn)
+		actor.checkState(rt)
+
+		// Further vouchers redeem more funds to withdraw.
+		sv.Amount = abi.NewTokenAmount(1500)
+		requireUpdateChannelState(t, rt, actor, sv)
+		actor.withdraw(rt, abi.NewTokenAmount(500))
+		actor.checkState(rt)
+	})
+
+	t.Run("fails to withdraw more than redeemed", func(t *testing.T) {
+		rt, actor, _ := setup(t)
+		actor.withdraw(rt, abi.NewTokenAmount(400))
+
+		rt.SetCaller(actor.payee, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payee)
+		rt.ExpectAbortContainsMessage(exitcode.ErrInsufficientFunds, "exceeds redeemed amount available 600", func() {
+			rt.Call(actor.Withdraw, &WithdrawParams{Amount: abi.NewTokenAmount(601)})
+		})
+		rt.ExpectValidateCallerAddr(actor.payee)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "must be positive", func() {
+			rt.Call(actor.Withdraw, &WithdrawParams{Amount: big.Zero()})
+		})
+	})
+
+	t.Run("fails when not called by payee", func(t *testing.T) {
+		rt, actor, _ := setup(t)
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payee)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(actor.Withdraw, &WithdrawParams{Amount: abi.NewTokenAmount(1)})
+		})
+	})
+
+	t.Run("voucher may not reduce redeemed amount below withdrawn", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+		actor.withdraw(rt, abi.NewTokenAmount(800))
+
+		// A voucher for a lower amount on the same lane reduces the redeemed amount.
+		sv.Amount = abi.NewTokenAmount(700)
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectVerifySignature(*sv.Signature, actor.payee, voucherBytes(t, sv), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "below withdrawn amount 800", func() {
+			rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *sv})
+		})
+		rt.Verify()
+
+		sv.Amount = abi.NewTokenAmount(800)
+		requireUpdateChannelState(t, rt, actor, sv)
+		actor.checkState(rt)
+	})
+
+	t.Run("voucher may not exceed balance plus withdrawn", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+		actor.withdraw(rt, abi.NewTokenAmount(1000))
+
+		// The remaining balance covers redemptions beyond those withdrawn.
+		sv.Amount = big.Add(abi.NewTokenAmount(1001), rt.Balance())
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectVerifySignature(*sv.Signature, actor.payee, voucherBytes(t, sv), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "not enough funds", func() {
+			rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *sv})
+		})
+		rt.Verify()
+
+		sv.Amount = big.Add(abi.NewTokenAmount(1000), rt.Balance())
+		requireUpdateChannelState(t, rt, actor, sv)
+		actor.checkState(rt)
+	})
+
+	t.Run("collect pays remainder not withdrawn", func(t *testing.T) {
+		rt, actor, _ := setup(t)
+		actor.withdraw(rt, abi.NewTokenAmount(400))
+
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.Call(actor.Settle, nil)
+
+		var st State
+		rt.GetState(&st)
+		rt.SetEpoch(st.SettlingAt)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectSend(actor.payee, builtin.MethodSend, nil, abi.NewTokenAmount(600), nil, exitcode.Ok)
+		rt.ExpectDeleteActor(actor.payer)
+		rt.Call(actor.Collect, nil)
+		rt.Verify()
+	})
+}
+
+func TestActor_ConditionalVoucher(t *testing.T) {
+	secret := []byte("secret")
+	// The test hasher pads its input, so the secret hash is recognisable.
+	hasher := func(data []byte) [32]byte {
+		var h [32]byte
+		copy(h[:], data)
+		return h
+	}
+	secretHash := hasher(secret)
+	expiry := abi.ChainEpoch(100)
+
+	setup := func(t *testing.T) (*mock.Runtime, *pcActorHarness, *SignedVoucher) {
+		rt, actor, _ := requireCreateChannelWithLanes(t, context.Background(), 0)
+		rt.SetHasher(hasher)
+		sv := requireAddNewLane(t, rt, actor, laneParams{
+			epochNum: 2,
+			from:     actor.payer,
+			to:       actor.payee,
+			amt:      abi.NewTokenAmount(1000),
+			lane:     0,
+			nonce:    1,
+		})
+		sv.Amount = abi.NewTokenAmount(1500)
+		sv.SecretPreimage = secretHash[:]
+		sv.TimeLockMax = expiry
+		return rt, actor, sv
+	}
+
+	t.Run("reveal redeems reserved funds", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+		actor.lockConditionalVoucher(rt, sv)
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, abi.NewTokenAmount(500), st.Reserved)
+		assert.Equal(t, abi.NewTokenAmount(1000), st.ToSend)
+		ls := getLaneState(t, rt, st.LaneStates, 0)
+		assert.Equal(t, sv.Nonce, ls.Nonce)
+		assert.Equal(t, abi.NewTokenAmount(1000), ls.Redeemed)
+		require.NotNil(t, ls.Pending)
+		actor.checkState(rt)
+
+		rt.SetEpoch(expiry)
+		actor.revealSecret(rt, 0, secret)
+
+		rt.GetState(&st)
+		assert.Equal(t, big.Zero(), st.Reserved)
+		assert.Equal(t, abi.NewTokenAmount(1500), st.ToSend)
+		ls = getLaneState(t, rt, st.LaneStates, 0)
+		assert.Equal(t, abi.NewTokenAmount(1500), ls.Redeemed)
+		assert.Nil(t, ls.Pending)
+		actor.checkState(rt)
+	})
+
+	t.Run("reveal fails with incorrect secret or after expiry", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+		actor.lockConditionalVoucher(rt, sv)
+
+		rt.SetCaller(actor.payee, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "incorrect secret", func() {
+			rt.Call(actor.RevealSecret, &RevealSecretParams{Lane: 0, Secret: []byte("guess")})
+		})
+		rt.Verify()
+
+		rt.SetEpoch(expiry + 1)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "expired", func() {
+			rt.Call(actor.RevealSecret, &RevealSecretParams{Lane: 0, Secret: secret})
+		})
+		rt.Verify()
+
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no conditional voucher pending on lane 1", func() {
+			rt.Call(actor.RevealSecret, &RevealSecretParams{Lane: 1, Secret: secret})
+		})
+	})
+
+	t.Run("settle then reveal before expiry", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+		lateExpiry := abi.ChainEpoch(2 + SettleDelay + 100)
+		sv.TimeLockMax = lateExpiry
+		actor.lockConditionalVoucher(rt, sv)
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, lateExpiry+1, st.MinSettleHeight)
+
+		// The payer settles before the expiry, but settlement waits for it.
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.Call(actor.Settle, nil)
+		rt.Verify()
+		rt.GetState(&st)
+		assert.Equal(t, lateExpiry+1, st.SettlingAt)
+
+		rt.SetEpoch(lateExpiry)
+		actor.revealSecret(rt, 0, secret)
+
+		rt.GetState(&st)
+		assert.Equal(t, big.Zero(), st.Reserved)
+		assert.Equal(t, abi.NewTokenAmount(1500), st.ToSend)
+		actor.checkState(rt)
+	})
+
+	t.Run("lock while settling delays settlement past expiry", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.Call(actor.Settle, nil)
+		rt.Verify()
+
+		lateExpiry := abi.ChainEpoch(2 + SettleDelay + 100)
+		sv.TimeLockMax = lateExpiry
+		actor.lockConditionalVoucher(rt, sv)
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, lateExpiry+1, st.SettlingAt)
+
+		rt.SetEpoch(lateExpiry)
+		actor.revealSecret(rt, 0, secret)
+		actor.checkState(rt)
+	})
+
+	t.Run("release after expiry returns reserved funds", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+		actor.lockConditionalVoucher(rt, sv)
+
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "pending until 100", func() {
+			rt.Call(actor.ReleaseConditionalVoucher, &ReleaseConditionalVoucherParams{Lane: 0})
+		})
+		rt.Verify()
+
+		rt.SetEpoch(expiry + 1)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.Call(actor.ReleaseConditionalVoucher, &ReleaseConditionalVoucherParams{Lane: 0})
+		rt.Verify()
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, big.Zero(), st.Reserved)
+		assert.Equal(t, abi.NewTokenAmount(1000), st.ToSend)
+		ls := getLaneState(t, rt, st.LaneStates, 0)
+		assert.Equal(t, sv.Nonce, ls.Nonce)
+		assert.Nil(t, ls.Pending)
+		actor.checkState(rt)
+
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no conditional voucher", func() {
+			rt.Call(actor.ReleaseConditionalVoucher, &ReleaseConditionalVoucherParams{Lane: 0})
+		})
+	})
+
+	t.Run("pending voucher blocks lane updates until expiry", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+		actor.lockConditionalVoucher(rt, sv)
+
+		update := &SignedVoucher{
+			ChannelAddr: actor.addr,
+			TimeLockMax: math.MaxInt64,
+			Lane:        0,
+			Nonce:       sv.Nonce + 1,
+			Amount:      abi.NewTokenAmount(1200),
+			Signature:   sv.Signature,
+		}
+		rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectVerifySignature(*update.Signature, actor.payee, voucherBytes(t, update), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "conditional voucher pending", func() {
+			rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *update})
+		})
+		rt.Verify()
+
+		// Merging the lane into another is likewise blocked.
+		merge := &SignedVoucher{
+			ChannelAddr: actor.addr,
+			TimeLockMax: math.MaxInt64,
+			Lane:        1,
+			Nonce:       1,
+			Amount:      abi.NewTokenAmount(1200),
+			Signature:   sv.Signature,
+			Merges:      []Merge{{Lane: 0, Nonce: sv.Nonce + 1}},
+		}
+		rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+		rt.ExpectVerifySignature(*merge.Signature, actor.payee, voucherBytes(t, merge), nil)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "conditional voucher pending", func() {
+			rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *merge})
+		})
+		rt.Verify()
+
+		// Once expired, an update releases the reservation.
+		rt.SetEpoch(expiry + 1)
+		requireUpdateChannelState(t, rt, actor, update)
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, big.Zero(), st.Reserved)
+		assert.Equal(t, abi.NewTokenAmount(1200), st.ToSend)
+		actor.checkState(rt)
+	})
+
+	t.Run("lock fails with invalid voucher", func(t *testing.T) {
+		rt, actor, sv := setup(t)
+
+		for _, tc := range []struct {
+			name   string
+			modify func(sv *SignedVoucher)
+			code   exitcode.ExitCode
+			msg    string
+		}{
+			{"no secret hash", func(sv *SignedVoucher) { sv.SecretPreimage = nil }, exitcode.ErrIllegalArgument, "must have a secret hash"},
+			{"no expiry", func(sv *SignedVoucher) { sv.TimeLockMax = 0 }, exitcode.ErrIllegalArgument, "must have an expiry"},
+			{"merges", func(sv *SignedVoucher) { sv.Merges = []Merge{{Lane: 1, Nonce: 1}} }, exitcode.ErrIllegalArgument, "cannot merge lanes"},
+			{"outdated nonce", func(sv *SignedVoucher) { sv.Nonce = 1 }, exitcode.ErrIllegalArgument, "outdated nonce"},
+			{"below redeemed", func(sv *SignedVoucher) { sv.Amount = abi.NewTokenAmount(999) }, exitcode.ErrIllegalArgument, "less than lane redeemed amount"},
+			{"insufficient funds", func(sv *SignedVoucher) { sv.Amount = big.Add(rt.Balance(), abi.NewTokenAmount(1)) }, exitcode.ErrIllegalArgument, "not enough funds"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				bad := *sv
+				tc.modify(&bad)
+				rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+				rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+				rt.ExpectVerifySignature(*bad.Signature, actor.payee, voucherBytes(t, &bad), nil)
+				rt.ExpectAbortContainsMessage(tc.code, tc.msg, func() {
+					rt.Call(actor.LockConditionalVoucher, &LockConditionalVoucherParams{Sv: bad})
+				})
+				rt.Verify()
+			})
+		}
+	})
+}
+
+func TestActor_MultisigParty(t *testing.T) {
+	paychAddr := tutil.NewIDAddr(t, 100)
+	payerAddr := tutil.NewIDAddr(t, 101)
+	payeeAddr := tutil.NewIDAddr(t, 102)
+	delegate := tutil.NewIDAddr(t, 103)
+	sig := &crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte{0, 1, 2, 3}}
+
+	setup := func(t *testing.T, fromSigner *addr.Address) (*mock.Runtime, *pcActorHarness) {
+		rt := mock.NewBuilder(context.Background(), paychAddr).
+			WithCaller(builtin.InitActorAddr, builtin.InitActorCodeID).
+			WithActorType(payerAddr, builtin.MultisigActorCodeID).
+			WithActorType(payeeAddr, builtin.AccountActorCodeID).
+			WithActorType(delegate, builtin.AccountActorCodeID).
+			WithBalance(abi.NewTokenAmount(1000), big.Zero()).
+			WithEpoch(2).
+			Build(t)
+		actor := pcActorHarness{Actor{}, t, paychAddr, payerAddr, payeeAddr}
+		rt.ExpectValidateCallerType(builtin.InitActorCodeID)
+		rt.Call(actor.Constructor, &ConstructorParams{From: payerAddr, To: payeeAddr})
+		rt.Verify()
+		if fromSigner != nil {
+			actor.delegateSigner(rt, payerAddr, builtin.MultisigActorCodeID, *fromSigner)
+		}
+		return rt, &actor
+	}
+	voucher := func(nonce uint64, amount int64) *SignedVoucher {
+		return &SignedVoucher{ChannelAddr: paychAddr, TimeLockMax: math.MaxInt64, Lane: 0, Nonce: nonce, Amount: abi.NewTokenAmount(amount)}
+	}
+	approvals := func(rt *mock.Runtime) uint64 {
+		var st State
+		rt.GetState(&st)
+		summary, msgs := CheckStateInvariants(&st, rt.AdtStore(), rt.Balance())
+		assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+		return summary.Approvals
+	}
+
+	t.Run("payee redeems voucher approved on-chain once", func(t *testing.T) {
+		rt, actor := setup(t, nil)
+		sv := voucher(1, 100)
+
+		rt.SetCaller(payerAddr, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.Call(actor.ApproveVoucher, &ApproveVoucherParams{Sv: *sv})
+		rt.Verify()
+		assert.Equal(t, uint64(1), approvals(rt))
+
+		rt.SetCaller(payeeAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *sv})
+		rt.Verify()
+
+		var st State
+		rt.GetState(&st)
+		assert.Equal(t, abi.NewTokenAmount(100), st.ToSend)
+		assert.Equal(t, uint64(0), approvals(rt))
+
+		// The approval is consumed.
+		sv = voucher(2, 200)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "voucher not approved", func() {
+			rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *sv})
+		})
+	})
+
+	t.Run("approval does not authorise a different voucher", func(t *testing.T) {
+		rt, actor := setup(t, nil)
+
+		rt.SetCaller(payerAddr, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.Call(actor.ApproveVoucher, &ApproveVoucherParams{Sv: *voucher(1, 100)})
+		rt.Verify()
+
+		rt.SetCaller(payeeAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "voucher not approved", func() {
+			rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *voucher(1, 1000)})
+		})
+	})
+
+	t.Run("account party may not approve vouchers", func(t *testing.T) {
+		rt, actor := setup(t, nil)
+		rt.SetCaller(payeeAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "must sign vouchers", func() {
+			rt.Call(actor.ApproveVoucher, &ApproveVoucherParams{Sv: *voucher(1, 100)})
+		})
+	})
+
+	t.Run("delegated signer signs vouchers", func(t *testing.T) {
+		rt, actor := setup(t, &delegate)
+		sv := voucher(1, 100)
+		sv.Signature = sig
+
+		rt.SetCaller(payeeAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.ExpectVerifySignature(*sig, delegate, voucherBytes(t, sv), nil)
+		rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *sv})
+		rt.Verify()
+
+		// With a delegated key, the multisig may not approve on-chain.
+		rt.SetCaller(payerAddr, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(payerAddr, payeeAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "must sign vouchers", func() {
+			rt.Call(actor.ApproveVoucher, &ApproveVoucherParams{Sv: *voucher(2, 200)})
+		})
+		actor.checkState(rt)
+	})
+}
+
 type pcActorHarness struct {
 	Actor
 	t testing.TB
@@ -955,6 +1566,71 @@ func (h *pcActorHarness) constructAndVerify(t *testing.T, rt *mock.Runtime, send
 	verifyInitialState(t, rt, senderId, receiverId)
 }
 
+func (h *pcActorHarness) addFunds(rt *mock.Runtime, amount abi.TokenAmount) {
+	rt.SetCaller(h.payer, builtin.AccountActorCodeID)
+	rt.SetReceived(amount)
+	rt.SetBalance(big.Add(rt.Balance(), amount))
+	rt.ExpectValidateCallerAddr(h.payer)
+	rt.Call(h.AddFunds, nil)
+	rt.Verify()
+	rt.SetReceived(big.Zero())
+}
+
+func (h *pcActorHarness) withdraw(rt *mock.Runtime, amount abi.TokenAmount) {
+	rt.SetCaller(h.payee, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(h.payee)
+	rt.ExpectSend(h.payee, builtin.MethodSend, nil, amount, nil, exitcode.Ok)
+	rt.Call(h.Withdraw, &WithdrawParams{Amount: amount})
+	rt.Verify()
+}
+
+func (h *pcActorHarness) deposits(rt *mock.Runtime) []Deposit {
+	var st State
+	rt.GetState(&st)
+	arr, err := adt.AsArray(adt.AsStore(rt), st.Deposits, DepositsAmtBitwidth)
+	require.NoError(h.t, err)
+	var deposits []Deposit
+	var deposit Deposit
+	require.NoError(h.t, arr.ForEach(&deposit, func(int64) error {
+		deposits = append(deposits, deposit)
+		return nil
+	}))
+	return deposits
+}
+
+func (h *pcActorHarness) delegateSigner(rt *mock.Runtime, party addr.Address, partyCode cid.Cid, signer addr.Address) {
+	rt.SetCaller(party, partyCode)
+	rt.ExpectValidateCallerAddr(h.payer, h.payee)
+	rt.Call(h.DelegateSigner, &DelegateSignerParams{Signer: signer})
+	rt.Verify()
+}
+
+func (h *pcActorHarness) lockConditionalVoucher(rt *mock.Runtime, sv *SignedVoucher) {
+	rt.SetCaller(h.payer, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(h.payer, h.payee)
+	vb, err := sv.SigningBytes()
+	require.NoError(h.t, err)
+	rt.ExpectVerifySignature(*sv.Signature, h.payee, vb, nil)
+	rt.Call(h.LockConditionalVoucher, &LockConditionalVoucherParams{Sv: *sv})
+	rt.Verify()
+}
+
+func (h *pcActorHarness) revealSecret(rt *mock.Runtime, lane uint64, secret []byte) {
+	rt.SetCaller(h.payee, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(h.payer, h.payee)
+	rt.Call(h.RevealSecret, &RevealSecretParams{Lane: lane, Secret: secret})
+	rt.Verify()
+}
+
+func requireUpdateChannelState(t *testing.T, rt *mock.Runtime, actor *pcActorHarness, sv *SignedVoucher) {
+	sv.Nonce++
+	rt.SetCaller(actor.payer, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(actor.payer, actor.payee)
+	rt.ExpectVerifySignature(*sv.Signature, actor.payee, voucherBytes(t, sv), nil)
+	rt.Call(actor.UpdateChannelState, &UpdateChannelStateParams{Sv: *sv})
+	rt.Verify()
+}
+
 func (h *pcActorHarness) checkState(rt *mock.Runtime) {
 	var st State
 	rt.GetState(&st)
@@ -9,19 +9,28 @@ import (
 )
 
 type StateSummary struct {
-	Redeemed abi.TokenAmount
+	Redeemed  abi.TokenAmount
+	Withdrawn abi.TokenAmount
+	Reserved  abi.TokenAmount
+	Deposited abi.TokenAmount
+	Approvals uint64
 }
 
 // Checks internal invariants of paych state.
 func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (*StateSummary, *builtin.MessageAccumulator) {
 	acc := &builtin.MessageAccumulator{}
 	paychSummary := &StateSummary{
-		Redeemed: big.Zero(),
+		Redeemed:  big.Zero(),
+		Withdrawn: st.Withdrawn,
+		Reserved:  big.Zero(),
+		Deposited: big.Zero(),
 	}
 
 	acc.Require(st.From.Protocol() == address.ID, "from address is not ID address %v", st.From)
 	acc.Require(st.To.Protocol() == address.ID, "to address is not ID address %v", st.To)
-	acc.Require(st.SettlingAt >= st.MinSettleHeight,
+	acc.Require(st.FromSigner.Protocol() == address.ID, "from signer address is not ID address %v", st.FromSigner)
+	acc.Require(st.ToSigner.Protocol() == address.ID, "to signer address is not ID address %v", st.ToSigner)
+	acc.Require(st.SettlingAt == 0 || st.SettlingAt >= st.MinSettleHeight,
 		"channel is setting at epoch %d before min settle height %d", st.SettlingAt, st.MinSettleHeight)
 
 	if lanes, err := adt.AsArray(store, st.LaneStates, LaneStatesAmtBitwidth); err != nil {
@@ -29,15 +38,64 @@ func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (
 	} else {
 		var lane LaneState
 		err = lanes.ForEach(&lane, func(i int64) error {
-			acc.Require(lane.Redeemed.GreaterThan(big.Zero()), "land %d redeemed is not greater than zero %v", i, lane.Redeemed)
+			// A lane created by a conditional voucher redeems nothing until its secret is revealed,
+			// and continues to redeem nothing should the voucher expire.
+			acc.Require(lane.Redeemed.GreaterThanEqual(big.Zero()), "lane %d redeemed is negative %v", i, lane.Redeemed)
+			if lane.Pending != nil {
+				acc.Require(lane.Pending.Amount.GreaterThanEqual(lane.Redeemed), "lane %d conditional voucher amount %v less than redeemed %v",
+					i, lane.Pending.Amount, lane.Redeemed)
+				acc.Require(len(lane.Pending.SecretHash) > 0, "lane %d conditional voucher has no secret hash", i)
+				acc.Require(lane.Pending.Expiry < st.MinSettleHeight, "lane %d conditional voucher expiry %d not before min settle height %d",
+					i, lane.Pending.Expiry, st.MinSettleHeight)
+				paychSummary.Reserved = big.Add(paychSummary.Reserved, lane.ReservedAmount())
+			}
 			paychSummary.Redeemed = big.Add(paychSummary.Redeemed, lane.Redeemed)
 			return nil
 		})
 		acc.RequireNoError(err, "error iterating lanes")
 	}
 
-	acc.Require(balance.GreaterThanEqual(st.ToSend),
-		"channel has insufficient funds to send (%v < %v)", balance, st.ToSend)
+	if deposits, err := adt.AsArray(store, st.Deposits, DepositsAmtBitwidth); err != nil {
+		acc.Addf("error loading deposits: %v", err)
+	} else {
+		prevEpoch := abi.ChainEpoch(-1)
+		var deposit Deposit
+		err = deposits.ForEach(&deposit, func(i int64) error {
+			acc.Require(deposit.Amount.GreaterThan(big.Zero()), "deposit %d amount is not greater than zero %v", i, deposit.Amount)
+			acc.Require(deposit.Epoch >= prevEpoch, "deposit %d at epoch %d precedes prior deposit at %d", i, deposit.Epoch, prevEpoch)
+			prevEpoch = deposit.Epoch
+			paychSummary.Deposited = big.Add(paychSummary.Deposited, deposit.Amount)
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating deposits")
+	}
+
+	if approvals, err := adt.AsSet(store, st.ApprovedVouchers, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading voucher approvals: %v", err)
+	} else {
+		err = approvals.ForEach(func(k string) error {
+			paychSummary.Approvals++
+			if len(k) <= 32 {
+				acc.Addf("voucher approval key %x too short", k)
+				return nil
+			}
+			approver, err := address.NewFromBytes([]byte(k[:len(k)-32]))
+			if err != nil {
+				acc.Addf("voucher approval key has invalid approver: %v", err)
+				return nil
+			}
+			acc.Require(approver == st.From || approver == st.To, "voucher approved by %v, not a channel party", approver)
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating voucher approvals")
+	}
+
+	acc.Require(st.Withdrawn.GreaterThanEqual(big.Zero()), "withdrawn amount %v is negative", st.Withdrawn)
+	acc.Require(st.Withdrawn.LessThanEqual(st.ToSend), "withdrawn amount %v exceeds redeemed amount %v", st.Withdrawn, st.ToSend)
+	acc.Require(st.Reserved.Equals(paychSummary.Reserved), "reserved amount %v does not match conditional vouchers %v",
+		st.Reserved, paychSummary.Reserved)
+	acc.Require(balance.GreaterThanEqual(big.Add(st.AvailableToWithdraw(), st.Reserved)),
+		"channel has insufficient funds to send (%v < %v + %v)", balance, st.AvailableToWithdraw(), st.Reserved)
 
 	return paychSummary, acc
 }
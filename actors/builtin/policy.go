@@ -0,0 +1,176 @@
+package builtin
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"golang.org/x/xerrors"
+)
+
+// The tunable parameters that differ between networks, applied together to the policy variables of the actor
+// packages.
+// Changing the number of deadlines changes the encoding of miner state, so the deadline count is part of the
+// bundle only so that it is checked against the count the actors are built with.
+type NetworkPolicy struct {
+	Name string
+
+	// Power: the number of miners which must reach the consensus minimum power before it is enforced, and the
+	// minimum power for each Window PoSt proof type.
+	ConsensusMinerMinMiners int64
+	ConsensusMinerMinPower  map[abi.RegisteredPoStProof]abi.StoragePower
+
+	// Miner: Window PoSt deadlines and challenge windows, which together make up the proving period.
+	WPoStPeriodDeadlines uint64
+	WPoStChallengeWindow abi.ChainEpoch
+	// Epochs after a challenge window closes during which its proofs may be disputed.
+	WPoStDisputeWindow abi.ChainEpoch
+	// Epochs between a sector pre-commitment and drawing its interactive PoRep challenge.
+	PreCommitChallengeDelay abi.ChainEpoch
+
+	// Market: bounds on deal duration.
+	DealMinDuration abi.ChainEpoch
+	DealMaxDuration abi.ChainEpoch
+}
+
+// The length of a proving period, in which every deadline's challenge window opens once.
+func (p *NetworkPolicy) WPoStProvingPeriod() abi.ChainEpoch {
+	return abi.ChainEpoch(p.WPoStPeriodDeadlines) * p.WPoStChallengeWindow
+}
+
+// The policy of the Filecoin mainnet.
+func MainnetPolicy() NetworkPolicy {
+	return NetworkPolicy{
+		Name:                    "mainnet",
+		ConsensusMinerMinMiners: 4,
+		ConsensusMinerMinPower: map[abi.RegisteredPoStProof]abi.StoragePower{
+			abi.RegisteredPoStProof_StackedDrgWindow2KiBV1:   abi.NewStoragePower(0),
+			abi.RegisteredPoStProof_StackedDrgWindow8MiBV1:   abi.NewStoragePower(16 << 20),
+			abi.RegisteredPoStProof_StackedDrgWindow512MiBV1: abi.NewStoragePower(1 << 30),
+			abi.RegisteredPoStProof_StackedDrgWindow32GiBV1:  abi.NewStoragePower(10 << 40),
+			abi.RegisteredPoStProof_StackedDrgWindow64GiBV1:  abi.NewStoragePower(20 << 40),
+		},
+		WPoStPeriodDeadlines:    48,
+		WPoStChallengeWindow:    30 * 60 / EpochDurationSeconds, // 30 minutes
+		WPoStDisputeWindow:      2 * 900,                        // twice finality
+		PreCommitChallengeDelay: 150,
+		DealMinDuration:         180 * EpochsInDay,
+		DealMaxDuration:         540 * EpochsInDay,
+	}
+}
+
+// The policy of the calibration network, which differs from mainnet in a consensus minimum small enough
+// to be reached with a few sectors of any size.
+func CalibrationPolicy() NetworkPolicy {
+	p := MainnetPolicy()
+	p.Name = "calibration"
+	for proof := range p.ConsensusMinerMinPower {
+		p.ConsensusMinerMinPower[proof] = abi.NewStoragePower(32 << 30)
+	}
+	return p
+}
+
+// The policy of a development network of 2KiB sectors, with short proving periods and challenge delays.
+func Devnet2KiBPolicy() NetworkPolicy {
+	p := MainnetPolicy()
+	p.Name = "devnet-2k"
+	for proof := range p.ConsensusMinerMinPower {
+		p.ConsensusMinerMinPower[proof] = abi.NewStoragePower(2 << 10)
+	}
+	// The shortest challenge window for which a proving period still fits the dispute window and the
+	// deadline immutability and compaction windows.
+	p.WPoStChallengeWindow = 40
+	p.PreCommitChallengeDelay = 10
+	p.DealMinDuration = EpochsInDay
+	return p
+}
+
+func (p *NetworkPolicy) clone() NetworkPolicy {
+	c := *p
+	c.ConsensusMinerMinPower = make(map[abi.RegisteredPoStProof]abi.StoragePower, len(p.ConsensusMinerMinPower))
+	for proof, minPower := range p.ConsensusMinerMinPower {
+		c.ConsensusMinerMinPower[proof] = minPower
+	}
+	return c
+}
+
+// The network version from which these actors, and so the presets of their policy, are in effect.
+const NetworkPolicyFirstVersion = network.Version10
+
+// Returns the preset policy with a name.
+func LookupNetworkPolicy(name string) (NetworkPolicy, bool) {
+	for _, preset := range []func() NetworkPolicy{MainnetPolicy, CalibrationPolicy, Devnet2KiBPolicy} {
+		if p := preset(); p.Name == name {
+			return p, true
+		}
+	}
+	return NetworkPolicy{}, false
+}
+
+// Returns the preset policy of the named network in effect at a network version.
+// The presets do not change across the versions these actors run at, and there is none for a version before
+// these actors, which runs earlier actors with their own policy.
+func LookupNetworkPolicyAtVersion(name string, nv network.Version) (NetworkPolicy, bool) {
+	if nv < NetworkPolicyFirstVersion {
+		return NetworkPolicy{}, false
+	}
+	return LookupNetworkPolicy(name)
+}
+
+// Checks that a policy is internally consistent, and compatible with each actor package.
+func (p *NetworkPolicy) Validate() error {
+	if p.ConsensusMinerMinMiners < 0 {
+		return xerrors.Errorf("negative consensus minimum miners %d", p.ConsensusMinerMinMiners)
+	}
+	for proof := range PoStProofPolicies {
+		minPower, ok := p.ConsensusMinerMinPower[proof]
+		if !ok {
+			return xerrors.Errorf("no consensus minimum power for proof type %d", proof)
+		}
+		if minPower.Nil() || minPower.Sign() < 0 {
+			return xerrors.Errorf("invalid consensus minimum power %v for proof type %d", minPower, proof)
+		}
+	}
+	for _, applier := range policyAppliers {
+		if err := applier.validate(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A package's check of a policy's compatibility, and the function setting its policy variables from it.
+type policyApplier struct {
+	validate func(p *NetworkPolicy) error
+	apply    func(p *NetworkPolicy)
+}
+
+var policyAppliers []policyApplier
+var activePolicy = MainnetPolicy()
+
+// Registers an actor package's check and application of a network policy, and applies the active policy.
+// This is intended to be called from the package's init function.
+func RegisterPolicyApplier(validate func(p *NetworkPolicy) error, apply func(p *NetworkPolicy)) {
+	policyAppliers = append(policyAppliers, policyApplier{validate, apply})
+	apply(&activePolicy)
+}
+
+// Returns a copy of the policy in effect.
+func ActiveNetworkPolicy() NetworkPolicy {
+	return activePolicy.clone()
+}
+
+// Validates a policy and applies it to the policy variables of all actor packages.
+// Like setting those variables directly, this must happen before any actor code is executed, and is not safe
+// for concurrent use.
+func SetNetworkPolicy(p NetworkPolicy) error {
+	if err := p.Validate(); err != nil {
+		return xerrors.Errorf("invalid policy %s: %w", p.Name, err)
+	}
+	activePolicy = p.clone()
+	for proof, info := range PoStProofPolicies {
+		info.ConsensusMinerMinPower = p.ConsensusMinerMinPower[proof]
+	}
+	for _, applier := range policyAppliers {
+		applier.apply(&activePolicy)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package builtin_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	t.Run("mainnet policy matches defaults", func(t *testing.T) {
+		p := builtin.ActiveNetworkPolicy()
+		assert.Equal(t, builtin.MainnetPolicy(), p)
+		assert.Equal(t, miner.WPoStProvingPeriod, p.WPoStProvingPeriod())
+		assert.Equal(t, miner.WPoStChallengeWindow, p.WPoStChallengeWindow)
+		assert.Equal(t, miner.WPoStDisputeWindow, p.WPoStDisputeWindow)
+		assert.Equal(t, miner.PreCommitChallengeDelay, p.PreCommitChallengeDelay)
+		assert.Equal(t, power.ConsensusMinerMinMiners, p.ConsensusMinerMinMiners)
+		assert.Equal(t, market.DealMinDuration, p.DealMinDuration)
+		assert.Equal(t, market.DealMaxDuration, p.DealMaxDuration)
+		for proof, minPower := range p.ConsensusMinerMinPower {
+			actual, err := builtin.ConsensusMinerMinPower(proof)
+			require.NoError(t, err)
+			assert.Equal(t, minPower, actual)
+		}
+	})
+
+	t.Run("presets are valid and found by name", func(t *testing.T) {
+		for _, name := range []string{"mainnet", "calibration", "devnet-2k"} {
+			p, ok := builtin.LookupNetworkPolicy(name)
+			require.True(t, ok, name)
+			assert.Equal(t, name, p.Name)
+			assert.NoError(t, p.Validate(), name)
+		}
+		_, ok := builtin.LookupNetworkPolicy("nonesuch")
+		assert.False(t, ok)
+	})
+
+	t.Run("presets are found by network version from the first version of these actors", func(t *testing.T) {
+		for _, nv := range []network.Version{network.Version10, network.Version11, network.VersionMax} {
+			p, ok := builtin.LookupNetworkPolicyAtVersion("calibration", nv)
+			require.True(t, ok, nv)
+			assert.Equal(t, builtin.CalibrationPolicy(), p)
+		}
+		_, ok := builtin.LookupNetworkPolicyAtVersion("calibration", network.Version9)
+		assert.False(t, ok)
+		_, ok = builtin.LookupNetworkPolicyAtVersion("nonesuch", network.Version10)
+		assert.False(t, ok)
+	})
+
+	t.Run("set policy applies to all packages", func(t *testing.T) {
+		defer func() {
+			require.NoError(t, builtin.SetNetworkPolicy(builtin.MainnetPolicy()))
+		}()
+
+		devnet := builtin.Devnet2KiBPolicy()
+		require.NoError(t, builtin.SetNetworkPolicy(devnet))
+		assert.Equal(t, devnet, builtin.ActiveNetworkPolicy())
+
+		assert.Equal(t, abi.ChainEpoch(40), miner.WPoStChallengeWindow)
+		assert.Equal(t, abi.ChainEpoch(48*40), miner.WPoStProvingPeriod)
+		assert.Equal(t, 14*miner.WPoStProvingPeriod, miner.FaultMaxAge)
+		assert.Equal(t, abi.ChainEpoch(10), miner.PreCommitChallengeDelay)
+		assert.Equal(t, builtin.EpochsInDay+abi.ChainEpoch(10), miner.MaxProveCommitDuration[abi.RegisteredSealProof_StackedDrg2KiBV1_1])
+		assert.Equal(t, abi.ChainEpoch(builtin.EpochsInDay), market.DealMinDuration)
+		minPower, err := builtin.ConsensusMinerMinPower(abi.RegisteredPoStProof_StackedDrgWindow32GiBV1)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewStoragePower(2048), minPower)
+
+		// Changes to the policy after it is set have no effect.
+		devnet.ConsensusMinerMinPower[abi.RegisteredPoStProof_StackedDrgWindow32GiBV1] = abi.NewStoragePower(1)
+		assert.Equal(t, abi.NewStoragePower(2048), builtin.ActiveNetworkPolicy().ConsensusMinerMinPower[abi.RegisteredPoStProof_StackedDrgWindow32GiBV1])
+	})
+
+	t.Run("invalid policy is rejected", func(t *testing.T) {
+		for name, modify := range map[string]func(p *builtin.NetworkPolicy){
+			"deadline count": func(p *builtin.NetworkPolicy) { p.WPoStPeriodDeadlines = 24 },
+			"dispute window": func(p *builtin.NetworkPolicy) { p.WPoStDisputeWindow = miner.ChainFinality },
+			"proving period": func(p *builtin.NetworkPolicy) { p.WPoStChallengeWindow = 30 },
+			"deal durations": func(p *builtin.NetworkPolicy) { p.DealMaxDuration = p.DealMinDuration - 1 },
+			"min miners":     func(p *builtin.NetworkPolicy) { p.ConsensusMinerMinMiners = -1 },
+			"missing min power": func(p *builtin.NetworkPolicy) {
+				delete(p.ConsensusMinerMinPower, abi.RegisteredPoStProof_StackedDrgWindow2KiBV1)
+			},
+		} { // nolint:nomaprange
+			p := builtin.CalibrationPolicy()
+			modify(&p)
+			assert.Error(t, builtin.SetNetworkPolicy(p), name)
+			assert.Equal(t, builtin.MainnetPolicy(), builtin.ActiveNetworkPolicy(), name)
+		}
+	})
+}
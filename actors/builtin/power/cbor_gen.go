@@ -832,6 +832,90 @@ func (t *CurrentTotalPowerReturn) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
+var lengthBufMinerPowerReturn = []byte{131}
+
+func (t *MinerPowerReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufMinerPowerReturn); err != nil {
+		return err
+	}
+
+	// t.Claim (power.Claim) (struct)
+	if err := t.Claim.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.MeetsConsensusMinimum (bool) (bool)
+	if err := cbg.WriteBool(w, t.MeetsConsensusMinimum); err != nil {
+		return err
+	}
+
+	// t.QualityAdjPowerShare (big.Int) (struct)
+	if err := t.QualityAdjPowerShare.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *MinerPowerReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = MinerPowerReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Claim (power.Claim) (struct)
+
+	{
+
+		if err := t.Claim.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Claim: %w", err)
+		}
+
+	}
+	// t.MeetsConsensusMinimum (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.MeetsConsensusMinimum = false
+	case 21:
+		t.MeetsConsensusMinimum = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	// t.QualityAdjPowerShare (big.Int) (struct)
+
+	{
+
+		if err := t.QualityAdjPowerShare.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.QualityAdjPowerShare: %w", err)
+		}
+
+	}
+	return nil
+}
+
 var lengthBufMinerConstructorParams = []byte{134}
 
 func (t *MinerConstructorParams) MarshalCBOR(w io.Writer) error {
@@ -0,0 +1,56 @@
+package power
+
+import (
+	"sort"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// Loads the cron events enrolled by each miner, in order of epoch.
+// Events enrolled by a miner for the same epoch are in order of enrollment.
+// This iterates the whole queue, so should not be used in actor code.
+func (st *State) LoadCronEventsByMiner(s adt.Store) (CronEventsByAddress, error) {
+	queue, err := adt.AsMultimap(s, st.CronEventQueue, CronQueueHamtBitwidth, CronQueueAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load cron events: %w", err)
+	}
+
+	byMiner := make(CronEventsByAddress)
+	if err = queue.ForAll(func(key string, arr *adt.Array) error {
+		epoch, err := abi.ParseIntKey(key)
+		if err != nil {
+			return xerrors.Errorf("failed to parse cron epoch key %v: %w", key, err)
+		}
+		var event CronEvent
+		return arr.ForEach(&event, func(_ int64) error {
+			byMiner[event.MinerAddr] = append(byMiner[event.MinerAddr], MinerCronEvent{
+				Epoch:   abi.ChainEpoch(epoch),
+				Payload: event.CallbackPayload,
+			})
+			return nil
+		})
+	}); err != nil {
+		return nil, xerrors.Errorf("failed to iterate cron events: %w", err)
+	}
+
+	for _, events := range byMiner { // nolint:nomaprange
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Epoch < events[j].Epoch
+		})
+	}
+	return byMiner, nil
+}
+
+// Loads the cron events enrolled by a miner, in order of epoch.
+// This iterates the whole queue, so should not be used in actor code.
+func (st *State) LoadMinerCronEvents(s adt.Store, miner addr.Address) ([]MinerCronEvent, error) {
+	byMiner, err := st.LoadCronEventsByMiner(s)
+	if err != nil {
+		return nil, err
+	}
+	return byMiner[miner], nil
+}
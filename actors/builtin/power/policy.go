@@ -1,9 +1,16 @@
 package power
 
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+)
+
 // The number of miners that must meet the consensus minimum miner power before that minimum power is enforced
 // as a condition of leader election.
 // This ensures a network still functions before any miners reach that threshold.
-const ConsensusMinerMinMiners = 4 // PARAM_SPEC
+// Set by the network policy.
+var ConsensusMinerMinMiners = int64(4) // PARAM_SPEC
 
 // Maximum number of prove-commits each miner can submit in one epoch.
 //
@@ -11,3 +18,13 @@ const ConsensusMinerMinMiners = 4 // PARAM_SPEC
 // Onboarding 1EiB/year requires at least 32 prove-commits per epoch.
 const MaxMinerProveCommitsPerEpoch = 200 // PARAM_SPEC
 
+func init() {
+	builtin.RegisterPolicyApplier(func(p *builtin.NetworkPolicy) error {
+		if p.ConsensusMinerMinMiners < 0 {
+			return xerrors.Errorf("negative consensus minimum miners %d", p.ConsensusMinerMinMiners)
+		}
+		return nil
+	}, func(p *builtin.NetworkPolicy) {
+		ConsensusMinerMinMiners = p.ConsensusMinerMinMiners
+	})
+}
@@ -41,6 +41,7 @@ func (a Actor) Exports() []interface{} {
 		7:                         nil, // deprecated
 		8:                         a.SubmitPoRepForBulkVerify,
 		9:                         a.CurrentTotalPower,
+		10:                        a.MinerPower,
 	}
 }
 
@@ -321,6 +322,42 @@ func (a Actor) CurrentTotalPower(rt Runtime, _ *abi.EmptyValue) *CurrentTotalPow
 	}
 }
 
+type MinerPowerReturn struct {
+	Claim Claim
+	// Whether the miner's power is sufficient for it to win blocks.
+	MeetsConsensusMinimum bool
+	// The miner's share of the total quality-adjusted power counting towards consensus, in Q.128 format.
+	// Zero if the miner does not meet the consensus minimum.
+	QualityAdjPowerShare big.Int
+}
+
+// Returns a miner's claimed power, and its standing in consensus.
+// Unlike CurrentTotalPower, the values reflect all power changes made so far in this epoch.
+func (a Actor) MinerPower(rt Runtime, minerAddr *addr.Address) *MinerPowerReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	miner, ok := rt.ResolveAddress(*minerAddr)
+	if !ok {
+		rt.Abortf(exitcode.ErrNotFound, "failed to resolve miner address %v", minerAddr)
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+	claim, found, err := st.GetClaim(adt.AsStore(rt), miner)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get claim for %v", miner)
+	if !found {
+		rt.Abortf(exitcode.ErrNotFound, "no claim for miner %v", miner)
+	}
+	eligible, err := st.claimMeetsConsensusMinimum(claim)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check consensus minimum for %v", miner)
+
+	_, qaTotal := CurrentTotalPower(&st)
+	return &MinerPowerReturn{
+		Claim:                 *claim,
+		MeetsConsensusMinimum: eligible,
+		QualityAdjPowerShare:  qualityAdjPowerShare(claim.QualityAdjPower, qaTotal, eligible),
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // Method utility functions
 ////////////////////////////////////////////////////////////////////////////////
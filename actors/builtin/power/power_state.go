@@ -1,6 +1,7 @@
 package power
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 
@@ -130,6 +131,12 @@ func (st *State) MinerNominalPowerMeetsConsensusMinimum(s adt.Store, miner addr.
 		return false, errors.Errorf("no claim for actor %v", miner)
 	}
 
+	return st.claimMeetsConsensusMinimum(claim)
+}
+
+// Checks whether a miner's claim is sufficient for it to win blocks, given the number of miners above the
+// minimum power.
+func (st *State) claimMeetsConsensusMinimum(claim *Claim) (bool, error) {
 	minerNominalPower := claim.RawBytePower
 	minerMinPower, err := builtin.ConsensusMinerMinPower(claim.WindowPoStProofType)
 	if err != nil {
@@ -287,6 +294,16 @@ func (st *State) appendCronEvent(events *adt.Multimap, epoch abi.ChainEpoch, eve
 		st.FirstCronEpoch = epoch
 	}
 
+	// An event identical to one already enrolled would only repeat its callback, so is dropped.
+	// This makes it safe for a miner to re-enroll an event it suspects is lost.
+	found, err := hasCronEvent(events, epoch, event)
+	if err != nil {
+		return err
+	}
+	if found {
+		return nil
+	}
+
 	if err := events.Add(epochKey(epoch), event); err != nil {
 		return xerrors.Errorf("failed to store cron event at epoch %v for miner %v: %w", epoch, event, err)
 	}
@@ -294,6 +311,20 @@ func (st *State) appendCronEvent(events *adt.Multimap, epoch abi.ChainEpoch, eve
 	return nil
 }
 
+func hasCronEvent(events *adt.Multimap, epoch abi.ChainEpoch, event *CronEvent) (bool, error) {
+	found := false
+	var existing CronEvent
+	if err := events.ForEach(epochKey(epoch), &existing, func(_ int64) error {
+		if existing.MinerAddr == event.MinerAddr && bytes.Equal(existing.CallbackPayload, event.CallbackPayload) {
+			found = true
+		}
+		return nil
+	}); err != nil {
+		return false, xerrors.Errorf("failed to iterate cron events at epoch %v: %w", epoch, err)
+	}
+	return found, nil
+}
+
 func (st *State) updateSmoothedEstimate(delta abi.ChainEpoch) {
 	filterQAPower := smoothing.LoadFilter(st.ThisEpochQAPowerSmoothed, smoothing.DefaultAlpha, smoothing.DefaultBeta)
 	st.ThisEpochQAPowerSmoothed = filterQAPower.NextEstimate(st.ThisEpochQualityAdjPower, delta)
@@ -0,0 +1,86 @@
+package power
+
+import (
+	"bytes"
+	"sort"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/math"
+)
+
+// A miner eligible to win blocks, and its power.
+type PowerTableEntry struct {
+	Miner           addr.Address
+	RawBytePower    abi.StoragePower
+	QualityAdjPower abi.StoragePower
+}
+
+// A snapshot of the miners eligible to win blocks.
+type PowerTable struct {
+	// Total power counting towards consensus, as returned by CurrentTotalPower.
+	TotalRawBytePower    abi.StoragePower
+	TotalQualityAdjPower abi.StoragePower
+	// Miners meeting the consensus minimum, in decreasing order of quality-adjusted power.
+	// Miners with equal power are ordered by address.
+	Miners []PowerTableEntry
+}
+
+// Computes a snapshot of the miners eligible to win blocks, for election tooling outside the chain state.
+// This iterates all claims, so should not be used in actor code.
+func (st *State) PowerTable(s adt.Store) (*PowerTable, error) {
+	claims, err := adt.AsMap(s, st.Claims, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load claims: %w", err)
+	}
+
+	rawTotal, qaTotal := CurrentTotalPower(st)
+	table := &PowerTable{
+		TotalRawBytePower:    rawTotal,
+		TotalQualityAdjPower: qaTotal,
+	}
+	var claim Claim
+	if err = claims.ForEach(&claim, func(key string) error {
+		miner, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return xerrors.Errorf("failed to parse claim key %v: %w", key, err)
+		}
+		eligible, err := st.claimMeetsConsensusMinimum(&claim)
+		if err != nil {
+			return xerrors.Errorf("failed to check consensus minimum for %v: %w", miner, err)
+		}
+		if eligible {
+			table.Miners = append(table.Miners, PowerTableEntry{
+				Miner:           miner,
+				RawBytePower:    claim.RawBytePower,
+				QualityAdjPower: claim.QualityAdjPower,
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, xerrors.Errorf("failed to iterate claims: %w", err)
+	}
+
+	sort.Slice(table.Miners, func(i, j int) bool {
+		cmp := big.Cmp(table.Miners[i].QualityAdjPower, table.Miners[j].QualityAdjPower)
+		if cmp != 0 {
+			return cmp > 0
+		}
+		return bytes.Compare(table.Miners[i].Miner.Bytes(), table.Miners[j].Miner.Bytes()) < 0
+	})
+	return table, nil
+}
+
+// Computes a miner's share of the total quality-adjusted power in Q.128 format.
+// The share is zero if the miner does not meet the consensus minimum, or if there is no power.
+func qualityAdjPowerShare(qaPower, qaTotal abi.StoragePower, eligible bool) big.Int {
+	if !eligible || qaTotal.LessThanEqual(big.Zero()) {
+		return big.Zero()
+	}
+	return big.Div(big.Lsh(qaPower, math.Precision128), qaTotal)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/v3/actors/runtime/proof"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/math"
 	"github.com/filecoin-project/specs-actors/v3/support/mock"
 	tutil "github.com/filecoin-project/specs-actors/v3/support/testing"
 )
@@ -241,6 +242,27 @@ func TestEnrollCronEpoch(t *testing.T) {
 		ac.checkState(rt)
 	})
 
+	t.Run("identical enrollment is ignored", func(t *testing.T) {
+		rt, ac := basicPowerSetup(t)
+		ac.createMinerBasic(rt, owner, owner, miner)
+		miner2 := tutil.NewIDAddr(t, 501)
+		ac.createMinerBasic(rt, owner, owner, miner2)
+
+		e1 := abi.ChainEpoch(3)
+		ac.enrollCronEvent(rt, miner, e1, []byte("hello"))
+		ac.enrollCronEvent(rt, miner, e1, []byte("hello"))
+		// The same payload from another miner, or at another epoch, is enrolled.
+		ac.enrollCronEvent(rt, miner2, e1, []byte("hello"))
+		ac.enrollCronEvent(rt, miner, 2, []byte("hello"))
+		assert.Len(t, ac.getEnrolledCronTicks(rt, e1), 2)
+
+		byMiner, err := getState(rt).LoadCronEventsByMiner(rt.AdtStore())
+		require.NoError(t, err)
+		assert.Equal(t, []power.MinerCronEvent{{Epoch: 2, Payload: []byte("hello")}, {Epoch: e1, Payload: []byte("hello")}}, byMiner[miner])
+		assert.Equal(t, []power.MinerCronEvent{{Epoch: e1, Payload: []byte("hello")}}, byMiner[miner2])
+		ac.checkState(rt)
+	})
+
 	t.Run("fails if epoch is negative", func(t *testing.T) {
 		rt, ac := basicPowerSetup(t)
 
@@ -272,7 +294,7 @@ func TestPowerAndPledgeAccounting(t *testing.T) {
 	smallPowerUnit := big.NewInt(1_000_000)
 	require.True(t, smallPowerUnit.LessThan(powerUnit), "power.ConsensusMinerMinPower has changed requiring update to this test")
 	// Subtests implicitly rely on ConsensusMinerMinMiners = 3
-	require.Equal(t, 4, power.ConsensusMinerMinMiners, "power.ConsensusMinerMinMiners has changed requiring update to this test")
+	require.Equal(t, int64(4), power.ConsensusMinerMinMiners, "power.ConsensusMinerMinMiners has changed requiring update to this test")
 
 	builder := mock.NewBuilder(context.Background(), builtin.StoragePowerActorAddr).
 		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
@@ -523,6 +545,99 @@ func TestPowerAndPledgeAccounting(t *testing.T) {
 	})
 }
 
+func TestMinerPower(t *testing.T) {
+	owner := tutil.NewIDAddr(t, 101)
+	miner1 := tutil.NewIDAddr(t, 111)
+	miner2 := tutil.NewIDAddr(t, 112)
+	miner3 := tutil.NewIDAddr(t, 113)
+	miner4 := tutil.NewIDAddr(t, 114)
+	miner5 := tutil.NewIDAddr(t, 115)
+
+	powerUnit, err := builtin.ConsensusMinerMinPower(abi.RegisteredPoStProof_StackedDrgWindow32GiBV1)
+	require.NoError(t, err)
+	smallPowerUnit := big.NewInt(1_000_000)
+	q128 := big.Lsh(big.NewInt(1), math.Precision128)
+	share := func(qaPower, total big.Int) big.Int {
+		return big.Div(big.Lsh(qaPower, math.Precision128), total)
+	}
+
+	t.Run("below minimum miners all miners with power are eligible", func(t *testing.T) {
+		rt, actor := basicPowerSetup(t)
+		actor.createMinerBasic(rt, owner, owner, miner1)
+		actor.createMinerBasic(rt, owner, owner, miner2)
+		actor.createMinerBasic(rt, owner, owner, miner3)
+		actor.updateClaimedPower(rt, miner1, smallPowerUnit, big.Mul(smallPowerUnit, big.NewInt(3)))
+		actor.updateClaimedPower(rt, miner2, smallPowerUnit, smallPowerUnit)
+
+		ret := actor.minerPower(rt, miner1)
+		assert.Equal(t, smallPowerUnit, ret.Claim.RawBytePower)
+		assert.True(t, ret.MeetsConsensusMinimum)
+		assert.Equal(t, big.Div(big.Mul(q128, big.NewInt(3)), big.NewInt(4)), ret.QualityAdjPowerShare)
+
+		// A miner without power cannot win.
+		ret = actor.minerPower(rt, miner3)
+		assert.False(t, ret.MeetsConsensusMinimum)
+		assert.Equal(t, big.Zero(), ret.QualityAdjPowerShare)
+
+		table, err := getState(rt).PowerTable(rt.AdtStore())
+		require.NoError(t, err)
+		assert.Equal(t, big.Mul(smallPowerUnit, big.NewInt(4)), table.TotalQualityAdjPower)
+		require.Len(t, table.Miners, 2)
+		assert.Equal(t, miner1, table.Miners[0].Miner)
+		assert.Equal(t, miner2, table.Miners[1].Miner)
+	})
+
+	t.Run("above minimum miners only large miners are eligible", func(t *testing.T) {
+		rt, actor := basicPowerSetup(t)
+		for _, miner := range []addr.Address{miner1, miner2, miner3, miner4, miner5} {
+			actor.createMinerBasic(rt, owner, owner, miner)
+		}
+		actor.updateClaimedPower(rt, miner1, smallPowerUnit, smallPowerUnit)
+		actor.updateClaimedPower(rt, miner2, powerUnit, powerUnit)
+		actor.updateClaimedPower(rt, miner3, powerUnit, big.Mul(powerUnit, big.NewInt(2)))
+		actor.updateClaimedPower(rt, miner4, powerUnit, powerUnit)
+		actor.updateClaimedPower(rt, miner5, powerUnit, big.Mul(powerUnit, big.NewInt(3)))
+		total := big.Mul(powerUnit, big.NewInt(7))
+
+		ret := actor.minerPower(rt, miner1)
+		assert.Equal(t, smallPowerUnit, ret.Claim.QualityAdjPower)
+		assert.False(t, ret.MeetsConsensusMinimum)
+		assert.Equal(t, big.Zero(), ret.QualityAdjPowerShare)
+
+		ret = actor.minerPower(rt, miner5)
+		assert.True(t, ret.MeetsConsensusMinimum)
+		assert.Equal(t, share(big.Mul(powerUnit, big.NewInt(3)), total), ret.QualityAdjPowerShare)
+
+		// Ordered by decreasing quality-adjusted power, then by address.
+		table, err := getState(rt).PowerTable(rt.AdtStore())
+		require.NoError(t, err)
+		assert.Equal(t, total, table.TotalQualityAdjPower)
+		assert.Equal(t, big.Mul(powerUnit, big.NewInt(4)), table.TotalRawBytePower)
+		var miners []addr.Address
+		for _, entry := range table.Miners {
+			miners = append(miners, entry.Miner)
+		}
+		assert.Equal(t, []addr.Address{miner5, miner3, miner2, miner4}, miners)
+		actor.checkState(rt)
+	})
+
+	t.Run("fails for unknown miner", func(t *testing.T) {
+		rt, actor := basicPowerSetup(t)
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no claim", func() {
+			rt.Call(actor.MinerPower, &miner1)
+		})
+		rt.Verify()
+
+		unresolvable := tutil.NewBLSAddr(t, 1)
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "failed to resolve", func() {
+			rt.Call(actor.MinerPower, &unresolvable)
+		})
+		rt.Verify()
+	})
+}
+
 func TestUpdatePledgeTotal(t *testing.T) {
 	// most coverage of update pledge total is in accounting test above
 
@@ -1306,6 +1421,13 @@ func (h *spActorHarness) currentPowerTotal(rt *mock.Runtime) *power.CurrentTotal
 	return ret
 }
 
+func (h *spActorHarness) minerPower(rt *mock.Runtime, miner addr.Address) *power.MinerPowerReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.MinerPower, &miner).(*power.MinerPowerReturn)
+	rt.Verify()
+	return ret
+}
+
 func (h *spActorHarness) enrollCronEvent(rt *mock.Runtime, miner addr.Address, epoch abi.ChainEpoch, payload []byte) {
 	rt.ExpectValidateCallerType(builtin.StorageMinerActorCodeID)
 	rt.SetCaller(miner, builtin.StorageMinerActorCodeID)
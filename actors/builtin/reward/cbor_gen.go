@@ -13,7 +13,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{139}
+var lengthBufState = []byte{140}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -83,15 +83,22 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
-	// t.SimpleTotal (big.Int) (struct)
-	if err := t.SimpleTotal.MarshalCBOR(w); err != nil {
+	// t.Policy (reward.RewardPolicy) (struct)
+	if err := t.Policy.MarshalCBOR(w); err != nil {
 		return err
 	}
 
-	// t.BaselineTotal (big.Int) (struct)
-	if err := t.BaselineTotal.MarshalCBOR(w); err != nil {
+	// t.Constants (reward.RewardConstants) (struct)
+	if err := t.Constants.MarshalCBOR(w); err != nil {
 		return err
 	}
+
+	// t.Checkpoints (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Checkpoints); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Checkpoints: %w", err)
+	}
+
 	return nil
 }
 
@@ -109,7 +116,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 11 {
+	if extra != 12 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -226,6 +233,115 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		}
 
 	}
+	// t.Policy (reward.RewardPolicy) (struct)
+
+	{
+
+		if err := t.Policy.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Policy: %w", err)
+		}
+
+	}
+	// t.Constants (reward.RewardConstants) (struct)
+
+	{
+
+		if err := t.Constants.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Constants: %w", err)
+		}
+
+	}
+	// t.Checkpoints (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Checkpoints: %w", err)
+		}
+
+		t.Checkpoints = c
+
+	}
+	return nil
+}
+
+var lengthBufRewardPolicy = []byte{134}
+
+func (t *RewardPolicy) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRewardPolicy); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.SimpleTotal (big.Int) (struct)
+	if err := t.SimpleTotal.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.BaselineTotal (big.Int) (struct)
+	if err := t.BaselineTotal.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.HalfLife (abi.ChainEpoch) (int64)
+	if t.HalfLife >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.HalfLife)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.HalfLife-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.BaselineInitialValue (big.Int) (struct)
+	if err := t.BaselineInitialValue.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.BaselineGrowthPercent (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.BaselineGrowthPercent)); err != nil {
+		return err
+	}
+
+	// t.BaselineGrowthPeriod (abi.ChainEpoch) (int64)
+	if t.BaselineGrowthPeriod >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.BaselineGrowthPeriod)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.BaselineGrowthPeriod-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *RewardPolicy) UnmarshalCBOR(r io.Reader) error {
+	*t = RewardPolicy{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 6 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
 	// t.SimpleTotal (big.Int) (struct)
 
 	{
@@ -243,6 +359,333 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.BaselineTotal: %w", err)
 		}
 
+	}
+	// t.HalfLife (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.HalfLife = abi.ChainEpoch(extraI)
+	}
+	// t.BaselineInitialValue (big.Int) (struct)
+
+	{
+
+		if err := t.BaselineInitialValue.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.BaselineInitialValue: %w", err)
+		}
+
+	}
+	// t.BaselineGrowthPercent (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.BaselineGrowthPercent = uint64(extra)
+
+	}
+	// t.BaselineGrowthPeriod (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.BaselineGrowthPeriod = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufRewardConstants = []byte{131}
+
+func (t *RewardConstants) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRewardConstants); err != nil {
+		return err
+	}
+
+	// t.Lambda (big.Int) (struct)
+	if err := t.Lambda.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.ExpLamSubOne (big.Int) (struct)
+	if err := t.ExpLamSubOne.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.BaselineExponent (big.Int) (struct)
+	if err := t.BaselineExponent.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RewardConstants) UnmarshalCBOR(r io.Reader) error {
+	*t = RewardConstants{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Lambda (big.Int) (struct)
+
+	{
+
+		if err := t.Lambda.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Lambda: %w", err)
+		}
+
+	}
+	// t.ExpLamSubOne (big.Int) (struct)
+
+	{
+
+		if err := t.ExpLamSubOne.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ExpLamSubOne: %w", err)
+		}
+
+	}
+	// t.BaselineExponent (big.Int) (struct)
+
+	{
+
+		if err := t.BaselineExponent.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.BaselineExponent: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufCheckpoint = []byte{135}
+
+func (t *Checkpoint) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufCheckpoint); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	if t.Epoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Epoch-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.ThisEpochReward (big.Int) (struct)
+	if err := t.ThisEpochReward.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.ThisEpochBaselinePower (big.Int) (struct)
+	if err := t.ThisEpochBaselinePower.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.CumsumRealized (big.Int) (struct)
+	if err := t.CumsumRealized.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.CumsumBaseline (big.Int) (struct)
+	if err := t.CumsumBaseline.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.EffectiveNetworkTime (abi.ChainEpoch) (int64)
+	if t.EffectiveNetworkTime >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.EffectiveNetworkTime)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.EffectiveNetworkTime-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.TotalStoragePowerReward (big.Int) (struct)
+	if err := t.TotalStoragePowerReward.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *Checkpoint) UnmarshalCBOR(r io.Reader) error {
+	*t = Checkpoint{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 7 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Epoch = abi.ChainEpoch(extraI)
+	}
+	// t.ThisEpochReward (big.Int) (struct)
+
+	{
+
+		if err := t.ThisEpochReward.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ThisEpochReward: %w", err)
+		}
+
+	}
+	// t.ThisEpochBaselinePower (big.Int) (struct)
+
+	{
+
+		if err := t.ThisEpochBaselinePower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ThisEpochBaselinePower: %w", err)
+		}
+
+	}
+	// t.CumsumRealized (big.Int) (struct)
+
+	{
+
+		if err := t.CumsumRealized.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.CumsumRealized: %w", err)
+		}
+
+	}
+	// t.CumsumBaseline (big.Int) (struct)
+
+	{
+
+		if err := t.CumsumBaseline.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.CumsumBaseline: %w", err)
+		}
+
+	}
+	// t.EffectiveNetworkTime (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.EffectiveNetworkTime = abi.ChainEpoch(extraI)
+	}
+	// t.TotalStoragePowerReward (big.Int) (struct)
+
+	{
+
+		if err := t.TotalStoragePowerReward.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalStoragePowerReward: %w", err)
+		}
+
 	}
 	return nil
 }
@@ -308,3 +751,75 @@ func (t *ThisEpochRewardReturn) UnmarshalCBOR(r io.Reader) error {
 	}
 	return nil
 }
+
+var lengthBufConstructorParams = []byte{130}
+
+func (t *ConstructorParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufConstructorParams); err != nil {
+		return err
+	}
+
+	// t.CurrRealizedPower (big.Int) (struct)
+	if err := t.CurrRealizedPower.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Policy (reward.RewardPolicy) (struct)
+	if err := t.Policy.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ConstructorParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ConstructorParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.CurrRealizedPower (big.Int) (struct)
+
+	{
+
+		if err := t.CurrRealizedPower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.CurrRealizedPower: %w", err)
+		}
+
+	}
+	// t.Policy (reward.RewardPolicy) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Policy = new(RewardPolicy)
+			if err := t.Policy.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Policy pointer: %w", err)
+			}
+		}
+
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/runtime"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
 )
 
@@ -42,14 +43,23 @@ func (a Actor) State() cbor.Er {
 
 var _ runtime.VMActor = Actor{}
 
-func (a Actor) Constructor(rt runtime.Runtime, currRealizedPower *abi.StoragePower) *abi.EmptyValue {
+type ConstructorParams struct {
+	CurrRealizedPower abi.StoragePower
+	Policy            *RewardPolicy // The default reward policy if nil
+}
+
+func (a Actor) Constructor(rt runtime.Runtime, params *ConstructorParams) *abi.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
 
-	if currRealizedPower == nil {
-		rt.Abortf(exitcode.ErrIllegalArgument, "argument should not be nil")
-		return nil // linter does not understand abort exiting
+	policy := DefaultRewardPolicy()
+	if params.Policy != nil {
+		policy = *params.Policy
+	}
+	if err := policy.Validate(); err != nil {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid reward policy: %v", err)
 	}
-	st := ConstructState(*currRealizedPower)
+	st, err := ConstructState(adt.AsStore(rt), params.CurrRealizedPower, policy)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to construct state")
 	rt.StateCreate(st)
 	return nil
 }
@@ -175,6 +185,9 @@ func (a Actor) UpdateNetworkKPI(rt runtime.Runtime, currRealizedPower *abi.Stora
 		st.updateToNextEpochWithReward(*currRealizedPower)
 		// only update smoothed estimates after updating reward and epoch
 		st.updateSmoothedEstimates(st.Epoch - prev)
+
+		err := st.recordCheckpoint(adt.AsStore(rt), prev)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record checkpoint")
 	})
 	return nil
 }
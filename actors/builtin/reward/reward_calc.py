@@ -2,6 +2,8 @@
 
 # This file can be used to recalculate the reward constants in the code when
 # changing the reward factor and/or the epoch duration in seconds.
+# The reward actor derives the same constants from its RewardPolicy, so this
+# serves to check the derivation rather than to configure a network.
 
 from decimal import Decimal
 import decimal
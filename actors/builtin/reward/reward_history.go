@@ -0,0 +1,86 @@
+package reward
+
+import (
+	"sort"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// Number of daily checkpoints retained in the reward actor's state.
+// The checkpoint for each day overwrites that of the day this many days before it.
+const CheckpointCapacity = 365
+
+const CheckpointsAmtBitwidth = 5
+
+// A record of the reward state at the first epoch of a day to be computed.
+type Checkpoint struct {
+	Epoch                   abi.ChainEpoch
+	ThisEpochReward         abi.TokenAmount
+	ThisEpochBaselinePower  abi.StoragePower
+	CumsumRealized          Spacetime
+	CumsumBaseline          Spacetime
+	EffectiveNetworkTime    abi.ChainEpoch
+	TotalStoragePowerReward abi.TokenAmount
+}
+
+// Records a checkpoint of the state if its epoch is in a later day than prevEpoch.
+// The checkpoints of days skipped since prevEpoch, left from CheckpointCapacity days before, are removed.
+func (st *State) recordCheckpoint(store adt.Store, prevEpoch abi.ChainEpoch) error {
+	day := st.Epoch / builtin.EpochsInDay
+	if prevEpoch >= 0 && day == prevEpoch/builtin.EpochsInDay {
+		return nil
+	}
+
+	checkpoints, err := adt.AsArray(store, st.Checkpoints, CheckpointsAmtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load checkpoints: %w", err)
+	}
+	if prevEpoch >= 0 {
+		var skipped []uint64
+		for d := day - 1; d > prevEpoch/builtin.EpochsInDay && d > day-CheckpointCapacity; d-- {
+			skipped = append(skipped, uint64(d%CheckpointCapacity))
+		}
+		if err = checkpoints.BatchDelete(skipped, false); err != nil {
+			return xerrors.Errorf("failed to delete checkpoints of days skipped before day %d: %w", day, err)
+		}
+	}
+	if err = checkpoints.Set(uint64(day%CheckpointCapacity), &Checkpoint{
+		Epoch:                   st.Epoch,
+		ThisEpochReward:         st.ThisEpochReward,
+		ThisEpochBaselinePower:  st.ThisEpochBaselinePower,
+		CumsumRealized:          st.CumsumRealized,
+		CumsumBaseline:          st.CumsumBaseline,
+		EffectiveNetworkTime:    st.EffectiveNetworkTime,
+		TotalStoragePowerReward: st.TotalStoragePowerReward,
+	}); err != nil {
+		return xerrors.Errorf("failed to set checkpoint for day %d: %w", day, err)
+	}
+	if st.Checkpoints, err = checkpoints.Root(); err != nil {
+		return xerrors.Errorf("failed to flush checkpoints: %w", err)
+	}
+	return nil
+}
+
+// Loads the retained checkpoints, in order of epoch.
+func (st *State) LoadCheckpoints(store adt.Store) ([]Checkpoint, error) {
+	checkpoints, err := adt.AsArray(store, st.Checkpoints, CheckpointsAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load checkpoints: %w", err)
+	}
+	var out []Checkpoint
+	var checkpoint Checkpoint
+	if err = checkpoints.ForEach(&checkpoint, func(_ int64) error {
+		out = append(out, checkpoint)
+		return nil
+	}); err != nil {
+		return nil, xerrors.Errorf("failed to iterate checkpoints: %w", err)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Epoch < out[j].Epoch
+	})
+	return out, nil
+}
@@ -12,6 +12,9 @@ import (
 // Caller of baseline power function is responsible for keeping track of intermediate,
 // state e(n-1), the baseline power function just does the next multiplication
 
+// The constants below are those of the default reward policy, which derives them in the same way.
+// A network with a different reward policy derives its own constants, held in the reward actor's state.
+
 // Floor(e^(ln[1 + 100%] / epochsInYear) * 2^128
 // Q.128 formatted number such that f(epoch) = baseExponent^epoch grows 100% in one year of epochs
 // Calculation here: https://www.wolframalpha.com/input/?i=IntegerPart%5BExp%5BLog%5B1%2B100%25%5D%2F%28%28365+days%29%2F%2830+seconds%29%29%5D*2%5E128%5D
@@ -23,16 +26,24 @@ var BaselineInitialValue = big.NewInt(2_888_888_880_000_000_000) // Q.0
 // Initialize baseline power for epoch -1 so that baseline power at epoch 0 is
 // BaselineInitialValue.
 func InitBaselinePower() abi.StoragePower {
-	baselineInitialValue256 := big.Lsh(BaselineInitialValue, 2*math.Precision128) // Q.0 => Q.256
-	baselineAtMinusOne := big.Div(baselineInitialValue256, BaselineExponent)      // Q.256 / Q.128 => Q.128
-	return big.Rsh(baselineAtMinusOne, math.Precision128)                         // Q.128 => Q.0
+	return initBaselinePower(BaselineInitialValue, BaselineExponent)
+}
+
+func initBaselinePower(initialValue abi.StoragePower, exponent big.Int) abi.StoragePower {
+	baselineInitialValue256 := big.Lsh(initialValue, 2*math.Precision128) // Q.0 => Q.256
+	baselineAtMinusOne := big.Div(baselineInitialValue256, exponent)      // Q.256 / Q.128 => Q.128
+	return big.Rsh(baselineAtMinusOne, math.Precision128)                 // Q.128 => Q.0
 }
 
 // Compute BaselinePower(t) from BaselinePower(t-1) with an additional multiplication
 // of the base exponent.
 func BaselinePowerFromPrev(prevEpochBaselinePower abi.StoragePower) abi.StoragePower {
-	thisEpochBaselinePower := big.Mul(prevEpochBaselinePower, BaselineExponent) // Q.0 * Q.128 => Q.128
-	return big.Rsh(thisEpochBaselinePower, math.Precision128)                   // Q.128 => Q.0
+	return baselinePowerFromPrev(prevEpochBaselinePower, BaselineExponent)
+}
+
+func baselinePowerFromPrev(prevEpochBaselinePower abi.StoragePower, exponent big.Int) abi.StoragePower {
+	thisEpochBaselinePower := big.Mul(prevEpochBaselinePower, exponent) // Q.0 * Q.128 => Q.128
+	return big.Rsh(thisEpochBaselinePower, math.Precision128)           // Q.128 => Q.0
 }
 
 // These numbers are estimates of the onchain constants.  They are good for initializing state in
@@ -76,14 +87,17 @@ var (
 
 // Computes a reward for all expected leaders when effective network time changes from prevTheta to currTheta
 // Inputs are in Q.128 format
-func computeReward(epoch abi.ChainEpoch, prevTheta, currTheta, simpleTotal, baselineTotal big.Int) abi.TokenAmount {
-	simpleReward := big.Mul(simpleTotal, ExpLamSubOne)    //Q.0 * Q.128 =>  Q.128
-	epochLam := big.Mul(big.NewInt(int64(epoch)), Lambda) // Q.0 * Q.128 => Q.128
+func computeReward(epoch abi.ChainEpoch, prevTheta, currTheta, simpleTotal, baselineTotal big.Int, constants *RewardConstants) abi.TokenAmount {
+	simpleReward := big.Mul(simpleTotal, constants.ExpLamSubOne)    //Q.0 * Q.128 =>  Q.128
+	epochLam := big.Mul(big.NewInt(int64(epoch)), constants.Lambda) // Q.0 * Q.128 => Q.128
 
 	simpleReward = big.Mul(simpleReward, big.NewFromGo(math.ExpNeg(epochLam.Int))) // Q.128 * Q.128 => Q.256
 	simpleReward = big.Rsh(simpleReward, math.Precision128)                        // Q.256 >> 128 => Q.128
 
-	baselineReward := big.Sub(computeBaselineSupply(currTheta, baselineTotal), computeBaselineSupply(prevTheta, baselineTotal)) // Q.128
+	baselineReward := big.Sub(
+		computeBaselineSupply(currTheta, baselineTotal, constants.Lambda),
+		computeBaselineSupply(prevTheta, baselineTotal, constants.Lambda),
+	) // Q.128
 
 	reward := big.Add(simpleReward, baselineReward) // Q.128
 
@@ -92,8 +106,8 @@ func computeReward(epoch abi.ChainEpoch, prevTheta, currTheta, simpleTotal, base
 
 // Computes baseline supply based on theta in Q.128 format.
 // Return is in Q.128 format
-func computeBaselineSupply(theta, baselineTotal big.Int) big.Int {
-	thetaLam := big.Mul(theta, Lambda)              // Q.128 * Q.128 => Q.256
+func computeBaselineSupply(theta, baselineTotal, lambda big.Int) big.Int {
+	thetaLam := big.Mul(theta, lambda)              // Q.128 * Q.128 => Q.256
 	thetaLam = big.Rsh(thetaLam, math.Precision128) // Q.256 >> 128 => Q.128
 
 	eTL := big.NewFromGo(math.ExpNeg(thetaLam.Int)) // Q.128
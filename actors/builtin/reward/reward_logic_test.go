@@ -2,6 +2,7 @@ package reward
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	gbig "math/big"
 	"testing"
@@ -9,10 +10,13 @@ import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/xorcare/golden"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/math"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
+	"github.com/filecoin-project/specs-actors/v3/support/ipld"
 )
 
 func q128ToF(x big.Int) float64 {
@@ -54,10 +58,11 @@ func TestBaselineReward(t *testing.T) {
 
 	b := &bytes.Buffer{}
 	b.WriteString("t0, t1, y\n")
-	simple := computeReward(0, big.Zero(), big.Zero(), DefaultSimpleTotal, DefaultBaselineTotal)
+	constants := defaultConstants()
+	simple := computeReward(0, big.Zero(), big.Zero(), DefaultSimpleTotal, DefaultBaselineTotal, &constants)
 
 	for i := 0; i < 512; i++ {
-		reward := computeReward(0, big.NewFromGo(prevTheta), big.NewFromGo(theta), DefaultSimpleTotal, DefaultBaselineTotal)
+		reward := computeReward(0, big.NewFromGo(prevTheta), big.NewFromGo(theta), DefaultSimpleTotal, DefaultBaselineTotal, &constants)
 		reward = big.Sub(reward, simple)
 		fmt.Fprintf(b, "%s,%s,%s\n", prevTheta, theta, reward.Int)
 		prevTheta = prevTheta.Add(prevTheta, step)
@@ -70,9 +75,10 @@ func TestBaselineReward(t *testing.T) {
 func TestSimpleReward(t *testing.T) {
 	b := &bytes.Buffer{}
 	b.WriteString("x, y\n")
+	constants := defaultConstants()
 	for i := int64(0); i < 512; i++ {
 		x := i * 5000
-		reward := computeReward(abi.ChainEpoch(x), big.Zero(), big.Zero(), DefaultSimpleTotal, DefaultBaselineTotal)
+		reward := computeReward(abi.ChainEpoch(x), big.Zero(), big.Zero(), DefaultSimpleTotal, DefaultBaselineTotal, &constants)
 		fmt.Fprintf(b, "%d,%s\n", x, reward.Int)
 	}
 
@@ -152,3 +158,104 @@ func TestBaselineRewardGrowth(t *testing.T) {
 		assert.Less(t, perr, testCase.ErrBound)
 	}
 }
+
+func defaultConstants() RewardConstants {
+	return RewardConstants{
+		Lambda:           Lambda,
+		ExpLamSubOne:     ExpLamSubOne,
+		BaselineExponent: BaselineExponent,
+	}
+}
+
+func TestRewardPolicy(t *testing.T) {
+	t.Run("default policy derives Filecoin constants", func(t *testing.T) {
+		policy := DefaultRewardPolicy()
+		require.NoError(t, policy.Validate())
+		assert.Equal(t, defaultConstants(), policy.Constants())
+
+		expected := smoothing.NewEstimate(InitialRewardPositionEstimate, InitialRewardVelocityEstimate)
+		estimate := policy.InitialRewardEstimate()
+		assert.True(t, expected.PositionEstimate.Equals(estimate.PositionEstimate))
+		assert.True(t, expected.VelocityEstimate.Equals(estimate.VelocityEstimate))
+	})
+
+	t.Run("constants follow policy", func(t *testing.T) {
+		policy := DefaultRewardPolicy()
+		policy.HalfLife = 1000
+		policy.BaselineGrowthPercent = 50
+		policy.BaselineGrowthPeriod = 2000
+		require.NoError(t, policy.Validate())
+		constants := policy.Constants()
+
+		// Simple minting halves over the half-life.
+		first := computeReward(0, big.Zero(), big.Zero(), policy.SimpleTotal, policy.BaselineTotal, &constants)
+		halved := computeReward(policy.HalfLife, big.Zero(), big.Zero(), policy.SimpleTotal, policy.BaselineTotal, &constants)
+		assert.InDelta(t, 0.5, ratio(halved, first), 1e-9)
+
+		// Baseline grows by the growth percentage over the growth period.
+		baseline := policy.BaselineInitialValue
+		for i := abi.ChainEpoch(0); i < policy.BaselineGrowthPeriod; i++ {
+			baseline = baselinePowerFromPrev(baseline, constants.BaselineExponent)
+		}
+		assert.InDelta(t, 1.5, ratio(baseline, policy.BaselineInitialValue), 1e-9)
+	})
+
+	t.Run("invalid policies", func(t *testing.T) {
+		for _, mutate := range []func(p *RewardPolicy){
+			func(p *RewardPolicy) { p.SimpleTotal = big.NewInt(-1) },
+			func(p *RewardPolicy) { p.HalfLife = 0 },
+			func(p *RewardPolicy) { p.BaselineInitialValue = big.Zero() },
+			func(p *RewardPolicy) { p.BaselineGrowthPeriod = -1 },
+		} {
+			policy := DefaultRewardPolicy()
+			mutate(&policy)
+			assert.Error(t, policy.Validate())
+		}
+	})
+}
+
+func ratio(x, y big.Int) float64 {
+	r, _ := new(gbig.Rat).SetFrac(x.Int, y.Int).Float64()
+	return r
+}
+
+func TestProject(t *testing.T) {
+	policy := DefaultRewardPolicy()
+	policy.BaselineGrowthPeriod = 1000
+	st, err := ConstructState(ipld.NewADTStore(context.Background()), big.Zero(), policy)
+	require.NoError(t, err)
+
+	// Realized power starts above the baseline, which catches up after log2(1.5) of a growth period.
+	power := big.Div(big.Mul(policy.BaselineInitialValue, big.NewInt(3)), big.NewInt(2))
+	projection, err := Project(st, ConstantPower(power), 1000, 100)
+	require.NoError(t, err)
+	assert.Equal(t, abi.ChainEpoch(0), st.Epoch)
+
+	require.Len(t, projection.Periods, 10)
+	sum := big.Zero()
+	for i, period := range projection.Periods {
+		assert.Equal(t, abi.ChainEpoch(100*i+1), period.Start)
+		assert.Equal(t, abi.ChainEpoch(100*i+100), period.End)
+		assert.True(t, power.Equals(period.RealizedPower))
+		sum = big.Add(sum, period.Reward)
+		assert.True(t, sum.Equals(period.CumulativeReward))
+	}
+	assert.True(t, sum.Equals(projection.TotalReward))
+
+	require.Len(t, projection.BaselineCrossings, 1)
+	crossing := projection.BaselineCrossings[0]
+	assert.True(t, crossing > 580 && crossing < 590, "crossing at %d", crossing)
+	// Effective network time keeps pace with the epoch until realized power falls below the baseline.
+	assert.Equal(t, abi.ChainEpoch(500), projection.Periods[4].EffectiveNetworkTime)
+	assert.True(t, projection.Periods[9].EffectiveNetworkTime < 1000)
+
+	// A partial final period covers the remaining epochs.
+	projection, err = Project(st, ConstantPower(power), 250, 100)
+	require.NoError(t, err)
+	require.Len(t, projection.Periods, 3)
+	assert.Equal(t, abi.ChainEpoch(201), projection.Periods[2].Start)
+	assert.Equal(t, abi.ChainEpoch(250), projection.Periods[2].End)
+
+	_, err = Project(st, ConstantPower(power), 0, 100)
+	assert.Error(t, err)
+}
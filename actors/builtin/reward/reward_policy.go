@@ -0,0 +1,115 @@
+package reward
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/math"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
+)
+
+// The economic parameters of a network's reward schedule, fixed in state at construction.
+type RewardPolicy struct {
+	// Tokens minted by simple exponential decay, and as the network's realized power reaches the baseline.
+	SimpleTotal   abi.TokenAmount
+	BaselineTotal abi.TokenAmount
+	// Epochs over which the rate of simple minting halves.
+	// Baseline minting likewise halves over this many epochs of effective network time.
+	HalfLife abi.ChainEpoch
+	// Baseline power at epoch zero.
+	BaselineInitialValue abi.StoragePower
+	// The baseline grows by BaselineGrowthPercent every BaselineGrowthPeriod epochs.
+	BaselineGrowthPercent uint64
+	BaselineGrowthPeriod  abi.ChainEpoch
+}
+
+// Q.128 constants of the reward functions, derived from a reward policy.
+type RewardConstants struct {
+	// lambda = ln(2) / HalfLife
+	Lambda big.Int
+	// e^lambda - 1
+	ExpLamSubOne big.Int
+	// (1 + BaselineGrowthPercent/100) ^ (1 / BaselineGrowthPeriod), the growth of the baseline in one epoch
+	BaselineExponent big.Int
+}
+
+// The reward policy of the Filecoin network: a six year half-life and a baseline doubling every year.
+func DefaultRewardPolicy() RewardPolicy {
+	return RewardPolicy{
+		SimpleTotal:           DefaultSimpleTotal,
+		BaselineTotal:         DefaultBaselineTotal,
+		HalfLife:              6 * builtin.EpochsInYear,
+		BaselineInitialValue:  BaselineInitialValue,
+		BaselineGrowthPercent: 100,
+		BaselineGrowthPeriod:  builtin.EpochsInYear,
+	}
+}
+
+func (p *RewardPolicy) Validate() error {
+	if p.SimpleTotal.LessThan(big.Zero()) || p.BaselineTotal.LessThan(big.Zero()) {
+		return xerrors.Errorf("negative reward totals %v, %v", p.SimpleTotal, p.BaselineTotal)
+	}
+	if p.HalfLife <= 0 {
+		return xerrors.Errorf("non-positive half-life %d", p.HalfLife)
+	}
+	if p.BaselineInitialValue.LessThanEqual(big.Zero()) {
+		return xerrors.Errorf("non-positive initial baseline %v", p.BaselineInitialValue)
+	}
+	if p.BaselineGrowthPeriod <= 0 {
+		return xerrors.Errorf("non-positive baseline growth period %d", p.BaselineGrowthPeriod)
+	}
+	return nil
+}
+
+// Derives the constants of the reward functions from the policy, which must be valid.
+// Intermediate values are computed in Q.256 and truncated, matching the precision of constants computed
+// by reward_calc.py.
+func (p *RewardPolicy) Constants() RewardConstants {
+	one := big.Lsh(big.NewInt(1), math.Precision128) // Q.128
+	lambda := p.lambda()                             // Q.256
+
+	growth := big.Div(big.Lsh(big.NewIntUnsigned(100+p.BaselineGrowthPercent), math.Precision128), big.NewInt(100)) // Q.128
+	growthLn := math.Ln(growth)                                                                                     // Q.128
+	growthPerEpoch := big.Div(big.Lsh(growthLn, math.Precision128), big.NewInt(int64(p.BaselineGrowthPeriod)))      // Q.256
+
+	return RewardConstants{
+		Lambda:           big.Rsh(lambda, math.Precision128),
+		ExpLamSubOne:     big.Rsh(expSubOne(lambda), math.Precision128),
+		BaselineExponent: big.Add(one, big.Rsh(expSubOne(growthPerEpoch), math.Precision128)),
+	}
+}
+
+// Estimates the reward at epoch zero and its change per epoch, for initialization of the smoothed reward.
+// The position is the simple reward at epoch zero: SimpleTotal * (1 - e^-lambda).
+// The velocity is scaled to one FIL of simple supply: (e^-lambda - 1) * 10^18.
+func (p *RewardPolicy) InitialRewardEstimate() smoothing.FilterEstimate {
+	expLamSubOne := expSubOne(p.lambda())              // Q.256
+	one := big.Lsh(big.NewInt(1), 2*math.Precision128) // Q.256
+	// 1 - e^-lambda = (e^lambda - 1) / e^lambda
+	oneSubExpNegLam := big.Div(big.Lsh(expLamSubOne, 2*math.Precision128), big.Add(one, expLamSubOne)) // Q.512 / Q.256 => Q.256
+
+	position := big.Rsh(big.Mul(p.SimpleTotal, oneSubExpNegLam), 2*math.Precision128)          // Q.0
+	velocity := big.Rsh(big.Mul(big.NewInt(1e18), oneSubExpNegLam), 2*math.Precision128).Neg() // Q.0
+	return smoothing.NewEstimate(position, velocity)
+}
+
+// Computes lambda = ln(2) / HalfLife in Q.256 format.
+func (p *RewardPolicy) lambda() big.Int {
+	ln2 := math.Ln(big.Lsh(big.NewInt(2), math.Precision128))                      // Q.128
+	return big.Div(big.Lsh(ln2, math.Precision128), big.NewInt(int64(p.HalfLife))) // Q.256 / Q.0 => Q.256
+}
+
+// Computes e^x - 1 for x in Q.256 by its Taylor series, which converges quickly for the small exponents of
+// per-epoch rates. Output is in Q.256 format.
+func expSubOne(x big.Int) big.Int {
+	sum := big.Zero()
+	term := x
+	for k := int64(2); !term.IsZero(); k++ {
+		sum = big.Add(sum, term)
+		term = big.Rsh(big.Mul(term, x), 2*math.Precision128) // Q.256 * Q.256 => Q.512 >> 256 => Q.256
+		term = big.Div(term, big.NewInt(k))
+	}
+	return sum
+}
@@ -0,0 +1,87 @@
+package reward
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+)
+
+// The realized network power assumed at each epoch of a projection.
+type PowerTrajectory func(epoch abi.ChainEpoch) abi.StoragePower
+
+// A trajectory of constant power.
+func ConstantPower(power abi.StoragePower) PowerTrajectory {
+	return func(_ abi.ChainEpoch) abi.StoragePower {
+		return power
+	}
+}
+
+// A trajectory of power growing linearly from a start epoch, and constant before it.
+func LinearPower(start abi.ChainEpoch, initial, growthPerEpoch abi.StoragePower) PowerTrajectory {
+	return func(epoch abi.ChainEpoch) abi.StoragePower {
+		if epoch <= start {
+			return initial
+		}
+		return big.Add(initial, big.Mul(big.NewInt(int64(epoch-start)), growthPerEpoch))
+	}
+}
+
+// A summary of the projected reward over consecutive epochs.
+type ProjectedPeriod struct {
+	Start abi.ChainEpoch // First epoch of the period
+	End   abi.ChainEpoch // Last epoch of the period
+	// Total reward computed for the epochs of the period.
+	Reward abi.TokenAmount
+	// Values at the last epoch of the period.
+	BaselinePower        abi.StoragePower
+	RealizedPower        abi.StoragePower
+	EffectiveNetworkTime abi.ChainEpoch
+	// Total reward computed since the start of the projection, through the last epoch of the period.
+	CumulativeReward abi.TokenAmount
+}
+
+type Projection struct {
+	Periods []ProjectedPeriod
+	// Epochs at which realized power crosses the baseline power, in either direction.
+	BaselineCrossings []abi.ChainEpoch
+	// Total reward computed for all projected epochs.
+	TotalReward abi.TokenAmount
+}
+
+// Projects the reward for a number of epochs following a state, assuming a trajectory of realized power,
+// and summarizes it in periods of a number of epochs (e.g. 1, or a day's worth).
+// Every epoch is assumed to be non-null and so to mint its reward. The state is not modified.
+// This performs a computation per epoch, so it should not be used in actor code.
+func Project(st *State, power PowerTrajectory, epochs, period abi.ChainEpoch) (*Projection, error) {
+	if epochs <= 0 || period <= 0 {
+		return nil, xerrors.Errorf("epochs %d and period %d must be positive", epochs, period)
+	}
+
+	sim := *st
+	projection := &Projection{TotalReward: big.Zero()}
+	current := ProjectedPeriod{Start: sim.Epoch + 1, Reward: big.Zero()}
+	aboveBaseline := false
+	for i := abi.ChainEpoch(0); i < epochs; i++ {
+		realized := power(sim.Epoch + 1)
+		sim.updateToNextEpochWithReward(realized)
+		projection.TotalReward = big.Add(projection.TotalReward, sim.ThisEpochReward)
+		current.Reward = big.Add(current.Reward, sim.ThisEpochReward)
+
+		above := realized.GreaterThanEqual(sim.ThisEpochBaselinePower)
+		if i > 0 && above != aboveBaseline {
+			projection.BaselineCrossings = append(projection.BaselineCrossings, sim.Epoch)
+		}
+		aboveBaseline = above
+
+		if (i+1)%period == 0 || i+1 == epochs {
+			current.End = sim.Epoch
+			current.BaselinePower = sim.ThisEpochBaselinePower
+			current.RealizedPower = realized
+			current.EffectiveNetworkTime = sim.EffectiveNetworkTime
+			current.CumulativeReward = projection.TotalReward
+			projection.Periods = append(projection.Periods, current)
+			current = ProjectedPeriod{Start: sim.Epoch + 1, Reward: big.Zero()}
+		}
+	}
+	return projection, nil
+}
@@ -3,13 +3,19 @@ package reward
 import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
 
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
 )
 
 // A quantity of space * time (in byte-epochs) representing power committed to the network for some duration.
 type Spacetime = big.Int
 
+// The initial estimates of the reward under the default reward policy, from which the estimates of other
+// policies are derived in the same way.
+
 // 36.266260308195979333 FIL
 // https://www.wolframalpha.com/input/?i=IntegerPart%5B330%2C000%2C000+*+%281+-+Exp%5B-Log%5B2%5D+%2F+%286+*+%281+year+%2F+30+seconds%29%29%5D%29+*+10%5E18%5D
 const InitialRewardPositionEstimateStr = "36266260308195979333"
@@ -22,6 +28,7 @@ var InitialRewardVelocityEstimate = abi.NewTokenAmount(-109897758509)
 
 // Changed since v0:
 // - ThisEpochRewardSmoothed is not a pointer
+// - Policy, Constants and Checkpoints added
 type State struct {
 	// CumsumBaseline is a target CumsumRealized needs to reach for EffectiveNetworkTime to increase
 	// CumsumBaseline and CumsumRealized are expressed in byte-epochs.
@@ -55,49 +62,63 @@ type State struct {
 	// TotalStoragePowerReward tracks the total FIL awarded to block miners
 	TotalStoragePowerReward abi.TokenAmount
 
-	// Simple and Baseline totals are constants used for computing rewards.
-	// They are on chain because of a historical fix resetting baseline value
-	// in a way that depended on the history leading immediately up to the
-	// migration fixing the value.  These values can be moved from state back
-	// into a code constant in a subsequent upgrade.
-	SimpleTotal   abi.TokenAmount
-	BaselineTotal abi.TokenAmount
+	// The network's reward schedule, and the constants of the reward functions derived from it.
+	// The policy's simple and baseline totals are those in effect, which may differ from the default policy's
+	// because of a historical fix resetting baseline value in a way that depended on the history leading
+	// immediately up to the migration fixing the value.
+	Policy    RewardPolicy
+	Constants RewardConstants
+
+	// Ring buffer of daily checkpoints of the values above, indexed by day modulo CheckpointCapacity.
+	Checkpoints cid.Cid // AMT[uint64]Checkpoint
 }
 
-func ConstructState(currRealizedPower abi.StoragePower) *State {
+// Constructs reward state for a valid reward policy.
+func ConstructState(store adt.Store, currRealizedPower abi.StoragePower, policy RewardPolicy) (*State, error) {
+	emptyCheckpointsCid, err := adt.StoreEmptyArray(store, CheckpointsAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty array: %w", err)
+	}
+
+	constants := policy.Constants()
 	st := &State{
 		CumsumBaseline:         big.Zero(),
 		CumsumRealized:         big.Zero(),
 		EffectiveNetworkTime:   0,
-		EffectiveBaselinePower: BaselineInitialValue,
+		EffectiveBaselinePower: policy.BaselineInitialValue,
 
 		ThisEpochReward:        big.Zero(),
-		ThisEpochBaselinePower: InitBaselinePower(),
+		ThisEpochBaselinePower: initBaselinePower(policy.BaselineInitialValue, constants.BaselineExponent),
 		Epoch:                  -1,
 
-		ThisEpochRewardSmoothed: smoothing.NewEstimate(InitialRewardPositionEstimate, InitialRewardVelocityEstimate),
+		ThisEpochRewardSmoothed: policy.InitialRewardEstimate(),
 		TotalStoragePowerReward: big.Zero(),
 
-		SimpleTotal:   DefaultSimpleTotal,
-		BaselineTotal: DefaultBaselineTotal,
+		Policy:    policy,
+		Constants: constants,
+
+		Checkpoints: emptyCheckpointsCid,
 	}
 
 	st.updateToNextEpochWithReward(currRealizedPower)
+	if err := st.recordCheckpoint(store, -1); err != nil {
+		return nil, err
+	}
 
-	return st
+	return st, nil
 }
 
 // Takes in current realized power and updates internal state
 // Used for update of internal state during null rounds
 func (st *State) updateToNextEpoch(currRealizedPower abi.StoragePower) {
 	st.Epoch++
-	st.ThisEpochBaselinePower = BaselinePowerFromPrev(st.ThisEpochBaselinePower)
+	st.ThisEpochBaselinePower = baselinePowerFromPrev(st.ThisEpochBaselinePower, st.Constants.BaselineExponent)
 	cappedRealizedPower := big.Min(st.ThisEpochBaselinePower, currRealizedPower)
 	st.CumsumRealized = big.Add(st.CumsumRealized, cappedRealizedPower)
 
 	for st.CumsumRealized.GreaterThan(st.CumsumBaseline) {
 		st.EffectiveNetworkTime++
-		st.EffectiveBaselinePower = BaselinePowerFromPrev(st.EffectiveBaselinePower)
+		st.EffectiveBaselinePower = baselinePowerFromPrev(st.EffectiveBaselinePower, st.Constants.BaselineExponent)
 		st.CumsumBaseline = big.Add(st.CumsumBaseline, st.EffectiveBaselinePower)
 	}
 }
@@ -109,7 +130,7 @@ func (st *State) updateToNextEpochWithReward(currRealizedPower abi.StoragePower)
 	st.updateToNextEpoch(currRealizedPower)
 	currRewardTheta := ComputeRTheta(st.EffectiveNetworkTime, st.EffectiveBaselinePower, st.CumsumRealized, st.CumsumBaseline)
 
-	st.ThisEpochReward = computeReward(st.Epoch, prevRewardTheta, currRewardTheta, st.SimpleTotal, st.BaselineTotal)
+	st.ThisEpochReward = computeReward(st.Epoch, prevRewardTheta, currRewardTheta, st.Policy.SimpleTotal, st.Policy.BaselineTotal, &st.Constants)
 }
 
 func (st *State) updateSmoothedEstimates(delta abi.ChainEpoch) {
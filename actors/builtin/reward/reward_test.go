@@ -2,6 +2,7 @@ package reward_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	address "github.com/filecoin-project/go-address"
@@ -72,6 +73,53 @@ func TestConstructor(t *testing.T) {
 		assert.Equal(t, rwrd, newSt.ThisEpochReward)
 	})
 
+	t.Run("construct with custom policy", func(t *testing.T) {
+		rt := mock.NewBuilder(context.Background(), builtin.RewardActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		policy := reward.RewardPolicy{
+			SimpleTotal:           big.Mul(big.NewInt(100e6), reward.FIL),
+			BaselineTotal:         big.Mul(big.NewInt(200e6), reward.FIL),
+			HalfLife:              4 * builtin.EpochsInYear,
+			BaselineInitialValue:  abi.NewStoragePower(1 << 50),
+			BaselineGrowthPercent: 50,
+			BaselineGrowthPeriod:  builtin.EpochsInYear,
+		}
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.Call(actor.Constructor, &reward.ConstructorParams{CurrRealizedPower: big.Zero(), Policy: &policy})
+		rt.Verify()
+
+		st := getState(rt)
+		assert.Equal(t, policy, st.Policy)
+		assert.Equal(t, policy.Constants(), st.Constants)
+		assert.Equal(t, policy.SimpleTotal, st.Policy.SimpleTotal)
+		assert.Equal(t, policy.BaselineInitialValue, st.EffectiveBaselinePower)
+		defaultReward := big.MustFromString(EpochZeroReward)
+		assert.True(t, st.ThisEpochReward.LessThan(defaultReward))
+		assert.True(t, st.ThisEpochReward.GreaterThan(big.Zero()))
+
+		// The baseline grows under the policy.
+		rt.SetEpoch(1)
+		power := big.Zero()
+		actor.updateNetworkKPI(rt, &power)
+		st = getState(rt)
+		assert.True(t, st.ThisEpochBaselinePower.GreaterThan(policy.BaselineInitialValue))
+		_, msgs := reward.CheckStateInvariants(st, rt.AdtStore(), 1, big.Add(policy.SimpleTotal, policy.BaselineTotal))
+		assert.True(t, msgs.IsEmpty(), msgs.Messages())
+	})
+
+	t.Run("reject invalid policy", func(t *testing.T) {
+		rt := mock.NewBuilder(context.Background(), builtin.RewardActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		policy := reward.DefaultRewardPolicy()
+		policy.HalfLife = 0
+		rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid reward policy", func() {
+			rt.Call(actor.Constructor, &reward.ConstructorParams{CurrRealizedPower: big.Zero(), Policy: &policy})
+		})
+	})
+
 }
 
 func TestAwardBlockReward(t *testing.T) {
@@ -253,6 +301,62 @@ func TestSuccessiveKPIUpdates(t *testing.T) {
 
 }
 
+func TestCheckpoints(t *testing.T) {
+	actor := rewardHarness{reward.Actor{}, t}
+	builder := mock.NewBuilder(context.Background(), builtin.RewardActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID)
+	rt := builder.Build(t)
+	power := abi.NewStoragePower(1 << 50)
+	actor.constructAndVerify(rt, &power)
+
+	checkpointEpochs := func() []abi.ChainEpoch {
+		checkpoints, err := getState(rt).LoadCheckpoints(rt.AdtStore())
+		require.NoError(t, err)
+		var epochs []abi.ChainEpoch
+		for _, c := range checkpoints {
+			epochs = append(epochs, c.Epoch)
+		}
+		return epochs
+	}
+	assert.Equal(t, []abi.ChainEpoch{0}, checkpointEpochs())
+
+	// The state advances to the epoch after the update, which is checkpointed when it begins a new day.
+	rt.SetEpoch(builtin.EpochsInDay - 2)
+	actor.updateNetworkKPI(rt, &power)
+	assert.Equal(t, []abi.ChainEpoch{0}, checkpointEpochs())
+
+	rt.SetEpoch(builtin.EpochsInDay + 5)
+	actor.updateNetworkKPI(rt, &power)
+	rt.SetEpoch(builtin.EpochsInDay + 6)
+	actor.updateNetworkKPI(rt, &power)
+	assert.Equal(t, []abi.ChainEpoch{0, builtin.EpochsInDay + 6}, checkpointEpochs())
+
+	// A day with no update has no checkpoint.
+	rt.SetEpoch(3*builtin.EpochsInDay + 1)
+	actor.updateNetworkKPI(rt, &power)
+	assert.Equal(t, []abi.ChainEpoch{0, builtin.EpochsInDay + 6, 3*builtin.EpochsInDay + 2}, checkpointEpochs())
+
+	st := getState(rt)
+	checkpoints, err := st.LoadCheckpoints(rt.AdtStore())
+	require.NoError(t, err)
+	last := checkpoints[len(checkpoints)-1]
+	assert.True(t, st.ThisEpochReward.Equals(last.ThisEpochReward))
+	assert.True(t, st.CumsumRealized.Equals(last.CumsumRealized))
+	assert.Equal(t, st.EffectiveNetworkTime, last.EffectiveNetworkTime)
+
+	_, msgs := reward.CheckStateInvariants(st, rt.AdtStore(), rt.Epoch(), big.Add(reward.DefaultSimpleTotal, reward.DefaultBaselineTotal))
+	assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+
+	// Checkpoints of days skipped by null rounds are not retained from a year before.
+	rt.SetEpoch((reward.CheckpointCapacity+2)*builtin.EpochsInDay + 1)
+	actor.updateNetworkKPI(rt, &power)
+	assert.Equal(t, []abi.ChainEpoch{3*builtin.EpochsInDay + 2, (reward.CheckpointCapacity+2)*builtin.EpochsInDay + 2}, checkpointEpochs())
+
+	st = getState(rt)
+	_, msgs = reward.CheckStateInvariants(st, rt.AdtStore(), rt.Epoch(), big.Add(reward.DefaultSimpleTotal, reward.DefaultBaselineTotal))
+	assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+}
+
 type rewardHarness struct {
 	reward.Actor
 	t testing.TB
@@ -260,7 +364,7 @@ type rewardHarness struct {
 
 func (h *rewardHarness) constructAndVerify(rt *mock.Runtime, currRawPower *abi.StoragePower) {
 	rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
-	ret := rt.Call(h.Constructor, currRawPower)
+	ret := rt.Call(h.Constructor, &reward.ConstructorParams{CurrRealizedPower: *currRawPower})
 	assert.Nil(h.t, ret)
 	rt.Verify()
 
@@ -10,13 +10,24 @@ import (
 type StateSummary struct{}
 
 var FIL = big.NewInt(1e18)
+
+// The storage mining allocation of the default reward policy.
 var StorageMiningAllocationCheck = big.Mul(big.NewInt(1_100_000_000), FIL)
 
 func CheckStateInvariants(st *State, store adt.Store, priorEpoch abi.ChainEpoch, balance abi.TokenAmount) (*StateSummary, *builtin.MessageAccumulator) {
 	acc := &builtin.MessageAccumulator{}
 
 	// Can't assert equality because anyone can send funds to reward actor (and already have on mainnet)
-	acc.Require(big.Add(st.TotalStoragePowerReward, balance).GreaterThanEqual(StorageMiningAllocationCheck), "reward given %v + reward left %v < storage mining allocation %v", st.TotalStoragePowerReward, balance, StorageMiningAllocationCheck)
+	allocation := big.Add(st.Policy.SimpleTotal, st.Policy.BaselineTotal)
+	acc.Require(big.Add(st.TotalStoragePowerReward, balance).GreaterThanEqual(allocation), "reward given %v + reward left %v < storage mining allocation %v", st.TotalStoragePowerReward, balance, allocation)
+
+	if err := st.Policy.Validate(); err != nil {
+		acc.Addf("invalid reward policy: %v", err)
+	} else {
+		expected := st.Policy.Constants()
+		acc.Require(st.Constants.Lambda.Equals(expected.Lambda) && st.Constants.ExpLamSubOne.Equals(expected.ExpLamSubOne) &&
+			st.Constants.BaselineExponent.Equals(expected.BaselineExponent), "reward constants %v do not match policy %v", st.Constants, st.Policy)
+	}
 
 	acc.Require(st.Epoch == priorEpoch+1, "reward state epoch %d does not match priorEpoch+1 %d", st.Epoch, priorEpoch+1)
 	acc.Require(st.EffectiveNetworkTime <= st.Epoch, "effective network time greater than state epoch")
@@ -25,5 +36,20 @@ func CheckStateInvariants(st *State, store adt.Store, priorEpoch abi.ChainEpoch,
 	acc.Require(st.CumsumRealized.GreaterThanEqual(big.Zero()), "cumsum realized < 0")
 	acc.Require(st.EffectiveBaselinePower.LessThanEqual(st.ThisEpochBaselinePower), "effective baseline power > baseline power")
 
+	checkpoints, err := st.LoadCheckpoints(store)
+	if err != nil {
+		acc.Addf("error loading checkpoints: %v", err)
+	} else {
+		acc.Require(len(checkpoints) <= CheckpointCapacity, "%d checkpoints exceed capacity %d", len(checkpoints), CheckpointCapacity)
+		for i, checkpoint := range checkpoints {
+			acc.Require(checkpoint.Epoch <= st.Epoch, "checkpoint epoch %d after state epoch %d", checkpoint.Epoch, st.Epoch)
+			acc.Require(checkpoint.Epoch > st.Epoch-CheckpointCapacity*builtin.EpochsInDay, "checkpoint epoch %d should have been overwritten", checkpoint.Epoch)
+			if i > 0 {
+				acc.Require(checkpoint.Epoch/builtin.EpochsInDay > checkpoints[i-1].Epoch/builtin.EpochsInDay,
+					"checkpoints at epochs %d and %d in same day", checkpoints[i-1].Epoch, checkpoint.Epoch)
+			}
+		}
+	}
+
 	return &StateSummary{}, acc
 }
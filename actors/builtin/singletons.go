@@ -7,13 +7,14 @@ import (
 // Addresses for singleton system actors.
 var (
 	// Distinguished AccountActor that is the source of system implicit messages.
-	SystemActorAddr           = mustMakeAddress(0)
-	InitActorAddr             = mustMakeAddress(1)
-	RewardActorAddr           = mustMakeAddress(2)
-	CronActorAddr             = mustMakeAddress(3)
-	StoragePowerActorAddr     = mustMakeAddress(4)
-	StorageMarketActorAddr    = mustMakeAddress(5)
-	VerifiedRegistryActorAddr = mustMakeAddress(6)
+	SystemActorAddr             = mustMakeAddress(0)
+	InitActorAddr               = mustMakeAddress(1)
+	RewardActorAddr             = mustMakeAddress(2)
+	CronActorAddr               = mustMakeAddress(3)
+	StoragePowerActorAddr       = mustMakeAddress(4)
+	StorageMarketActorAddr      = mustMakeAddress(5)
+	VerifiedRegistryActorAddr   = mustMakeAddress(6)
+	StorageAskRegistryActorAddr = mustMakeAddress(7)
 	// Distinguished AccountActor that is the destination of all burnt funds.
 	BurntFundsActorAddr = mustMakeAddress(99)
 )
@@ -6,13 +6,14 @@ import (
 	"fmt"
 	"io"
 
+	abi "github.com/filecoin-project/go-state-types/abi"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	xerrors "golang.org/x/xerrors"
 )
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{131}
+var lengthBufState = []byte{135}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -42,6 +43,30 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return xerrors.Errorf("failed to write cid field t.VerifiedClients: %w", err)
 	}
 
+	// t.Allocations (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Allocations); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Allocations: %w", err)
+	}
+
+	// t.RemoveDataCapProposalIDs (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.RemoveDataCapProposalIDs); err != nil {
+		return xerrors.Errorf("failed to write cid field t.RemoveDataCapProposalIDs: %w", err)
+	}
+
+	// t.VerifierInfos (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.VerifierInfos); err != nil {
+		return xerrors.Errorf("failed to write cid field t.VerifierInfos: %w", err)
+	}
+
+	// t.GrantLog (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.GrantLog); err != nil {
+		return xerrors.Errorf("failed to write cid field t.GrantLog: %w", err)
+	}
+
 	return nil
 }
 
@@ -59,7 +84,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 3 {
+	if extra != 7 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -95,6 +120,1110 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 
 		t.VerifiedClients = c
 
+	}
+	// t.Allocations (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Allocations: %w", err)
+		}
+
+		t.Allocations = c
+
+	}
+	// t.RemoveDataCapProposalIDs (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.RemoveDataCapProposalIDs: %w", err)
+		}
+
+		t.RemoveDataCapProposalIDs = c
+
+	}
+	// t.VerifierInfos (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.VerifierInfos: %w", err)
+		}
+
+		t.VerifierInfos = c
+
+	}
+	// t.GrantLog (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.GrantLog: %w", err)
+		}
+
+		t.GrantLog = c
+
+	}
+	return nil
+}
+
+var lengthBufAllocation = []byte{133}
+
+func (t *Allocation) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufAllocation); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Granted (big.Int) (struct)
+	if err := t.Granted.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Used (big.Int) (struct)
+	if err := t.Used.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Revoked (big.Int) (struct)
+	if err := t.Revoked.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Expiration (abi.ChainEpoch) (int64)
+	if t.Expiration >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Expiration)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Expiration-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.LastGrant (abi.ChainEpoch) (int64)
+	if t.LastGrant >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.LastGrant)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.LastGrant-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Allocation) UnmarshalCBOR(r io.Reader) error {
+	*t = Allocation{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 5 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Granted (big.Int) (struct)
+
+	{
+
+		if err := t.Granted.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Granted: %w", err)
+		}
+
+	}
+	// t.Used (big.Int) (struct)
+
+	{
+
+		if err := t.Used.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Used: %w", err)
+		}
+
+	}
+	// t.Revoked (big.Int) (struct)
+
+	{
+
+		if err := t.Revoked.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Revoked: %w", err)
+		}
+
+	}
+	// t.Expiration (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Expiration = abi.ChainEpoch(extraI)
+	}
+	// t.LastGrant (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.LastGrant = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufRmDcProposalID = []byte{129}
+
+func (t *RmDcProposalID) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRmDcProposalID); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.ProposalID (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ProposalID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *RmDcProposalID) UnmarshalCBOR(r io.Reader) error {
+	*t = RmDcProposalID{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.ProposalID (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.ProposalID = uint64(extra)
+
+	}
+	return nil
+}
+
+var lengthBufVerifierInfo = []byte{132}
+
+func (t *VerifierInfo) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufVerifierInfo); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Allowance (big.Int) (struct)
+	if err := t.Allowance.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.GrantLogStart (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.GrantLogStart)); err != nil {
+		return err
+	}
+
+	// t.Policy (verifreg.VerifierPolicy) (struct)
+	if err := t.Policy.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.RecentGrants ([]verifreg.RecentGrant) (slice)
+	if len(t.RecentGrants) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.RecentGrants was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.RecentGrants))); err != nil {
+		return err
+	}
+	for _, v := range t.RecentGrants {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *VerifierInfo) UnmarshalCBOR(r io.Reader) error {
+	*t = VerifierInfo{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Allowance (big.Int) (struct)
+
+	{
+
+		if err := t.Allowance.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Allowance: %w", err)
+		}
+
+	}
+	// t.GrantLogStart (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.GrantLogStart = uint64(extra)
+
+	}
+	// t.Policy (verifreg.VerifierPolicy) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Policy = new(VerifierPolicy)
+			if err := t.Policy.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Policy pointer: %w", err)
+			}
+		}
+
+	}
+	// t.RecentGrants ([]verifreg.RecentGrant) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.RecentGrants: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.RecentGrants = make([]RecentGrant, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v RecentGrant
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.RecentGrants[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufGrant = []byte{132}
+
+func (t *Grant) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGrant); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Verifier (address.Address) (struct)
+	if err := t.Verifier.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Client (address.Address) (struct)
+	if err := t.Client.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	if t.Epoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Epoch-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Grant) UnmarshalCBOR(r io.Reader) error {
+	*t = Grant{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Verifier (address.Address) (struct)
+
+	{
+
+		if err := t.Verifier.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Verifier: %w", err)
+		}
+
+	}
+	// t.Client (address.Address) (struct)
+
+	{
+
+		if err := t.Client.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Client: %w", err)
+		}
+
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	// t.Epoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Epoch = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufAddVerifierWithPolicyParams = []byte{131}
+
+func (t *AddVerifierWithPolicyParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufAddVerifierWithPolicyParams); err != nil {
+		return err
+	}
+
+	// t.Address (address.Address) (struct)
+	if err := t.Address.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Allowance (big.Int) (struct)
+	if err := t.Allowance.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Policy (verifreg.VerifierPolicy) (struct)
+	if err := t.Policy.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *AddVerifierWithPolicyParams) UnmarshalCBOR(r io.Reader) error {
+	*t = AddVerifierWithPolicyParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Address (address.Address) (struct)
+
+	{
+
+		if err := t.Address.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Address: %w", err)
+		}
+
+	}
+	// t.Allowance (big.Int) (struct)
+
+	{
+
+		if err := t.Allowance.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Allowance: %w", err)
+		}
+
+	}
+	// t.Policy (verifreg.VerifierPolicy) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Policy = new(VerifierPolicy)
+			if err := t.Policy.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Policy pointer: %w", err)
+			}
+		}
+
+	}
+	return nil
+}
+
+var lengthBufRemoveDataCapParams = []byte{132}
+
+func (t *RemoveDataCapParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRemoveDataCapParams); err != nil {
+		return err
+	}
+
+	// t.VerifiedClientToRemove (address.Address) (struct)
+	if err := t.VerifiedClientToRemove.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.DataCapAmountToRemove (big.Int) (struct)
+	if err := t.DataCapAmountToRemove.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.VerifierRequest1 (verifreg.RemoveDataCapRequest) (struct)
+	if err := t.VerifierRequest1.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.VerifierRequest2 (verifreg.RemoveDataCapRequest) (struct)
+	if err := t.VerifierRequest2.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RemoveDataCapParams) UnmarshalCBOR(r io.Reader) error {
+	*t = RemoveDataCapParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.VerifiedClientToRemove (address.Address) (struct)
+
+	{
+
+		if err := t.VerifiedClientToRemove.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.VerifiedClientToRemove: %w", err)
+		}
+
+	}
+	// t.DataCapAmountToRemove (big.Int) (struct)
+
+	{
+
+		if err := t.DataCapAmountToRemove.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.DataCapAmountToRemove: %w", err)
+		}
+
+	}
+	// t.VerifierRequest1 (verifreg.RemoveDataCapRequest) (struct)
+
+	{
+
+		if err := t.VerifierRequest1.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.VerifierRequest1: %w", err)
+		}
+
+	}
+	// t.VerifierRequest2 (verifreg.RemoveDataCapRequest) (struct)
+
+	{
+
+		if err := t.VerifierRequest2.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.VerifierRequest2: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufRemoveDataCapReturn = []byte{130}
+
+func (t *RemoveDataCapReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRemoveDataCapReturn); err != nil {
+		return err
+	}
+
+	// t.VerifiedClient (address.Address) (struct)
+	if err := t.VerifiedClient.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.DataCapRemoved (big.Int) (struct)
+	if err := t.DataCapRemoved.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RemoveDataCapReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = RemoveDataCapReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.VerifiedClient (address.Address) (struct)
+
+	{
+
+		if err := t.VerifiedClient.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.VerifiedClient: %w", err)
+		}
+
+	}
+	// t.DataCapRemoved (big.Int) (struct)
+
+	{
+
+		if err := t.DataCapRemoved.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.DataCapRemoved: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufRemoveDataCapProposal = []byte{131}
+
+func (t *RemoveDataCapProposal) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRemoveDataCapProposal); err != nil {
+		return err
+	}
+
+	// t.VerifiedClient (address.Address) (struct)
+	if err := t.VerifiedClient.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.DataCapAmount (big.Int) (struct)
+	if err := t.DataCapAmount.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.RemovalProposalID (verifreg.RmDcProposalID) (struct)
+	if err := t.RemovalProposalID.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RemoveDataCapProposal) UnmarshalCBOR(r io.Reader) error {
+	*t = RemoveDataCapProposal{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.VerifiedClient (address.Address) (struct)
+
+	{
+
+		if err := t.VerifiedClient.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.VerifiedClient: %w", err)
+		}
+
+	}
+	// t.DataCapAmount (big.Int) (struct)
+
+	{
+
+		if err := t.DataCapAmount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.DataCapAmount: %w", err)
+		}
+
+	}
+	// t.RemovalProposalID (verifreg.RmDcProposalID) (struct)
+
+	{
+
+		if err := t.RemovalProposalID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RemovalProposalID: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufRemoveDataCapRequest = []byte{130}
+
+func (t *RemoveDataCapRequest) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRemoveDataCapRequest); err != nil {
+		return err
+	}
+
+	// t.Verifier (address.Address) (struct)
+	if err := t.Verifier.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.VerifierSignature (crypto.Signature) (struct)
+	if err := t.VerifierSignature.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RemoveDataCapRequest) UnmarshalCBOR(r io.Reader) error {
+	*t = RemoveDataCapRequest{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Verifier (address.Address) (struct)
+
+	{
+
+		if err := t.Verifier.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Verifier: %w", err)
+		}
+
+	}
+	// t.VerifierSignature (crypto.Signature) (struct)
+
+	{
+
+		if err := t.VerifierSignature.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.VerifierSignature: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufVerifierPolicy = []byte{132}
+
+func (t *VerifierPolicy) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufVerifierPolicy); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.MaxClientGrant (big.Int) (struct)
+	if err := t.MaxClientGrant.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.WindowAllowance (big.Int) (struct)
+	if err := t.WindowAllowance.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.WindowDuration (abi.ChainEpoch) (int64)
+	if t.WindowDuration >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.WindowDuration)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.WindowDuration-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.ClientCooldown (abi.ChainEpoch) (int64)
+	if t.ClientCooldown >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ClientCooldown)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ClientCooldown-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *VerifierPolicy) UnmarshalCBOR(r io.Reader) error {
+	*t = VerifierPolicy{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.MaxClientGrant (big.Int) (struct)
+
+	{
+
+		if err := t.MaxClientGrant.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.MaxClientGrant: %w", err)
+		}
+
+	}
+	// t.WindowAllowance (big.Int) (struct)
+
+	{
+
+		if err := t.WindowAllowance.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.WindowAllowance: %w", err)
+		}
+
+	}
+	// t.WindowDuration (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.WindowDuration = abi.ChainEpoch(extraI)
+	}
+	// t.ClientCooldown (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ClientCooldown = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufRecentGrant = []byte{130}
+
+func (t *RecentGrant) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRecentGrant); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	if t.Epoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Epoch-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RecentGrant) UnmarshalCBOR(r io.Reader) error {
+	*t = RecentGrant{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Epoch = abi.ChainEpoch(extraI)
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
 	}
 	return nil
 }
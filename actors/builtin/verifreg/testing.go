@@ -10,8 +10,10 @@ import (
 )
 
 type StateSummary struct {
-	Verifiers map[addr.Address]DataCap
-	Clients   map[addr.Address]DataCap
+	Verifiers   map[addr.Address]DataCap
+	Clients     map[addr.Address]DataCap
+	Allocations map[addr.Address][]*ClientAllocation
+	Grants      uint64
 }
 
 // Checks internal invariants of verified registry state.
@@ -58,6 +60,99 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 		acc.RequireNoError(err, "error iterating clients")
 	}
 
+	// Check allocations
+	allAllocations := map[addr.Address][]*ClientAllocation{}
+	if allocations, err := adt.AsMap(store, st.Allocations, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading allocations: %v", err)
+	} else {
+		err = allocations.ForEach(nil, func(key string) error {
+			client, err := addr.NewFromBytes([]byte(key))
+			if err != nil {
+				return err
+			}
+			acc.Require(client.Protocol() == addr.ID, "allocation client %v should have ID protocol", client)
+			allocs, err := st.ClientAllocations(store, client)
+			if err != nil {
+				return err
+			}
+			for _, alloc := range allocs {
+				acc.Require(alloc.Verifier.Protocol() == addr.ID, "allocation verifier %v should have ID protocol", alloc.Verifier)
+				acc.Require(alloc.Granted.GreaterThan(big.Zero()), "allocation by %v to %v grants non-positive %v", alloc.Verifier, client, alloc.Granted)
+				acc.Require(alloc.Used.GreaterThanEqual(big.Zero()), "allocation by %v to %v used is negative %v", alloc.Verifier, client, alloc.Used)
+				acc.Require(alloc.Revoked.GreaterThanEqual(big.Zero()), "allocation by %v to %v revoked is negative %v", alloc.Verifier, client, alloc.Revoked)
+				acc.Require(alloc.Remaining().GreaterThanEqual(big.Zero()), "allocation by %v to %v used %v and revoked %v exceed granted %v",
+					alloc.Verifier, client, alloc.Used, alloc.Revoked, alloc.Granted)
+			}
+			allAllocations[client] = allocs
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating allocations")
+	}
+
+	// Check verifier infos
+	allInfos := map[addr.Address]*VerifierInfo{}
+	if infos, err := adt.AsMap(store, st.VerifierInfos, builtin.DefaultHamtBitwidth); err != nil {
+		acc.Addf("error loading verifier infos: %v", err)
+	} else {
+		var info VerifierInfo
+		err = infos.ForEach(&info, func(key string) error {
+			verifier, err := addr.NewFromBytes([]byte(key))
+			if err != nil {
+				return err
+			}
+			_, found := allVerifiers[verifier]
+			acc.Require(found, "info for %v which is not a verifier", verifier)
+			if info.Policy != nil && info.Policy.WindowAllowance.GreaterThan(big.Zero()) {
+				acc.Require(info.recentlyGranted().LessThanEqual(info.Policy.WindowAllowance),
+					"verifier %v recent grants %v exceed window allowance %v", verifier, info.recentlyGranted(), info.Policy.WindowAllowance)
+			} else {
+				acc.Require(len(info.RecentGrants) == 0, "verifier %v without window policy has recent grants", verifier)
+			}
+			infoCopy := info
+			allInfos[verifier] = &infoCopy
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating verifier infos")
+	}
+
+	// Check the grant log accounts for each verifier's allowance spent.
+	granted := map[addr.Address]DataCap{}
+	var grantCount uint64
+	if grantLog, err := adt.AsArray(store, st.GrantLog, GrantLogAmtBitwidth); err != nil {
+		acc.Addf("error loading grant log: %v", err)
+	} else {
+		grantCount = grantLog.Length()
+		var grant Grant
+		err = grantLog.ForEach(&grant, func(i int64) error {
+			acc.Require(grant.Verifier.Protocol() == addr.ID, "grant %d verifier %v should have ID protocol", i, grant.Verifier)
+			acc.Require(grant.Client.Protocol() == addr.ID, "grant %d client %v should have ID protocol", i, grant.Client)
+			acc.Require(grant.Amount.GreaterThanEqual(MinVerifiedDealSize), "grant %d amount %v below minimum", i, grant.Amount)
+			if info, found := allInfos[grant.Verifier]; found && uint64(i) >= info.GrantLogStart {
+				prev, ok := granted[grant.Verifier]
+				if !ok {
+					prev = big.Zero()
+				}
+				granted[grant.Verifier] = big.Add(prev, grant.Amount)
+			}
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating grant log")
+	}
+	for verifier, vcap := range allVerifiers { //nolint:nomaprange
+		info, found := allInfos[verifier]
+		if !found {
+			acc.Addf("verifier %v has no info", verifier)
+			continue
+		}
+		acc.Require(info.GrantLogStart <= grantCount, "verifier %v grant log start %d beyond log length %d", verifier, info.GrantLogStart, grantCount)
+		spent, ok := granted[verifier]
+		if !ok {
+			spent = big.Zero()
+		}
+		acc.Require(big.Sub(info.Allowance, spent).Equals(vcap), "verifier %v allowance %v less logged grants %v does not match cap %v",
+			verifier, info.Allowance, spent, vcap)
+	}
+
 	// Check verifiers and clients are disjoint.
 	for v := range allVerifiers { //nolint:nomaprange
 		_, found := allClients[v]
@@ -66,7 +161,9 @@ func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.M
 	// No need to iterate all clients; any overlap must have been one of all verifiers.
 
 	return &StateSummary{
-		Verifiers: allVerifiers,
-		Clients:   allClients,
+		Verifiers:   allVerifiers,
+		Clients:     allClients,
+		Allocations: allAllocations,
+		Grants:      grantCount,
 	}, acc
 }
@@ -1,9 +1,12 @@
 package verifreg
 
 import (
+	"bytes"
+
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/ipfs/go-cid"
 
 	"github.com/filecoin-project/go-state-types/big"
@@ -25,6 +28,9 @@ func (a Actor) Exports() []interface{} {
 		4:                         a.AddVerifiedClient,
 		5:                         a.UseBytes,
 		6:                         a.RestoreBytes,
+		7:                         a.RemoveVerifiedClientDataCap,
+		8:                         a.RemoveExpiredDataCap,
+		9:                         a.AddVerifierWithPolicy,
 	}
 }
 
@@ -65,10 +71,30 @@ func (a Actor) Constructor(rt runtime.Runtime, rootKey *addr.Address) *abi.Empty
 //}
 type AddVerifierParams = verifreg0.AddVerifierParams
 
+type AddVerifierWithPolicyParams struct {
+	Address   addr.Address
+	Allowance DataCap
+	Policy    *VerifierPolicy // Optional limits on the verifier's grants.
+}
+
+// Adds a verifier with an allowance of DataCap and no limits on its grants, or resets the allowance of an
+// existing verifier and removes its policy.
 func (a Actor) AddVerifier(rt runtime.Runtime, params *AddVerifierParams) *abi.EmptyValue {
+	return a.addVerifier(rt, &AddVerifierWithPolicyParams{Address: params.Address, Allowance: params.Allowance})
+}
+
+// Adds a verifier with an allowance of DataCap, or resets the allowance and policy of an existing verifier.
+func (a Actor) AddVerifierWithPolicy(rt runtime.Runtime, params *AddVerifierWithPolicyParams) *abi.EmptyValue {
+	return a.addVerifier(rt, params)
+}
+
+func (a Actor) addVerifier(rt runtime.Runtime, params *AddVerifierWithPolicyParams) *abi.EmptyValue {
 	if params.Allowance.LessThan(MinVerifiedDealSize) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "Allowance %d below MinVerifiedDealSize for add verifier %v", params.Allowance, params.Address)
 	}
+	if params.Policy != nil {
+		validateVerifierPolicy(rt, params.Policy)
+	}
 
 	verifier, err := builtin.ResolveToIDAddr(rt, params.Address)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve verifier address %v to ID address", params.Address)
@@ -99,11 +125,44 @@ func (a Actor) AddVerifier(rt runtime.Runtime, params *AddVerifierParams) *abi.E
 
 		st.Verifiers, err = verifiers.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verifiers")
+
+		// Subsequent grants draw from the new allowance.
+		// Recent grants are retained, so re-adding a verifier does not reset its rolling window.
+		infos, err := adt.AsMap(adt.AsStore(rt), st.VerifierInfos, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verifier infos")
+		var info VerifierInfo
+		_, err = infos.Get(abi.AddrKey(verifier), &info)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verifier info for %v", verifier)
+
+		grantLog, err := adt.AsArray(adt.AsStore(rt), st.GrantLog, GrantLogAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load grant log")
+
+		info.Allowance = params.Allowance
+		info.GrantLogStart = grantLog.Length()
+		info.Policy = params.Policy
+		info.pruneRecentGrants(rt.CurrEpoch())
+		err = infos.Put(abi.AddrKey(verifier), &info)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put verifier info for %v", verifier)
+
+		st.VerifierInfos, err = infos.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verifier infos")
 	})
 
 	return nil
 }
 
+func validateVerifierPolicy(rt runtime.Runtime, policy *VerifierPolicy) {
+	if policy.MaxClientGrant.LessThan(big.Zero()) || policy.WindowAllowance.LessThan(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "verifier policy limits must be non-negative")
+	}
+	if policy.WindowDuration < 0 || policy.ClientCooldown < 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "verifier policy durations must be non-negative")
+	}
+	if policy.WindowAllowance.GreaterThan(big.Zero()) && policy.WindowDuration == 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "verifier policy window allowance requires a window duration")
+	}
+}
+
 func (a Actor) RemoveVerifier(rt runtime.Runtime, verifierAddr *addr.Address) *abi.EmptyValue {
 	verifier, err := builtin.ResolveToIDAddr(rt, *verifierAddr)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve verifier address %v to ID address", *verifierAddr)
@@ -122,6 +181,13 @@ func (a Actor) RemoveVerifier(rt runtime.Runtime, verifierAddr *addr.Address) *a
 
 		st.Verifiers, err = verifiers.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verifiers")
+
+		infos, err := adt.AsMap(adt.AsStore(rt), st.VerifierInfos, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verifier infos")
+		err = infos.Delete(abi.AddrKey(verifier))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to remove verifier info for %v", verifier)
+		st.VerifierInfos, err = infos.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verifier infos")
 	})
 
 	return nil
@@ -177,6 +243,26 @@ func (a Actor) AddVerifiedClient(rt runtime.Runtime, params *AddVerifiedClientPa
 		if verifierCap.LessThan(params.Allowance) {
 			rt.Abortf(exitcode.ErrIllegalArgument, "add more DataCap (%d) for VerifiedClient than allocated %d", params.Allowance, verifierCap)
 		}
+
+		// Enforce the verifier's policy and log the grant.
+		infos, err := adt.AsMap(adt.AsStore(rt), st.VerifierInfos, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verifier infos")
+		var info VerifierInfo
+		found, err = infos.Get(abi.AddrKey(verifier), &info)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verifier info for %v", verifier)
+		builtin.RequireState(rt, found, "no info for verifier %v", verifier)
+		enforceVerifierPolicy(rt, &info, params.Allowance)
+		err = infos.Put(abi.AddrKey(verifier), &info)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put verifier info for %v", verifier)
+		st.VerifierInfos, err = infos.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verifier infos")
+
+		grantLog, err := adt.AsArray(adt.AsStore(rt), st.GrantLog, GrantLogAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load grant log")
+		err = grantLog.AppendContinuous(&Grant{Verifier: verifier, Client: client, Amount: params.Allowance, Epoch: rt.CurrEpoch()})
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to append to grant log")
+		st.GrantLog, err = grantLog.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush grant log")
 		newVerifierCap := big.Sub(verifierCap, params.Allowance)
 
 		err = verifiers.Put(abi.AddrKey(verifier), &newVerifierCap)
@@ -195,6 +281,21 @@ func (a Actor) AddVerifiedClient(rt runtime.Runtime, params *AddVerifiedClientPa
 		err = verifiedClients.Put(abi.AddrKey(client), &params.Allowance)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to add verified client %v with cap %d", client, params.Allowance)
 
+		// Record the allocation, renewing any prior allocation by the verifier to the client.
+		alloc, found, err := st.GetAllocation(adt.AsStore(rt), verifier, client)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allocation")
+		if !found {
+			alloc = &Allocation{Granted: big.Zero(), Used: big.Zero(), Revoked: big.Zero()}
+		} else if info.Policy != nil && rt.CurrEpoch() < alloc.LastGrant+info.Policy.ClientCooldown {
+			rt.Abortf(exitcode.ErrForbidden, "verifier %v cannot grant to %v again until epoch %d", verifier, client,
+				alloc.LastGrant+info.Policy.ClientCooldown)
+		}
+		alloc.Granted = big.Add(alloc.Granted, params.Allowance)
+		alloc.Expiration = rt.CurrEpoch() + DataCapAllocationLifetime
+		alloc.LastGrant = rt.CurrEpoch()
+		err = st.PutClientAllocations(adt.AsStore(rt), client, []*ClientAllocation{{Verifier: verifier, Allocation: *alloc}})
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record allocation")
+
 		st.Verifiers, err = verifiers.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verifiers")
 
@@ -205,6 +306,25 @@ func (a Actor) AddVerifiedClient(rt runtime.Runtime, params *AddVerifiedClientPa
 	return nil
 }
 
+// Checks a grant against a verifier's policy, recording it against the policy's rolling window.
+func enforceVerifierPolicy(rt runtime.Runtime, info *VerifierInfo, amount DataCap) {
+	info.pruneRecentGrants(rt.CurrEpoch())
+	if info.Policy == nil {
+		return
+	}
+	if info.Policy.MaxClientGrant.GreaterThan(big.Zero()) && amount.GreaterThan(info.Policy.MaxClientGrant) {
+		rt.Abortf(exitcode.ErrForbidden, "grant %v exceeds verifier's maximum grant per client %v", amount, info.Policy.MaxClientGrant)
+	}
+	if info.Policy.WindowAllowance.GreaterThan(big.Zero()) {
+		windowTotal := big.Add(info.recentlyGranted(), amount)
+		if windowTotal.GreaterThan(info.Policy.WindowAllowance) {
+			rt.Abortf(exitcode.ErrForbidden, "grant %v would exceed verifier's allowance %v per %d epochs, having granted %v",
+				amount, info.Policy.WindowAllowance, info.Policy.WindowDuration, info.recentlyGranted())
+		}
+		info.RecentGrants = append(info.RecentGrants, RecentGrant{Epoch: rt.CurrEpoch(), Amount: amount})
+	}
+}
+
 //type UseBytesParams struct {
 //	Address  addr.Address     // Address of verified client.
 //	DealSize abi.StoragePower // Number of bytes to use.
@@ -214,6 +334,7 @@ type UseBytesParams = verifreg0.UseBytesParams
 // Called by StorageMarketActor during PublishStorageDeals.
 // Do not allow partially verified deals (DealSize must be greater than equal to allowed cap).
 // Delete VerifiedClient if remaining DataCap is smaller than minimum VerifiedDealSize.
+// DataCap of expired allocations is lost before the deal is admitted.
 func (a Actor) UseBytes(rt runtime.Runtime, params *UseBytesParams) *abi.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.StorageMarketActorAddr)
 
@@ -237,11 +358,16 @@ func (a Actor) UseBytes(rt runtime.Runtime, params *UseBytesParams) *abi.EmptyVa
 		}
 		builtin.RequireState(rt, vcCap.GreaterThanEqual(big.Zero()), "negative cap for client %v: %v", client, vcCap)
 
+		allocs, err := st.ClientAllocations(adt.AsStore(rt), client)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allocations to %v", client)
+		vcCap = big.Sub(vcCap, big.Min(vcCap, expireAllocations(allocs, rt.CurrEpoch())))
+
 		if params.DealSize.GreaterThan(vcCap) {
 			rt.Abortf(exitcode.ErrIllegalArgument, "DealSize %d exceeds allowable cap: %d for VerifiedClient %v", params.DealSize, vcCap, client)
 		}
 
 		newVcCap := big.Sub(vcCap, params.DealSize)
+		useAllocations(allocs, rt.CurrEpoch(), params.DealSize)
 		if newVcCap.LessThan(MinVerifiedDealSize) {
 			// Delete entry if remaining DataCap is less than MinVerifiedDealSize.
 			// Will be restored later if the deal did not get activated with a ProvenSector.
@@ -250,11 +376,15 @@ func (a Actor) UseBytes(rt runtime.Runtime, params *UseBytesParams) *abi.EmptyVa
 			// See: https://github.com/filecoin-project/specs-actors/issues/727
 			err = verifiedClients.Delete(abi.AddrKey(client))
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete verified client %v", client)
+			revokeAllocations(allocs, newVcCap)
 		} else {
 			err = verifiedClients.Put(abi.AddrKey(client), &newVcCap)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update verified client %v with %v", client, newVcCap)
 		}
 
+		err = st.PutClientAllocations(adt.AsStore(rt), client, allocs)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update allocations to %v", client)
+
 		st.VerifiedClients, err = verifiedClients.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verified clients")
 	})
@@ -307,9 +437,17 @@ func (a Actor) RestoreBytes(rt runtime.Runtime, params *RestoreBytesParams) *abi
 			vcCap = big.Zero()
 		}
 
-		newVcCap := big.Add(vcCap, params.DealSize)
-		err = verifiedClients.Put(abi.AddrKey(client), &newVcCap)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put verified client %v with %v", client, newVcCap)
+		allocs, err := st.ClientAllocations(adt.AsStore(rt), client)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allocations to %v", client)
+		expired := restoreAllocations(allocs, rt.CurrEpoch(), params.DealSize)
+		err = st.PutClientAllocations(adt.AsStore(rt), client, allocs)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update allocations to %v", client)
+
+		// DataCap restored to expired allocations is revoked rather than returned to the client.
+		newVcCap := big.Add(vcCap, big.Sub(params.DealSize, expired))
+		if found || !newVcCap.IsZero() {
+			putClientCap(rt, verifiedClients, client, newVcCap)
+		}
 
 		st.VerifiedClients, err = verifiedClients.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verifiers")
@@ -317,3 +455,174 @@ func (a Actor) RestoreBytes(rt runtime.Runtime, params *RestoreBytesParams) *abi
 
 	return nil
 }
+
+const SignatureDomainSeparation_RemoveDataCap = "fil_removedatacap:"
+
+// A proposal by a verifier to remove DataCap from a client, signed by the verifier.
+type RemoveDataCapProposal struct {
+	VerifiedClient    addr.Address
+	DataCapAmount     DataCap
+	RemovalProposalID RmDcProposalID
+}
+
+type RemoveDataCapRequest struct {
+	Verifier          addr.Address
+	VerifierSignature crypto.Signature
+}
+
+type RemoveDataCapParams struct {
+	VerifiedClientToRemove addr.Address
+	DataCapAmountToRemove  DataCap
+	VerifierRequest1       RemoveDataCapRequest
+	VerifierRequest2       RemoveDataCapRequest
+}
+
+type RemoveDataCapReturn struct {
+	VerifiedClient addr.Address
+	DataCapRemoved DataCap
+}
+
+// Removes unused DataCap from a verified client, as proposed by two verifiers.
+// Each verifier signs a proposal with the next removal proposal ID recorded for it and the client.
+// Removes at most the client's DataCap, deleting the client if none remains.
+func (a Actor) RemoveVerifiedClientDataCap(rt runtime.Runtime, params *RemoveDataCapParams) *RemoveDataCapReturn {
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.RootKey)
+
+	if params.DataCapAmountToRemove.LessThanEqual(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "DataCap to remove %v must be positive", params.DataCapAmountToRemove)
+	}
+
+	client, err := builtin.ResolveToIDAddr(rt, params.VerifiedClientToRemove)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve client address %v", params.VerifiedClientToRemove)
+	verifier1, err := builtin.ResolveToIDAddr(rt, params.VerifierRequest1.Verifier)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve verifier address %v", params.VerifierRequest1.Verifier)
+	verifier2, err := builtin.ResolveToIDAddr(rt, params.VerifierRequest2.Verifier)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve verifier address %v", params.VerifierRequest2.Verifier)
+	if verifier1 == verifier2 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "need two different verifiers to send remove DataCap request")
+	}
+
+	removed := big.Zero()
+	rt.StateTransaction(&st, func() {
+		verifiers, err := adt.AsMap(adt.AsStore(rt), st.Verifiers, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verifiers")
+		for _, verifier := range []addr.Address{verifier1, verifier2} {
+			found, err := verifiers.Get(abi.AddrKey(verifier), nil)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verifier %v", verifier)
+			if !found {
+				rt.Abortf(exitcode.ErrNotFound, "%v is not a verifier", verifier)
+			}
+		}
+
+		proposalIDs, err := adt.AsMap(adt.AsStore(rt), st.RemoveDataCapProposalIDs, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load datacap removal proposal ids")
+		useProposalID(rt, proposalIDs, verifier1, client, params.VerifierRequest1.VerifierSignature, params.DataCapAmountToRemove)
+		useProposalID(rt, proposalIDs, verifier2, client, params.VerifierRequest2.VerifierSignature, params.DataCapAmountToRemove)
+		st.RemoveDataCapProposalIDs, err = proposalIDs.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush datacap removal proposal ids")
+
+		verifiedClients, err := adt.AsMap(adt.AsStore(rt), st.VerifiedClients, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verified clients")
+		var vcCap DataCap
+		found, err := verifiedClients.Get(abi.AddrKey(client), &vcCap)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verified client %v", client)
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "%v is not a verified client", client)
+		}
+
+		removed = big.Min(vcCap, params.DataCapAmountToRemove)
+		putClientCap(rt, verifiedClients, client, big.Sub(vcCap, removed))
+		st.VerifiedClients, err = verifiedClients.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verified clients")
+
+		allocs, err := st.ClientAllocations(adt.AsStore(rt), client)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allocations to %v", client)
+		revokeAllocations(allocs, removed)
+		err = st.PutClientAllocations(adt.AsStore(rt), client, allocs)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update allocations to %v", client)
+	})
+
+	return &RemoveDataCapReturn{
+		VerifiedClient: client,
+		DataCapRemoved: removed,
+	}
+}
+
+// Removes the unused DataCap of a verified client's expired allocations, deleting the client if none remains.
+// May be invoked by anyone.
+func (a Actor) RemoveExpiredDataCap(rt runtime.Runtime, clientAddr *addr.Address) *RemoveDataCapReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	client, err := builtin.ResolveToIDAddr(rt, *clientAddr)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to resolve client address %v", *clientAddr)
+
+	removed := big.Zero()
+	var st State
+	rt.StateTransaction(&st, func() {
+		verifiedClients, err := adt.AsMap(adt.AsStore(rt), st.VerifiedClients, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load verified clients")
+		var vcCap DataCap
+		found, err := verifiedClients.Get(abi.AddrKey(client), &vcCap)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get verified client %v", client)
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "%v is not a verified client", client)
+		}
+
+		allocs, err := st.ClientAllocations(adt.AsStore(rt), client)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allocations to %v", client)
+		removed = big.Min(vcCap, expireAllocations(allocs, rt.CurrEpoch()))
+		if removed.IsZero() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "%v has no expired DataCap", client)
+		}
+		err = st.PutClientAllocations(adt.AsStore(rt), client, allocs)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update allocations to %v", client)
+
+		putClientCap(rt, verifiedClients, client, big.Sub(vcCap, removed))
+		st.VerifiedClients, err = verifiedClients.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush verified clients")
+	})
+
+	return &RemoveDataCapReturn{
+		VerifiedClient: client,
+		DataCapRemoved: removed,
+	}
+}
+
+// Verifies a verifier's signature over a proposal to remove a client's DataCap, with the next
+// proposal ID for the verifier and client, and consumes the ID.
+func useProposalID(rt runtime.Runtime, proposalIDs *adt.Map, verifier, client addr.Address, signature crypto.Signature, amount DataCap) {
+	key := NewAddrPairKey(verifier, client)
+	var id RmDcProposalID
+	found, err := proposalIDs.Get(key, &id)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get datacap removal proposal id for %v and %v", verifier, client)
+	if !found {
+		id = RmDcProposalID{ProposalID: 0}
+	}
+
+	proposal := RemoveDataCapProposal{
+		VerifiedClient:    client,
+		DataCapAmount:     amount,
+		RemovalProposalID: id,
+	}
+	buf := bytes.NewBufferString(SignatureDomainSeparation_RemoveDataCap)
+	err = proposal.MarshalCBOR(buf)
+	builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to serialize datacap removal proposal")
+	err = rt.VerifySignature(signature, verifier, buf.Bytes())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalArgument, "invalid signature for datacap removal proposal by %v", verifier)
+
+	err = proposalIDs.Put(key, &RmDcProposalID{ProposalID: id.ProposalID + 1})
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update datacap removal proposal id for %v and %v", verifier, client)
+}
+
+// Stores a client's DataCap, deleting the client if none remains.
+func putClientCap(rt runtime.Runtime, verifiedClients *adt.Map, client addr.Address, vcCap DataCap) {
+	if vcCap.IsZero() {
+		err := verifiedClients.Delete(abi.AddrKey(client))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete verified client %v", client)
+		return
+	}
+	err := verifiedClients.Put(abi.AddrKey(client), &vcCap)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update verified client %v with %v", client, vcCap)
+}
@@ -1,9 +1,14 @@
 package verifreg
 
 import (
+	"bytes"
+	"sort"
+
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 	cid "github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
@@ -25,10 +30,101 @@ type State struct {
 
 	// VerifiedClients can add VerifiedClientData, up to DataCap.
 	VerifiedClients cid.Cid // HAMT[addr.Address]DataCap
+
+	// Record of the DataCap allocated to each verified client by each verifier, and its use.
+	// A client's DataCap in VerifiedClients is authoritative; allocations account for its origin.
+	Allocations cid.Cid // HAMT[addr.Address(client)]HAMT[addr.Address(verifier)]Allocation
+
+	// Next ID expected in a proposal signed by a verifier to remove a client's DataCap.
+	// Prevents replay of signed removal proposals.
+	RemoveDataCapProposalIDs cid.Cid // HAMT[AddrPairKey(verifier, client)]RmDcProposalID
+
+	// Each verifier's allowance, policy and recent grants.
+	VerifierInfos cid.Cid // HAMT[addr.Address]VerifierInfo
+
+	// Log of grants of DataCap by verifiers to clients, in order.
+	GrantLog cid.Cid // AMT[uint64]Grant
+}
+
+// Optional limits on the DataCap a verifier grants.
+type VerifierPolicy struct {
+	MaxClientGrant  DataCap        // Maximum DataCap granted to a client at once. Zero for no limit.
+	WindowAllowance DataCap        // Maximum DataCap granted within any WindowDuration epochs. Zero for no limit.
+	WindowDuration  abi.ChainEpoch // Duration of the rolling window limited by WindowAllowance.
+	ClientCooldown  abi.ChainEpoch // Minimum epochs between grants to the same client.
+}
+
+type VerifierInfo struct {
+	// DataCap allowance set when the verifier was last added by the root key.
+	Allowance DataCap
+	// Index of the first entry in the grant log drawn from Allowance.
+	GrantLogStart uint64
+	// Limits on the verifier's grants. Nil for no limits.
+	Policy *VerifierPolicy
+	// Grants by the verifier within the latest window of its policy, oldest first.
+	RecentGrants []RecentGrant
+}
+
+type RecentGrant struct {
+	Epoch  abi.ChainEpoch
+	Amount DataCap
+}
+
+// An entry in the grant log.
+type Grant struct {
+	Verifier addr.Address
+	Client   addr.Address
+	Amount   DataCap
+	Epoch    abi.ChainEpoch
+}
+
+const GrantLogAmtBitwidth = 5
+
+// DataCap allocated to a verified client by a verifier.
+// DataCap used by deals is restored to the allocation if a deal fails to activate.
+type Allocation struct {
+	Granted    DataCap        // Total DataCap granted by the verifier to the client.
+	Used       DataCap        // DataCap used by deals.
+	Revoked    DataCap        // Unused DataCap removed by verifiers, lost to expiry or forfeited as below MinVerifiedDealSize.
+	Expiration abi.ChainEpoch // Epoch after which unused DataCap is lost.
+	LastGrant  abi.ChainEpoch // Epoch of the latest grant.
+}
+
+// Returns the DataCap of an allocation that is neither used nor revoked.
+func (a *Allocation) Remaining() DataCap {
+	return big.Sub(big.Sub(a.Granted, a.Used), a.Revoked)
+}
+
+// An allocation, with the verifier that granted it.
+type ClientAllocation struct {
+	Verifier addr.Address
+	Allocation
+}
+
+type RmDcProposalID struct {
+	ProposalID uint64
+}
+
+// Key for a pair of addresses.
+type AddrPairKey struct {
+	First  addr.Address
+	Second addr.Address
+}
+
+func NewAddrPairKey(first addr.Address, second addr.Address) *AddrPairKey {
+	return &AddrPairKey{First: first, Second: second}
+}
+
+func (k *AddrPairKey) Key() string {
+	return string(append(k.First.Bytes(), k.Second.Bytes()...))
 }
 
 var MinVerifiedDealSize = abi.NewStoragePower(1 << 20)
 
+// Lifetime of an allocation of DataCap, after which unused DataCap is lost.
+// A further allocation by the same verifier to the same client renews the lifetime.
+var DataCapAllocationLifetime = abi.ChainEpoch(180 * builtin.EpochsInDay)
+
 // rootKeyAddress comes from genesis.
 func ConstructState(store adt.Store, rootKeyAddress addr.Address) (*State, error) {
 	emptyMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
@@ -36,9 +132,204 @@ func ConstructState(store adt.Store, rootKeyAddress addr.Address) (*State, error
 		return nil, xerrors.Errorf("failed to create empty map: %w", err)
 	}
 
+	emptyGrantLogCid, err := adt.StoreEmptyArray(store, GrantLogAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty array: %w", err)
+	}
+
 	return &State{
-		RootKey:         rootKeyAddress,
-		Verifiers:       emptyMapCid,
-		VerifiedClients: emptyMapCid,
+		RootKey:                  rootKeyAddress,
+		Verifiers:                emptyMapCid,
+		VerifiedClients:          emptyMapCid,
+		Allocations:              emptyMapCid,
+		RemoveDataCapProposalIDs: emptyMapCid,
+		VerifierInfos:            emptyMapCid,
+		GrantLog:                 emptyGrantLogCid,
 	}, nil
 }
+
+// Returns the allocations to a client, ordered by expiration and then verifier.
+func (st *State) ClientAllocations(store adt.Store, client addr.Address) ([]*ClientAllocation, error) {
+	inner, found, err := st.loadClientAllocations(store, client)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	var allocs []*ClientAllocation
+	var alloc Allocation
+	err = inner.ForEach(&alloc, func(key string) error {
+		verifier, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		allocs = append(allocs, &ClientAllocation{Verifier: verifier, Allocation: alloc})
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to iterate allocations to %v: %w", client, err)
+	}
+	sort.Slice(allocs, func(i, j int) bool {
+		if allocs[i].Expiration != allocs[j].Expiration {
+			return allocs[i].Expiration < allocs[j].Expiration
+		}
+		return bytes.Compare(allocs[i].Verifier.Bytes(), allocs[j].Verifier.Bytes()) < 0
+	})
+	return allocs, nil
+}
+
+// Returns the allocation by a verifier to a client, if any.
+func (st *State) GetAllocation(store adt.Store, verifier, client addr.Address) (*Allocation, bool, error) {
+	inner, found, err := st.loadClientAllocations(store, client)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	var alloc Allocation
+	found, err = inner.Get(abi.AddrKey(verifier), &alloc)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to get allocation by %v to %v: %w", verifier, client, err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+	return &alloc, true, nil
+}
+
+// Stores allocations to a client, replacing any by the same verifiers.
+func (st *State) PutClientAllocations(store adt.Store, client addr.Address, allocs []*ClientAllocation) error {
+	if len(allocs) == 0 {
+		return nil
+	}
+	outer, err := adt.AsMap(store, st.Allocations, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load allocations: %w", err)
+	}
+	inner, found, err := st.loadClientAllocations(store, client)
+	if err != nil {
+		return err
+	}
+	if !found {
+		if inner, err = adt.MakeEmptyMap(store, builtin.DefaultHamtBitwidth); err != nil {
+			return err
+		}
+	}
+
+	for _, alloc := range allocs {
+		if err = inner.Put(abi.AddrKey(alloc.Verifier), &alloc.Allocation); err != nil {
+			return xerrors.Errorf("failed to put allocation by %v to %v: %w", alloc.Verifier, client, err)
+		}
+	}
+	innerRoot, err := inner.Root()
+	if err != nil {
+		return xerrors.Errorf("failed to flush allocations to %v: %w", client, err)
+	}
+	innerCid := cbg.CborCid(innerRoot)
+	if err = outer.Put(abi.AddrKey(client), &innerCid); err != nil {
+		return xerrors.Errorf("failed to put allocations to %v: %w", client, err)
+	}
+	if st.Allocations, err = outer.Root(); err != nil {
+		return xerrors.Errorf("failed to flush allocations: %w", err)
+	}
+	return nil
+}
+
+func (st *State) loadClientAllocations(store adt.Store, client addr.Address) (*adt.Map, bool, error) {
+	outer, err := adt.AsMap(store, st.Allocations, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load allocations: %w", err)
+	}
+	var innerRoot cbg.CborCid
+	found, err := outer.Get(abi.AddrKey(client), &innerRoot)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to get allocations to %v: %w", client, err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+	inner, err := adt.AsMap(store, cid.Cid(innerRoot), builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load allocations to %v: %w", client, err)
+	}
+	return inner, true, nil
+}
+
+// Revokes the unused DataCap of allocations that have expired, returning the total revoked.
+func expireAllocations(allocs []*ClientAllocation, currEpoch abi.ChainEpoch) DataCap {
+	expired := big.Zero()
+	for _, alloc := range allocs {
+		if alloc.Expiration < currEpoch {
+			remaining := alloc.Remaining()
+			alloc.Revoked = big.Add(alloc.Revoked, remaining)
+			expired = big.Add(expired, remaining)
+		}
+	}
+	return expired
+}
+
+// Attributes DataCap used by a deal to unexpired allocations, earliest expiring first.
+// Returns any amount that could not be attributed.
+func useAllocations(allocs []*ClientAllocation, currEpoch abi.ChainEpoch, amount DataCap) DataCap {
+	for _, alloc := range allocs {
+		if amount.IsZero() {
+			break
+		}
+		if alloc.Expiration < currEpoch {
+			continue
+		}
+		take := big.Min(amount, alloc.Remaining())
+		alloc.Used = big.Add(alloc.Used, take)
+		amount = big.Sub(amount, take)
+	}
+	return amount
+}
+
+// Attributes DataCap restored from a failed deal to the allocations it was used from, latest expiring first.
+// DataCap restored to an expired allocation is revoked at once. Returns the total revoked.
+func restoreAllocations(allocs []*ClientAllocation, currEpoch abi.ChainEpoch, amount DataCap) DataCap {
+	expired := big.Zero()
+	for i := len(allocs) - 1; i >= 0 && !amount.IsZero(); i-- {
+		alloc := allocs[i]
+		take := big.Min(amount, alloc.Used)
+		alloc.Used = big.Sub(alloc.Used, take)
+		if alloc.Expiration < currEpoch {
+			alloc.Revoked = big.Add(alloc.Revoked, take)
+			expired = big.Add(expired, take)
+		}
+		amount = big.Sub(amount, take)
+	}
+	return expired
+}
+
+// Revokes unused DataCap from allocations, latest expiring first.
+func revokeAllocations(allocs []*ClientAllocation, amount DataCap) {
+	for i := len(allocs) - 1; i >= 0 && !amount.IsZero(); i-- {
+		alloc := allocs[i]
+		take := big.Min(amount, alloc.Remaining())
+		alloc.Revoked = big.Add(alloc.Revoked, take)
+		amount = big.Sub(amount, take)
+	}
+}
+
+// Removes grants that precede the rolling window ending at an epoch.
+func (info *VerifierInfo) pruneRecentGrants(currEpoch abi.ChainEpoch) {
+	if info.Policy == nil || info.Policy.WindowAllowance.IsZero() {
+		info.RecentGrants = nil
+		return
+	}
+	i := 0
+	for i < len(info.RecentGrants) && info.RecentGrants[i].Epoch <= currEpoch-info.Policy.WindowDuration {
+		i++
+	}
+	info.RecentGrants = info.RecentGrants[i:]
+}
+
+// Returns the total of the grants within the rolling window.
+func (info *VerifierInfo) recentlyGranted() DataCap {
+	total := big.Zero()
+	for _, g := range info.RecentGrants {
+		total = big.Add(total, g.Amount)
+	}
+	return total
+}
@@ -1,6 +1,7 @@
 package verifreg_test
 
 import (
+	"bytes"
 	"context"
 	"strings"
 	"testing"
@@ -8,9 +9,13 @@ import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	verifreg0 "github.com/filecoin-project/specs-actors/actors/builtin/verifreg"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/verifreg"
@@ -46,6 +51,8 @@ func TestConstruction(t *testing.T) {
 		state := actor.state(rt)
 		assert.Equal(t, emptyMap, state.VerifiedClients)
 		assert.Equal(t, emptyMap, state.Verifiers)
+		assert.Equal(t, emptyMap, state.Allocations)
+		assert.Equal(t, emptyMap, state.RemoveDataCapProposalIDs)
 		assert.Equal(t, raddr, state.RootKey)
 		actor.checkState(rt)
 	})
@@ -772,6 +779,377 @@ func TestRestoreBytes(t *testing.T) {
 	})
 }
 
+func TestAllocations(t *testing.T) {
+	root := tutil.NewIDAddr(t, 101)
+	clientAddr := tutil.NewIDAddr(t, 201)
+	verifierAddr := tutil.NewIDAddr(t, 301)
+	verifierAddr2 := tutil.NewIDAddr(t, 302)
+	minSize := verifreg.MinVerifiedDealSize
+	allowance := big.Mul(minSize, big.NewInt(4))
+
+	t.Run("records use and restoration of datacap", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		rt.SetEpoch(10)
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, allowance, allowance)
+
+		alloc := ac.getAllocation(rt, verifierAddr, clientAddr)
+		assert.Equal(t, allowance, alloc.Granted)
+		assert.Equal(t, big.Zero(), alloc.Used)
+		assert.Equal(t, 10+verifreg.DataCapAllocationLifetime, alloc.Expiration)
+
+		ac.useBytes(rt, clientAddr, big.Mul(minSize, big.NewInt(2)), &capExpectation{expectedCap: big.Mul(minSize, big.NewInt(2))})
+		assert.Equal(t, big.Mul(minSize, big.NewInt(2)), ac.getAllocation(rt, verifierAddr, clientAddr).Used)
+
+		ac.restoreBytes(rt, clientAddr, minSize, &capExpectation{expectedCap: big.Mul(minSize, big.NewInt(3))})
+		alloc = ac.getAllocation(rt, verifierAddr, clientAddr)
+		assert.Equal(t, minSize, alloc.Used)
+		assert.Equal(t, big.Mul(minSize, big.NewInt(3)), alloc.Remaining())
+		ac.checkState(rt)
+	})
+
+	t.Run("forfeited datacap below minimum deal size is revoked", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		clientAllowance := big.Add(minSize, big.NewInt(1))
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, allowance, clientAllowance)
+
+		ac.useBytes(rt, clientAddr, minSize, &capExpectation{removed: true})
+		alloc := ac.getAllocation(rt, verifierAddr, clientAddr)
+		assert.Equal(t, minSize, alloc.Used)
+		assert.Equal(t, big.NewInt(1), alloc.Revoked)
+		assert.True(t, alloc.Remaining().Equals(big.Zero()))
+		ac.checkState(rt)
+	})
+
+	t.Run("datacap is used from earliest expiring allocations", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addNewVerifier(rt, verifierAddr, allowance)
+		ac.addNewVerifier(rt, verifierAddr2, allowance)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, big.Mul(minSize, big.NewInt(2)))
+		// A client exhausting its datacap may be granted more by another verifier.
+		ac.useBytes(rt, clientAddr, big.Mul(minSize, big.NewInt(2)), &capExpectation{removed: true})
+		rt.SetEpoch(100)
+		ac.addVerifiedClient(rt, verifierAddr2, clientAddr, big.Mul(minSize, big.NewInt(2)))
+		ac.restoreBytes(rt, clientAddr, minSize, &capExpectation{expectedCap: big.Mul(minSize, big.NewInt(3))})
+
+		// The restored bytes are attributed to the latest expiring allocation with use, the earliest.
+		assert.Equal(t, minSize, ac.getAllocation(rt, verifierAddr, clientAddr).Used)
+		ac.useBytes(rt, clientAddr, big.Mul(minSize, big.NewInt(2)), &capExpectation{expectedCap: minSize})
+		assert.Equal(t, big.Mul(minSize, big.NewInt(2)), ac.getAllocation(rt, verifierAddr, clientAddr).Used)
+		assert.Equal(t, minSize, ac.getAllocation(rt, verifierAddr2, clientAddr).Used)
+
+		st := ac.state(rt)
+		allocs, err := st.ClientAllocations(rt.AdtStore(), clientAddr)
+		require.NoError(t, err)
+		require.Len(t, allocs, 2)
+		assert.Equal(t, verifierAddr, allocs[0].Verifier)
+		assert.Equal(t, verifierAddr2, allocs[1].Verifier)
+		ac.checkState(rt)
+	})
+
+	t.Run("expired datacap cannot be used", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addNewVerifier(rt, verifierAddr, allowance)
+		ac.addNewVerifier(rt, verifierAddr2, allowance)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, big.Mul(minSize, big.NewInt(2)))
+		expiration := ac.getAllocation(rt, verifierAddr, clientAddr).Expiration
+
+		rt.SetEpoch(expiration + 1)
+		rt.ExpectValidateCallerAddr(builtin.StorageMarketActorAddr)
+		rt.SetCaller(builtin.StorageMarketActorAddr, builtin.StorageMarketActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "exceeds allowable cap", func() {
+			rt.Call(ac.UseBytes, &verifreg.UseBytesParams{Address: clientAddr, DealSize: minSize})
+		})
+		ac.checkState(rt)
+	})
+
+	t.Run("anyone removes expired datacap", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addNewVerifier(rt, verifierAddr, allowance)
+		ac.addNewVerifier(rt, verifierAddr2, allowance)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, big.Mul(minSize, big.NewInt(3)))
+		ac.useBytes(rt, clientAddr, minSize, &capExpectation{expectedCap: big.Mul(minSize, big.NewInt(2))})
+		expiration := ac.getAllocation(rt, verifierAddr, clientAddr).Expiration
+
+		anyone := tutil.NewIDAddr(t, 999)
+		rt.SetCaller(anyone, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "no expired DataCap", func() {
+			rt.Call(ac.RemoveExpiredDataCap, &clientAddr)
+		})
+		rt.Verify()
+
+		rt.SetEpoch(expiration + 1)
+		rt.ExpectValidateCallerAny()
+		ret := rt.Call(ac.RemoveExpiredDataCap, &clientAddr).(*verifreg.RemoveDataCapReturn)
+		rt.Verify()
+		assert.Equal(t, clientAddr, ret.VerifiedClient)
+		assert.Equal(t, big.Mul(minSize, big.NewInt(2)), ret.DataCapRemoved)
+		ac.assertClientRemoved(rt, clientAddr)
+
+		alloc := ac.getAllocation(rt, verifierAddr, clientAddr)
+		assert.Equal(t, minSize, alloc.Used)
+		assert.Equal(t, big.Mul(minSize, big.NewInt(2)), alloc.Revoked)
+		ac.checkState(rt)
+	})
+
+	t.Run("datacap restored to expired allocation is revoked", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addNewVerifier(rt, verifierAddr, allowance)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, big.Mul(minSize, big.NewInt(3)))
+		ac.useBytes(rt, clientAddr, big.Mul(minSize, big.NewInt(2)), &capExpectation{expectedCap: minSize})
+		expiration := ac.getAllocation(rt, verifierAddr, clientAddr).Expiration
+
+		// Restoration after expiry does not return the DataCap to the client.
+		rt.SetEpoch(expiration + 1)
+		ac.restoreBytes(rt, clientAddr, minSize, &capExpectation{expectedCap: minSize})
+		alloc := ac.getAllocation(rt, verifierAddr, clientAddr)
+		assert.Equal(t, minSize, alloc.Used)
+		assert.Equal(t, minSize, alloc.Revoked)
+		ac.checkState(rt)
+
+		// Removing expired DataCap leaves the client none.
+		rt.SetCaller(tutil.NewIDAddr(t, 999), builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAny()
+		ret := rt.Call(ac.RemoveExpiredDataCap, &clientAddr).(*verifreg.RemoveDataCapReturn)
+		rt.Verify()
+		assert.Equal(t, minSize, ret.DataCapRemoved)
+		ac.assertClientRemoved(rt, clientAddr)
+
+		// Nor does restoration to a client whose DataCap has been removed.
+		ac.restoreBytes(rt, clientAddr, minSize, &capExpectation{removed: true})
+		alloc = ac.getAllocation(rt, verifierAddr, clientAddr)
+		assert.True(t, alloc.Used.IsZero())
+		assert.Equal(t, big.Mul(minSize, big.NewInt(3)), alloc.Revoked)
+		ac.checkState(rt)
+	})
+}
+
+func TestRemoveVerifiedClientDataCap(t *testing.T) {
+	root := tutil.NewIDAddr(t, 101)
+	clientAddr := tutil.NewIDAddr(t, 201)
+	verifierAddr := tutil.NewIDAddr(t, 301)
+	verifierAddr2 := tutil.NewIDAddr(t, 302)
+	minSize := verifreg.MinVerifiedDealSize
+	clientAllowance := big.Mul(minSize, big.NewInt(4))
+
+	setup := func(t *testing.T) (*mock.Runtime, *verifRegActorTestHarness) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.generateAndAddVerifierAndVerifiedClient(rt, verifierAddr, clientAddr, clientAllowance, clientAllowance)
+		ac.addNewVerifier(rt, verifierAddr2, clientAllowance)
+		return rt, ac
+	}
+
+	t.Run("removes datacap with two verifier signatures", func(t *testing.T) {
+		rt, ac := setup(t)
+		ret := ac.removeDataCap(rt, clientAddr, minSize, verifierAddr, verifierAddr2, 0)
+		assert.Equal(t, minSize, ret.DataCapRemoved)
+		assert.Equal(t, big.Mul(minSize, big.NewInt(3)), ac.getClientCap(rt, clientAddr))
+		assert.Equal(t, minSize, ac.getAllocation(rt, verifierAddr, clientAddr).Revoked)
+
+		// Proposal IDs advance, so subsequent proposals must be signed anew.
+		ret = ac.removeDataCap(rt, clientAddr, big.Mul(minSize, big.NewInt(10)), verifierAddr2, verifierAddr, 1)
+		assert.Equal(t, big.Mul(minSize, big.NewInt(3)), ret.DataCapRemoved)
+		ac.assertClientRemoved(rt, clientAddr)
+		assert.True(t, ac.getAllocation(rt, verifierAddr, clientAddr).Remaining().Equals(big.Zero()))
+		ac.checkState(rt)
+	})
+
+	t.Run("fails with replayed signature", func(t *testing.T) {
+		rt, ac := setup(t)
+		ac.removeDataCap(rt, clientAddr, minSize, verifierAddr, verifierAddr2, 0)
+
+		// The signature over proposal ID 0 no longer verifies.
+		sig := crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("verifier")}
+		rt.SetCaller(root, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(root)
+		rt.ExpectVerifySignature(sig, verifierAddr, removalProposalBytes(t, clientAddr, minSize, 1), xerrors.New("bad signature"))
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid signature", func() {
+			rt.Call(ac.RemoveVerifiedClientDataCap, &verifreg.RemoveDataCapParams{
+				VerifiedClientToRemove: clientAddr,
+				DataCapAmountToRemove:  minSize,
+				VerifierRequest1:       verifreg.RemoveDataCapRequest{Verifier: verifierAddr, VerifierSignature: sig},
+				VerifierRequest2:       verifreg.RemoveDataCapRequest{Verifier: verifierAddr2, VerifierSignature: sig},
+			})
+		})
+		rt.Verify()
+	})
+
+	t.Run("fails with invalid requests", func(t *testing.T) {
+		rt, ac := setup(t)
+		sig := crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("verifier")}
+		nonVerifier := tutil.NewIDAddr(t, 303)
+		params := func(v1, v2 address.Address, amount verifreg.DataCap) *verifreg.RemoveDataCapParams {
+			return &verifreg.RemoveDataCapParams{
+				VerifiedClientToRemove: clientAddr,
+				DataCapAmountToRemove:  amount,
+				VerifierRequest1:       verifreg.RemoveDataCapRequest{Verifier: v1, VerifierSignature: sig},
+				VerifierRequest2:       verifreg.RemoveDataCapRequest{Verifier: v2, VerifierSignature: sig},
+			}
+		}
+
+		rt.SetCaller(verifierAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAddr(root)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			rt.Call(ac.RemoveVerifiedClientDataCap, params(verifierAddr, verifierAddr2, minSize))
+		})
+
+		rt.SetCaller(root, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(root)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "two different verifiers", func() {
+			rt.Call(ac.RemoveVerifiedClientDataCap, params(verifierAddr, verifierAddr, minSize))
+		})
+
+		rt.ExpectValidateCallerAddr(root)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "must be positive", func() {
+			rt.Call(ac.RemoveVerifiedClientDataCap, params(verifierAddr, verifierAddr2, big.Zero()))
+		})
+
+		rt.ExpectValidateCallerAddr(root)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "is not a verifier", func() {
+			rt.Call(ac.RemoveVerifiedClientDataCap, params(verifierAddr, nonVerifier, minSize))
+		})
+		rt.Verify()
+		ac.checkState(rt)
+	})
+}
+
+func TestVerifierPolicy(t *testing.T) {
+	root := tutil.NewIDAddr(t, 101)
+	verifierAddr := tutil.NewIDAddr(t, 301)
+	clientAddr := tutil.NewIDAddr(t, 201)
+	clientAddr2 := tutil.NewIDAddr(t, 202)
+	clientAddr3 := tutil.NewIDAddr(t, 203)
+	minSize := verifreg.MinVerifiedDealSize
+	allowance := big.Mul(minSize, big.NewInt(10))
+
+	t.Run("grants are logged", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addVerifierWithPolicy(rt, verifierAddr, allowance, nil)
+		rt.SetEpoch(5)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, minSize)
+		rt.SetEpoch(6)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr2, big.Mul(minSize, big.NewInt(2)))
+
+		assert.Equal(t, []verifreg.Grant{
+			{Verifier: verifierAddr, Client: clientAddr, Amount: minSize, Epoch: 5},
+			{Verifier: verifierAddr, Client: clientAddr2, Amount: big.Mul(minSize, big.NewInt(2)), Epoch: 6},
+		}, ac.grantLog(rt))
+		ac.checkState(rt)
+
+		// Re-adding the verifier resets its allowance, from which subsequent grants are drawn.
+		ac.addVerifierWithPolicy(rt, verifierAddr, allowance, nil)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr3, minSize)
+		assert.Equal(t, big.Sub(allowance, minSize), ac.getVerifierCap(rt, verifierAddr))
+		ac.checkState(rt)
+	})
+
+	t.Run("limits grant per client", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addVerifierWithPolicy(rt, verifierAddr, allowance, &verifreg.VerifierPolicy{
+			MaxClientGrant:  big.Mul(minSize, big.NewInt(2)),
+			WindowAllowance: big.Zero(),
+		})
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, big.Mul(minSize, big.NewInt(2)))
+
+		rt.SetCaller(verifierAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "exceeds verifier's maximum grant per client", func() {
+			rt.Call(ac.AddVerifiedClient, mkClientParams(clientAddr2, big.Add(big.Mul(minSize, big.NewInt(2)), big.NewInt(1))))
+		})
+		ac.checkState(rt)
+	})
+
+	t.Run("limits grants within rolling window", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addVerifierWithPolicy(rt, verifierAddr, allowance, &verifreg.VerifierPolicy{
+			MaxClientGrant:  big.Zero(),
+			WindowAllowance: big.Mul(minSize, big.NewInt(3)),
+			WindowDuration:  100,
+		})
+		rt.SetEpoch(10)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, big.Mul(minSize, big.NewInt(2)))
+		rt.SetEpoch(50)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr2, minSize)
+
+		rt.SetEpoch(109)
+		rt.SetCaller(verifierAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "would exceed verifier's allowance", func() {
+			rt.Call(ac.AddVerifiedClient, mkClientParams(clientAddr3, minSize))
+		})
+		rt.Verify()
+
+		// The first grant leaves the window.
+		rt.SetEpoch(110)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr3, minSize)
+		ac.checkState(rt)
+	})
+
+	t.Run("limits repeated grants to a client", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addVerifierWithPolicy(rt, verifierAddr, allowance, &verifreg.VerifierPolicy{
+			MaxClientGrant:  big.Zero(),
+			WindowAllowance: big.Zero(),
+			ClientCooldown:  1000,
+		})
+		rt.SetEpoch(10)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, minSize)
+		ac.useBytes(rt, clientAddr, minSize, &capExpectation{removed: true})
+
+		rt.SetEpoch(1009)
+		rt.SetCaller(verifierAddr, builtin.AccountActorCodeID)
+		rt.ExpectValidateCallerAny()
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "cannot grant to", func() {
+			rt.Call(ac.AddVerifiedClient, mkClientParams(clientAddr, minSize))
+		})
+		rt.Verify()
+
+		rt.SetEpoch(1010)
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, minSize)
+		assert.Equal(t, big.Mul(minSize, big.NewInt(2)), ac.getAllocation(rt, verifierAddr, clientAddr).Granted)
+		ac.checkState(rt)
+	})
+
+	t.Run("verifier added in the prior encoding has no policy", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		ac.addVerifierWithPolicy(rt, verifierAddr, allowance, &verifreg.VerifierPolicy{
+			MaxClientGrant:  minSize,
+			WindowAllowance: big.Zero(),
+		})
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, (&verifreg0.AddVerifierParams{Address: verifierAddr, Allowance: allowance}).MarshalCBOR(buf))
+		var params verifreg.AddVerifierParams
+		require.NoError(t, params.UnmarshalCBOR(buf))
+
+		rt.SetCaller(root, builtin.MultisigActorCodeID)
+		rt.ExpectValidateCallerAddr(root)
+		rt.Call(ac.AddVerifier, &params)
+		rt.Verify()
+
+		// Re-adding the verifier removed its limit on grants per client.
+		ac.addVerifiedClient(rt, verifierAddr, clientAddr, big.Mul(minSize, big.NewInt(2)))
+		ac.checkState(rt)
+	})
+
+	t.Run("rejects invalid policy", func(t *testing.T) {
+		rt, ac := basicVerifRegSetup(t, root)
+		rt.SetCaller(root, builtin.MultisigActorCodeID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "requires a window duration", func() {
+			rt.Call(ac.AddVerifierWithPolicy, &verifreg.AddVerifierWithPolicyParams{Address: verifierAddr, Allowance: allowance, Policy: &verifreg.VerifierPolicy{
+				MaxClientGrant:  big.Zero(),
+				WindowAllowance: minSize,
+			}})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "must be non-negative", func() {
+			rt.Call(ac.AddVerifierWithPolicy, &verifreg.AddVerifierWithPolicyParams{Address: verifierAddr, Allowance: allowance, Policy: &verifreg.VerifierPolicy{
+				MaxClientGrant:  big.NewInt(-1),
+				WindowAllowance: big.Zero(),
+			}})
+		})
+	})
+}
+
 type verifRegActorTestHarness struct {
 	rootkey address.Address
 	verifreg.Actor
@@ -909,7 +1287,11 @@ func (h *verifRegActorTestHarness) restoreBytes(rt *mock.Runtime, a address.Addr
 	require.True(h.t, found)
 
 	// assert client cap now
-	assert.EqualValues(h.t, expectedCap.expectedCap, h.getClientCap(rt, clientIdAddr))
+	if expectedCap.removed {
+		h.assertClientRemoved(rt, clientIdAddr)
+	} else {
+		assert.EqualValues(h.t, expectedCap.expectedCap, h.getClientCap(rt, clientIdAddr))
+	}
 }
 
 func (h *verifRegActorTestHarness) getVerifierCap(rt *mock.Runtime, a address.Address) verifreg.DataCap {
@@ -966,6 +1348,62 @@ func (h *verifRegActorTestHarness) assertClientRemoved(rt *mock.Runtime, a addre
 	assert.False(h.t, found)
 }
 
+func (h *verifRegActorTestHarness) addVerifierWithPolicy(rt *mock.Runtime, verifier address.Address, datacap verifreg.DataCap, policy *verifreg.VerifierPolicy) {
+	rt.SetCaller(h.rootkey, builtin.VerifiedRegistryActorCodeID)
+	rt.ExpectValidateCallerAddr(h.rootkey)
+	rt.Call(h.AddVerifierWithPolicy, &verifreg.AddVerifierWithPolicyParams{Address: verifier, Allowance: datacap, Policy: policy})
+	rt.Verify()
+}
+
+func (h *verifRegActorTestHarness) grantLog(rt *mock.Runtime) []verifreg.Grant {
+	arr, err := adt.AsArray(adt.AsStore(rt), h.state(rt).GrantLog, verifreg.GrantLogAmtBitwidth)
+	require.NoError(h.t, err)
+	var grants []verifreg.Grant
+	var grant verifreg.Grant
+	require.NoError(h.t, arr.ForEach(&grant, func(int64) error {
+		grants = append(grants, grant)
+		return nil
+	}))
+	return grants
+}
+
+func (h *verifRegActorTestHarness) getAllocation(rt *mock.Runtime, verifier, client address.Address) *verifreg.Allocation {
+	alloc, found, err := h.state(rt).GetAllocation(rt.AdtStore(), verifier, client)
+	require.NoError(h.t, err)
+	require.True(h.t, found)
+	return alloc
+}
+
+func (h *verifRegActorTestHarness) removeDataCap(rt *mock.Runtime, client address.Address, amount verifreg.DataCap,
+	verifier1, verifier2 address.Address, proposalID uint64) *verifreg.RemoveDataCapReturn {
+	sig1 := crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("verifier1")}
+	sig2 := crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte("verifier2")}
+	proposal := removalProposalBytes(h.t, client, amount, proposalID)
+
+	rt.SetCaller(h.rootkey, builtin.MultisigActorCodeID)
+	rt.ExpectValidateCallerAddr(h.rootkey)
+	rt.ExpectVerifySignature(sig1, verifier1, proposal, nil)
+	rt.ExpectVerifySignature(sig2, verifier2, proposal, nil)
+	ret := rt.Call(h.RemoveVerifiedClientDataCap, &verifreg.RemoveDataCapParams{
+		VerifiedClientToRemove: client,
+		DataCapAmountToRemove:  amount,
+		VerifierRequest1:       verifreg.RemoveDataCapRequest{Verifier: verifier1, VerifierSignature: sig1},
+		VerifierRequest2:       verifreg.RemoveDataCapRequest{Verifier: verifier2, VerifierSignature: sig2},
+	})
+	rt.Verify()
+	return ret.(*verifreg.RemoveDataCapReturn)
+}
+
+func removalProposalBytes(t testing.TB, client address.Address, amount verifreg.DataCap, proposalID uint64) []byte {
+	buf := bytes.NewBufferString(verifreg.SignatureDomainSeparation_RemoveDataCap)
+	require.NoError(t, (&verifreg.RemoveDataCapProposal{
+		VerifiedClient:    client,
+		DataCapAmount:     amount,
+		RemovalProposalID: verifreg.RmDcProposalID{ProposalID: proposalID},
+	}).MarshalCBOR(buf))
+	return buf.Bytes()
+}
+
 func mkVerifierParams(a address.Address, allowance verifreg.DataCap) *verifreg.AddVerifierParams {
 	return &verifreg.AddVerifierParams{Address: a, Allowance: allowance}
 }
@@ -0,0 +1,35 @@
+package nv10
+
+import (
+	"context"
+
+	account2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/account"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	account3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/account"
+)
+
+type accountMigrator struct{}
+
+func (m accountMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState account2.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, err
+	}
+
+	// Accounts begin with no guardians and no recovery in progress.
+	outState := account3.State{
+		Address: inState.Address,
+	}
+	newHead, err := store.Put(ctx, &outState)
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, err
+}
+
+func (m accountMigrator) migratedCodeCID() cid.Cid {
+	return builtin3.AccountActorCodeID
+}
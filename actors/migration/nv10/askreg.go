@@ -0,0 +1,29 @@
+package nv10
+
+import (
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	askreg3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/askreg"
+	states3 "github.com/filecoin-project/specs-actors/v3/actors/states"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// Creates the storage ask registry singleton, which has no prior version to migrate from.
+func createAskRegistryActor(store adt3.Store, actorsOut *states3.Tree) error {
+	st, err := askreg3.ConstructState(store)
+	if err != nil {
+		return xerrors.Errorf("failed to construct ask registry state: %w", err)
+	}
+	head, err := store.Put(store.Context(), st)
+	if err != nil {
+		return xerrors.Errorf("failed to store ask registry state: %w", err)
+	}
+	return actorsOut.SetActor(builtin3.StorageAskRegistryActorAddr, &states3.Actor{
+		Code:       builtin3.StorageAskRegistryActorCodeID,
+		Head:       head,
+		CallSeqNum: 0,
+		Balance:    big.Zero(),
+	})
+}
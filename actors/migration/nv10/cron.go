@@ -0,0 +1,66 @@
+package nv10
+
+import (
+	"context"
+
+	address "github.com/filecoin-project/go-address"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	cron2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/cron"
+	verifreg2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/verifreg"
+	states2 "github.com/filecoin-project/specs-actors/v2/actors/states"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	cron3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/cron"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+type cronMigrator struct {
+	governor address.Address // The governance multisig, which becomes the cron table's governor
+}
+
+func (m cronMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState cron2.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, err
+	}
+
+	// Existing entries are dispatched every epoch, indefinitely.
+	entriesOut := make([]cron3.Entry, len(inState.Entries))
+	for i, e := range inState.Entries {
+		entriesOut[i] = cron3.Entry{
+			Receiver:  e.Receiver,
+			MethodNum: e.MethodNum,
+			Interval:  1,
+		}
+	}
+
+	governor := m.governor
+	outState := cron3.ConstructState(entriesOut, &governor)
+	newHead, err := store.Put(ctx, outState)
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, err
+}
+
+func (m cronMigrator) migratedCodeCID() cid.Cid {
+	return builtin3.CronActorCodeID
+}
+
+// Loads the verified registry's root key, the governance multisig which also governs the cron table.
+func loadGovernanceAddress(store adt3.Store, actorsIn *states2.Tree) (address.Address, error) {
+	verifregActor, found, err := actorsIn.GetActor(builtin2.VerifiedRegistryActorAddr)
+	if err != nil {
+		return address.Undef, err
+	} else if !found {
+		return address.Undef, xerrors.Errorf("verified registry actor not found")
+	}
+	var verifregState verifreg2.State
+	if err := store.Get(store.Context(), verifregActor.Head, &verifregState); err != nil {
+		return address.Undef, xerrors.Errorf("failed to load verified registry state: %w", err)
+	}
+	return verifregState.RootKey, nil
+}
@@ -3,12 +3,17 @@ package nv10
 import (
 	"context"
 
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	init2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/init"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
 	cid "github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
 
 	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	init3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 )
 
 type initMigrator struct{}
@@ -24,10 +29,22 @@ func (m initMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in
 		return nil, err
 	}
 
+	robustAddressesOut, err := migrateRobustAddresses(ctx, store, inState.AddressMap)
+	if err != nil {
+		return nil, err
+	}
+	emptyMap, err := adt3.StoreEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+
 	outState := init3.State{
-		AddressMap:  addressMapOut,
-		NextID:      inState.NextID,
-		NetworkName: inState.NetworkName,
+		AddressMap:      addressMapOut,
+		NextID:          inState.NextID,
+		NetworkName:     inState.NetworkName,
+		RobustAddresses: robustAddressesOut,
+		Aliases:         emptyMap,
+		ActorAliases:    emptyMap,
 	}
 	newHead, err := store.Put(ctx, &outState)
 	return &actorMigrationResult{
@@ -36,6 +53,30 @@ func (m initMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in
 	}, err
 }
 
+// Builds the inverse of the address map, from actor ID to robust address.
+func migrateRobustAddresses(ctx context.Context, store cbor.IpldStore, addressMap cid.Cid) (cid.Cid, error) {
+	inMap, err := adt2.AsMap(adt2.WrapStore(ctx, store), addressMap)
+	if err != nil {
+		return cid.Undef, err
+	}
+	outMap, err := adt3.MakeEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	var actorID cbg.CborInt
+	if err = inMap.ForEach(&actorID, func(key string) error {
+		robust, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		return outMap.Put(abi.UIntKey(uint64(actorID)), &robust)
+	}); err != nil {
+		return cid.Undef, err
+	}
+	return outMap.Root()
+}
+
 func (m initMigrator) migratedCodeCID() cid.Cid {
 	return builtin3.InitActorCodeID
 }
@@ -47,6 +47,16 @@ func (m marketMigrator) migrateState(ctx context.Context, store cbor.IpldStore,
 		return nil, err
 	}
 
+	pieceReplicasCidOut, err := market3.ConstructPieceReplicas(adt3.WrapStore(ctx, store), proposalsCidOut, statesCidOut)
+	if err != nil {
+		return nil, err
+	}
+
+	emptySettledDealsCid, err := adt3.StoreEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+
 	outState := market3.State{
 		Proposals:                     proposalsCidOut,
 		States:                        statesCidOut,
@@ -59,6 +69,8 @@ func (m marketMigrator) migrateState(ctx context.Context, store cbor.IpldStore,
 		TotalClientLockedCollateral:   inState.TotalClientLockedCollateral,
 		TotalProviderLockedCollateral: inState.TotalProviderLockedCollateral,
 		TotalClientStorageFee:         inState.TotalClientStorageFee,
+		PieceReplicas:                 pieceReplicasCidOut,
+		SettledDeals:                  emptySettledDealsCid,
 	}
 
 	newHead, err := store.Put(ctx, &outState)
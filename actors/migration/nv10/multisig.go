@@ -4,11 +4,13 @@ import (
 	"context"
 
 	multisig2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/multisig"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
 	cid "github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
 
 	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	multisig3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/multisig"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 )
 
 type multisigMigrator struct{}
@@ -19,19 +21,33 @@ func (m multisigMigrator) migrateState(ctx context.Context, store cbor.IpldStore
 		return nil, err
 	}
 
-	pendingTxnsOut, err := migrateHAMTRaw(ctx, store, inState.PendingTxns, builtin3.DefaultHamtBitwidth)
+	pendingTxnsOut, err := m.migratePendingTxns(ctx, store, inState.PendingTxns)
 	if err != nil {
 		return nil, err
 	}
 
+	policiesOut, err := adt3.StoreEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signers prior to migration all have unit weight.
+	weights := make([]uint64, len(inState.Signers))
+	for i := range weights {
+		weights[i] = 1
+	}
+
+	// Existing locks continue to unlock linearly, with no vesting schedule.
 	outState := multisig3.State{
 		Signers:               inState.Signers,
+		SignerWeights:         weights,
 		NumApprovalsThreshold: inState.NumApprovalsThreshold,
 		NextTxnID:             inState.NextTxnID,
 		InitialBalance:        inState.InitialBalance,
 		StartEpoch:            inState.StartEpoch,
 		UnlockDuration:        inState.UnlockDuration,
 		PendingTxns:           pendingTxnsOut,
+		ApprovalPolicies:      policiesOut,
 	}
 	newHead, err := store.Put(ctx, &outState)
 	return &actorMigrationResult{
@@ -43,3 +59,30 @@ func (m multisigMigrator) migrateState(ctx context.Context, store cbor.IpldStore
 func (m multisigMigrator) migratedCodeCID() cid.Cid {
 	return builtin3.MultisigActorCodeID
 }
+
+// Pending transactions gain a time lock and expiry, neither of which is set for transactions proposed prior to migration.
+func (m multisigMigrator) migratePendingTxns(ctx context.Context, store cbor.IpldStore, root cid.Cid) (cid.Cid, error) {
+	inTxns, err := adt2.AsMap(adt2.WrapStore(ctx, store), root)
+	if err != nil {
+		return cid.Undef, err
+	}
+	outTxns, err := adt3.MakeEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	var inTxn multisig2.Transaction
+	if err = inTxns.ForEach(&inTxn, func(key string) error {
+		outTxn := multisig3.Transaction{
+			To:       inTxn.To,
+			Value:    inTxn.Value,
+			Method:   inTxn.Method,
+			Params:   inTxn.Params,
+			Approved: inTxn.Approved,
+		}
+		return outTxns.Put(StringKey(key), &outTxn)
+	}); err != nil {
+		return cid.Undef, err
+	}
+	return outTxns.Root()
+}
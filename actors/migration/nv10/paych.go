@@ -3,12 +3,16 @@ package nv10
 import (
 	"context"
 
+	"github.com/filecoin-project/go-state-types/big"
 	paych2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/paych"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
 	cid "github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
 
 	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	paych3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/paych"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 )
 
 type paychMigrator struct{}
@@ -19,18 +23,36 @@ func (m paychMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 		return nil, err
 	}
 
-	laneStatesOut, err := migrateAMTRaw(ctx, store, inState.LaneStates, paych3.LaneStatesAmtBitwidth)
+	laneStatesOut, err := m.migrateLaneStates(ctx, store, inState.LaneStates)
+	if err != nil {
+		return nil, xerrors.Errorf("lane states: %w", err)
+	}
+
+	// Channels created prior to migration have no recorded deposits.
+	depositsOut, err := adt3.StoreEmptyArray(adt3.WrapStore(ctx, store), paych3.DepositsAmtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Channels created prior to migration have account parties, which sign for themselves.
+	approvalsOut, err := adt3.StoreEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
 	if err != nil {
 		return nil, err
 	}
 
 	outState := paych3.State{
-		From:            inState.From,
-		To:              inState.To,
-		ToSend:          inState.ToSend,
-		SettlingAt:      inState.SettlingAt,
-		MinSettleHeight: inState.MinSettleHeight,
-		LaneStates:      laneStatesOut,
+		From:             inState.From,
+		To:               inState.To,
+		FromSigner:       inState.From,
+		ToSigner:         inState.To,
+		ToSend:           inState.ToSend,
+		Withdrawn:        big.Zero(),
+		Reserved:         big.Zero(),
+		SettlingAt:       inState.SettlingAt,
+		MinSettleHeight:  inState.MinSettleHeight,
+		LaneStates:       laneStatesOut,
+		Deposits:         depositsOut,
+		ApprovedVouchers: approvalsOut,
 	}
 	newHead, err := store.Put(ctx, &outState)
 	return &actorMigrationResult{
@@ -39,6 +61,32 @@ func (m paychMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 	}, err
 }
 
+func (m paychMigrator) migrateLaneStates(ctx context.Context, store cbor.IpldStore, root cid.Cid) (cid.Cid, error) {
+	// AMT[LaneID]LaneState
+	inArray, err := adt2.AsArray(adt2.WrapStore(ctx, store), root)
+	if err != nil {
+		return cid.Undef, err
+	}
+	outArray, err := adt3.MakeEmptyArray(adt3.WrapStore(ctx, store), paych3.LaneStatesAmtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	var inLane paych2.LaneState
+	if err = inArray.ForEach(&inLane, func(i int64) error {
+		// Lanes created prior to migration never hold a pending conditional voucher.
+		outLane := paych3.LaneState{
+			Redeemed: inLane.Redeemed,
+			Nonce:    inLane.Nonce,
+		}
+		return outArray.Set(uint64(i), &outLane)
+	}); err != nil {
+		return cid.Undef, err
+	}
+
+	return outArray.Root()
+}
+
 func (m paychMigrator) migratedCodeCID() cid.Cid {
 	return builtin3.PaymentChannelActorCodeID
 }
@@ -0,0 +1,57 @@
+package nv10
+
+import (
+	"context"
+
+	reward2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/reward"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	reward3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/reward"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+	smoothing3 "github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
+)
+
+type rewardMigrator struct{}
+
+func (m rewardMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState reward2.State
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, err
+	}
+
+	// The network follows the default reward policy, whose derived constants are those previously hard-coded.
+	// The simple and baseline totals in effect are retained, as they may differ from the default policy's.
+	policy := reward3.DefaultRewardPolicy()
+	policy.SimpleTotal = inState.SimpleTotal
+	policy.BaselineTotal = inState.BaselineTotal
+	// Checkpoints are recorded from the upgrade onwards.
+	emptyCheckpoints, err := adt3.StoreEmptyArray(adt3.WrapStore(ctx, store), reward3.CheckpointsAmtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+	outState := reward3.State{
+		CumsumBaseline:          inState.CumsumBaseline,
+		CumsumRealized:          inState.CumsumRealized,
+		EffectiveNetworkTime:    inState.EffectiveNetworkTime,
+		EffectiveBaselinePower:  inState.EffectiveBaselinePower,
+		ThisEpochReward:         inState.ThisEpochReward,
+		ThisEpochRewardSmoothed: smoothing3.FilterEstimate(inState.ThisEpochRewardSmoothed),
+		ThisEpochBaselinePower:  inState.ThisEpochBaselinePower,
+		Epoch:                   inState.Epoch,
+		TotalStoragePowerReward: inState.TotalStoragePowerReward,
+		Policy:                  policy,
+		Constants:               policy.Constants(),
+		Checkpoints:             emptyCheckpoints,
+	}
+	newHead, err := store.Put(ctx, &outState)
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, err
+}
+
+func (m rewardMigrator) migratedCodeCID() cid.Cid {
+	return builtin3.RewardActorCodeID
+}
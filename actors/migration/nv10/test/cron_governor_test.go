@@ -0,0 +1,44 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	vm2 "github.com/filecoin-project/specs-actors/v2/support/vm"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	cron3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/cron"
+	"github.com/filecoin-project/specs-actors/v3/actors/migration/nv10"
+	states3 "github.com/filecoin-project/specs-actors/v3/actors/states"
+)
+
+func TestCronGovernedByVerifiedRegistryRoot(t *testing.T) {
+	ctx := context.Background()
+	log := TestLogger{t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	v := vm2.NewVMWithSingletons(ctx, t, bs)
+
+	adtStore := adt2.WrapStore(ctx, cbor.NewCborStore(bs))
+	endRoot, err := nv10.MigrateStateTree(ctx, adtStore, v.StateRoot(), abi.ChainEpoch(0), nv10.Config{MaxWorkers: 1}, log, nv10.NewMemMigrationCache())
+	require.NoError(t, err)
+
+	actorsOut, err := states3.LoadTree(adtStore, endRoot)
+	require.NoError(t, err)
+	cronActor, found, err := actorsOut.GetActor(builtin3.CronActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var st cron3.State
+	require.NoError(t, adtStore.Get(ctx, cronActor.Head, &st))
+
+	require.NotNil(t, st.Governor)
+	assert.Equal(t, vm2.VerifregRoot, *st.Governor)
+	for _, e := range cron3.BuiltInEntries() {
+		assert.True(t, st.FindEntry(e.Receiver, e.MethodNum) >= 0)
+	}
+}
@@ -193,13 +193,15 @@ func publishDeal(t *testing.T, v *vm2.VM, provider, dealClient, minerID addr.Add
 func publishV3Deal(t *testing.T, v *vm3.VM, provider, dealClient, minerID addr.Address, dealLabel string,
 	pieceSize abi.PaddedPieceSize, verifiedDeal bool, dealStart abi.ChainEpoch, dealLifetime abi.ChainEpoch,
 ) *market2.PublishStorageDealsReturn {
+	label, err := market3.NewLabelFromString(dealLabel)
+	require.NoError(t, err)
 	deal := market3.DealProposal{
 		PieceCID:             tutil.MakeCID(dealLabel, &market2.PieceCIDPrefix),
 		PieceSize:            pieceSize,
 		VerifiedDeal:         verifiedDeal,
 		Client:               dealClient,
 		Provider:             minerID,
-		Label:                dealLabel,
+		Label:                label,
 		StartEpoch:           dealStart,
 		EndEpoch:             dealStart + dealLifetime,
 		StoragePricePerEpoch: abi.NewTokenAmount(1 << 20),
@@ -0,0 +1,53 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	reward2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/reward"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
+	ipld2 "github.com/filecoin-project/specs-actors/v2/support/ipld"
+	vm2 "github.com/filecoin-project/specs-actors/v2/support/vm"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	reward3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v3/actors/migration/nv10"
+	states3 "github.com/filecoin-project/specs-actors/v3/actors/states"
+)
+
+func TestRewardPolicyRetainsTotalsInEffect(t *testing.T) {
+	ctx := context.Background()
+	log := TestLogger{t}
+	bs := ipld2.NewSyncBlockStoreInMemory()
+	v := vm2.NewVMWithSingletons(ctx, t, bs)
+
+	// Totals in effect differ from the default policy's.
+	var inState reward2.State
+	require.NoError(t, v.GetState(builtin2.RewardActorAddr, &inState))
+	inState.SimpleTotal = big.Sub(inState.SimpleTotal, big.NewInt(1e18))
+	inState.BaselineTotal = big.Add(inState.BaselineTotal, big.NewInt(1e18))
+	require.NoError(t, v.SetActorState(ctx, builtin2.RewardActorAddr, &inState))
+	v, err := v.WithEpoch(0)
+	require.NoError(t, err)
+
+	adtStore := adt2.WrapStore(ctx, cbor.NewCborStore(bs))
+	endRoot, err := nv10.MigrateStateTree(ctx, adtStore, v.StateRoot(), abi.ChainEpoch(0), nv10.Config{MaxWorkers: 1}, log, nv10.NewMemMigrationCache())
+	require.NoError(t, err)
+
+	actorsOut, err := states3.LoadTree(adtStore, endRoot)
+	require.NoError(t, err)
+	rewardActor, found, err := actorsOut.GetActor(builtin3.RewardActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	var st reward3.State
+	require.NoError(t, adtStore.Get(ctx, rewardActor.Head, &st))
+
+	assert.Equal(t, inState.SimpleTotal, st.Policy.SimpleTotal)
+	assert.Equal(t, inState.BaselineTotal, st.Policy.BaselineTotal)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 
 	verifreg2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/verifreg"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
 	cid "github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
 
 	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	verifreg3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/verifreg"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 )
 
 type verifregMigrator struct{}
@@ -28,10 +31,29 @@ func (m verifregMigrator) migrateState(ctx context.Context, store cbor.IpldStore
 		return nil, err
 	}
 
+	// DataCap granted prior to migration is not attributed to any allocation, and never expires.
+	emptyMapCIDOut, err := adt3.StoreEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+
+	verifierInfosCIDOut, err := m.migrateVerifierInfos(ctx, store, inState.Verifiers)
+	if err != nil {
+		return nil, xerrors.Errorf("verifier infos: %w", err)
+	}
+	grantLogCIDOut, err := adt3.StoreEmptyArray(adt3.WrapStore(ctx, store), verifreg3.GrantLogAmtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+
 	outState := verifreg3.State{
-		RootKey:         inState.RootKey,
-		Verifiers:       verifiersCIDOut,
-		VerifiedClients: verifiedClientsCIDOut,
+		RootKey:                  inState.RootKey,
+		Verifiers:                verifiersCIDOut,
+		VerifiedClients:          verifiedClientsCIDOut,
+		Allocations:              emptyMapCIDOut,
+		RemoveDataCapProposalIDs: emptyMapCIDOut,
+		VerifierInfos:            verifierInfosCIDOut,
+		GrantLog:                 grantLogCIDOut,
 	}
 
 	newHead, err := store.Put(ctx, &outState)
@@ -41,6 +63,29 @@ func (m verifregMigrator) migrateState(ctx context.Context, store cbor.IpldStore
 	}, err
 }
 
+// Records each verifier's remaining DataCap as its allowance, with no policy and an empty grant log.
+func (m verifregMigrator) migrateVerifierInfos(ctx context.Context, store cbor.IpldStore, verifiersRoot cid.Cid) (cid.Cid, error) {
+	inVerifiers, err := adt2.AsMap(adt2.WrapStore(ctx, store), verifiersRoot)
+	if err != nil {
+		return cid.Undef, err
+	}
+	outInfos, err := adt3.MakeEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	var dataCap verifreg2.DataCap
+	if err = inVerifiers.ForEach(&dataCap, func(key string) error {
+		return outInfos.Put(StringKey(key), &verifreg3.VerifierInfo{
+			Allowance:     dataCap.Copy(),
+			GrantLogStart: 0,
+		})
+	}); err != nil {
+		return cid.Undef, err
+	}
+	return outInfos.Root()
+}
+
 func (m verifregMigrator) migratedCodeCID() cid.Cid {
 	return builtin3.VerifiedRegistryActorCodeID
 }
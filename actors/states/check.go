@@ -12,6 +12,7 @@ import (
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/askreg"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/cron"
 	init_ "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/market"
@@ -31,6 +32,7 @@ func CheckStateInvariants(tree *Tree, expectedBalanceTotal abi.TokenAmount, prio
 	var initSummary *init_.StateSummary
 	var cronSummary *cron.StateSummary
 	var verifregSummary *verifreg.StateSummary
+	var askregSummary *askreg.StateSummary
 	var marketSummary *market.StateSummary
 	var rewardSummary *reward.StateSummary
 	var accountSummaries []*account.StateSummary
@@ -129,6 +131,14 @@ func CheckStateInvariants(tree *Tree, expectedBalanceTotal abi.TokenAmount, prio
 			summary, msgs := verifreg.CheckStateInvariants(&st, tree.Store)
 			acc.WithPrefix("verifreg: ").AddAll(msgs)
 			verifregSummary = summary
+		case builtin.StorageAskRegistryActorCodeID:
+			var st askreg.State
+			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+				return err
+			}
+			summary, msgs := askreg.CheckStateInvariants(&st, tree.Store)
+			acc.WithPrefix("askreg: ").AddAll(msgs)
+			askregSummary = summary
 		default:
 			return xerrors.Errorf("unexpected actor code CID %v for address %v", actor.Code, key)
 		}
@@ -146,6 +156,7 @@ func CheckStateInvariants(tree *Tree, expectedBalanceTotal abi.TokenAmount, prio
 
 	_ = initSummary
 	_ = verifregSummary
+	_ = askregSummary
 	_ = cronSummary
 	_ = marketSummary
 	_ = rewardSummary
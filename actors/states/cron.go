@@ -0,0 +1,66 @@
+package states
+
+import (
+	"bytes"
+	"sort"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// A miner with other than exactly one proving deadline cron callback enrolled in the power actor.
+type MinerCronFault struct {
+	Miner addr.Address
+	// Epochs of the miner's proving deadline callbacks: empty if the callback is lost, or more than one
+	// if it is duplicated.
+	DeadlineCronEpochs []abi.ChainEpoch
+}
+
+// Finds miners with a power claim whose proving deadline cron callback is missing or duplicated, in order of address.
+// A miner without the callback does not process its deadlines, and may restore it with RestoreDeadlineCron.
+func FindMinerCronFaults(store adt.Store, pstate *power.State) ([]MinerCronFault, error) {
+	crons, err := pstate.LoadCronEventsByMiner(store)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := adt.AsMap(store, pstate.Claims, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load claims: %w", err)
+	}
+
+	var faults []MinerCronFault
+	if err = claims.ForEach(nil, func(key string) error {
+		maddr, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return xerrors.Errorf("failed to parse claim key %v: %w", key, err)
+		}
+
+		var deadlineEpochs []abi.ChainEpoch
+		for _, event := range crons[maddr] {
+			var payload miner.CronEventPayload
+			if err := payload.UnmarshalCBOR(bytes.NewReader(event.Payload)); err != nil {
+				return xerrors.Errorf("miner %v registered cron at epoch %d with corrupt payload: %w", maddr, event.Epoch, err)
+			}
+			if payload.EventType == miner.CronEventProvingDeadline {
+				deadlineEpochs = append(deadlineEpochs, event.Epoch)
+			}
+		}
+		if len(deadlineEpochs) != 1 {
+			faults = append(faults, MinerCronFault{Miner: maddr, DeadlineCronEpochs: deadlineEpochs})
+		}
+		return nil
+	}); err != nil {
+		return nil, xerrors.Errorf("failed to iterate claims: %w", err)
+	}
+
+	sort.Slice(faults, func(i, j int) bool {
+		return bytes.Compare(faults[i].Miner.Bytes(), faults[j].Miner.Bytes()) < 0
+	})
+	return faults, nil
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v3/support/ipld"
+	tutil "github.com/filecoin-project/specs-actors/v3/support/testing"
+	vm "github.com/filecoin-project/specs-actors/v3/support/vm"
+)
+
+func TestRotatedKeyNoLongerControlsAccount(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 2, big.Mul(big.NewInt(10_000), big.NewInt(1e18)), 93837778)
+	oldKey, other := addrs[0], addrs[1]
+	accountID, found := v.NormalizeAddress(oldKey)
+	require.True(t, found)
+
+	// A BLS key may not be replaced with a SECP key.
+	_, code := v.ApplyMessage(oldKey, accountID, big.Zero(), builtin.MethodsAccount.RotateKey,
+		&account.RotateKeyParams{NewAddress: tutil.NewSECP256K1Addr(t, "secp")})
+	assert.Equal(t, exitcode.ErrIllegalArgument, code)
+
+	// Nor with a key already belonging to another account.
+	_, code = v.ApplyMessage(oldKey, accountID, big.Zero(), builtin.MethodsAccount.RotateKey,
+		&account.RotateKeyParams{NewAddress: other})
+	assert.Equal(t, exitcode.ErrForbidden, code)
+
+	newKey := tutil.NewBLSAddr(t, 1)
+	vm.ApplyOk(t, v, oldKey, accountID, big.Zero(), builtin.MethodsAccount.RotateKey, &account.RotateKeyParams{NewAddress: newKey})
+
+	resolved, found := v.NormalizeAddress(newKey)
+	require.True(t, found)
+	assert.Equal(t, accountID, resolved)
+	_, found = v.NormalizeAddress(oldKey)
+	assert.False(t, found)
+
+	// Messages from the previous key are no longer accepted for the account.
+	_, code = v.ApplyMessage(oldKey, other, big.NewInt(1), builtin.MethodSend, nil)
+	assert.Equal(t, exitcode.SysErrSenderInvalid, code)
+	vm.ApplyOk(t, v, newKey, other, big.NewInt(1), builtin.MethodSend, nil)
+}
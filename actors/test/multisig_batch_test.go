@@ -0,0 +1,227 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	init_ "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/v3/support/ipld"
+	vm "github.com/filecoin-project/specs-actors/v3/support/vm"
+)
+
+func TestMultisigBatchExecutesAtomically(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 3, big.Mul(big.NewInt(10_000), big.NewInt(1e18)), 93837778)
+	signer, alice, bob := addrs[0], addrs[1], addrs[2]
+
+	multisigParams := multisig.ConstructorParams{
+		Signers:               []addr.Address{signer},
+		NumApprovalsThreshold: 1,
+	}
+	paramBuf := new(bytes.Buffer)
+	require.NoError(t, multisigParams.MarshalCBOR(paramBuf))
+
+	msigBalance := big.Mul(big.NewInt(100), big.NewInt(1e18))
+	initParam := init_.ExecParams{
+		CodeCID:           builtin.MultisigActorCodeID,
+		ConstructorParams: paramBuf.Bytes(),
+	}
+	ret := vm.ApplyOk(t, v, signer, builtin.InitActorAddr, msigBalance, builtin.MethodsInit.Exec, &initParam)
+	multisigAddr := ret.(*init_.ExecReturn).IDAddress
+
+	transfer := big.Mul(big.NewInt(10), big.NewInt(1e18))
+	proposeBatch := func(sends ...multisig.BatchSend) *multisig.ProposeReturn {
+		batchBuf := new(bytes.Buffer)
+		require.NoError(t, (&multisig.ExecuteBatchParams{Sends: sends}).MarshalCBOR(batchBuf))
+		ret := vm.ApplyOk(t, v, signer, multisigAddr, big.Zero(), builtin.MethodsMultisig.Propose, &multisig.ProposeParams{
+			To:     multisigAddr,
+			Value:  big.Zero(),
+			Method: builtin.MethodsMultisig.ExecuteBatch,
+			Params: batchBuf.Bytes(),
+		})
+		return ret.(*multisig.ProposeReturn)
+	}
+	balanceOf := func(a addr.Address) abi.TokenAmount {
+		act, found, err := v.GetActor(a)
+		require.NoError(t, err)
+		require.True(t, found)
+		return act.Balance
+	}
+	aliceBalance, bobBalance := balanceOf(alice), balanceOf(bob)
+
+	// A failing send rolls back the sends preceding it, and is reported with its exit code.
+	proposeRet := proposeBatch(
+		multisig.BatchSend{To: alice, Value: transfer, Method: builtin.MethodSend},
+		multisig.BatchSend{To: bob, Value: transfer, Method: abi.MethodNum(99)},
+		multisig.BatchSend{To: alice, Value: transfer, Method: builtin.MethodSend},
+	)
+	assert.True(t, proposeRet.Applied)
+	assert.Equal(t, exitcode.Ok, proposeRet.Code)
+	var failedRet multisig.ExecuteBatchReturn
+	require.NoError(t, failedRet.UnmarshalCBOR(bytes.NewReader(proposeRet.Ret)))
+	assert.Equal(t, []multisig.BatchSendResult{
+		{Code: exitcode.Ok},
+		{Code: exitcode.SysErrInvalidMethod},
+	}, failedRet.Results)
+	assert.Equal(t, msigBalance, balanceOf(multisigAddr))
+	assert.Equal(t, aliceBalance, balanceOf(alice))
+	assert.Equal(t, bobBalance, balanceOf(bob))
+
+	// A successful batch performs every send and reports each result.
+	proposeRet = proposeBatch(
+		multisig.BatchSend{To: alice, Value: transfer, Method: builtin.MethodSend},
+		multisig.BatchSend{To: bob, Value: transfer, Method: builtin.MethodSend},
+	)
+	assert.True(t, proposeRet.Applied)
+	assert.Equal(t, exitcode.Ok, proposeRet.Code)
+	var batchRet multisig.ExecuteBatchReturn
+	require.NoError(t, batchRet.UnmarshalCBOR(bytes.NewReader(proposeRet.Ret)))
+	require.Len(t, batchRet.Results, 2)
+	assert.Equal(t, big.Sub(msigBalance, big.Mul(transfer, big.NewInt(2))), balanceOf(multisigAddr))
+	assert.Equal(t, big.Add(aliceBalance, transfer), balanceOf(alice))
+	assert.Equal(t, big.Add(bobBalance, transfer), balanceOf(bob))
+}
+
+func TestMultisigNestedBatchCannotBypassApprovalPolicy(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 3, big.Mul(big.NewInt(10_000), big.NewInt(1e18)), 93837778)
+	signer, alice, bob := addrs[0], addrs[1], addrs[2]
+
+	multisigParams := multisig.ConstructorParams{
+		Signers:               []addr.Address{signer, alice},
+		NumApprovalsThreshold: 1,
+	}
+	paramBuf := new(bytes.Buffer)
+	require.NoError(t, multisigParams.MarshalCBOR(paramBuf))
+	initParam := init_.ExecParams{
+		CodeCID:           builtin.MultisigActorCodeID,
+		ConstructorParams: paramBuf.Bytes(),
+	}
+	ret := vm.ApplyOk(t, v, signer, builtin.InitActorAddr, big.Zero(), builtin.MethodsInit.Exec, &initParam)
+	multisigAddr := ret.(*init_.ExecReturn).IDAddress
+
+	propose := func(method abi.MethodNum, params cbor.Marshaler) *multisig.ProposeReturn {
+		return proposeToSelf(t, v, signer, multisigAddr, method, params)
+	}
+	batchOf := func(method abi.MethodNum, params cbor.Marshaler) *multisig.ExecuteBatchParams {
+		return selfBatch(t, multisigAddr, method, params)
+	}
+
+	// Adding a signer requires both signers' approval.
+	proposeRet := propose(builtin.MethodsMultisig.SetApprovalPolicy, &multisig.SetApprovalPolicyParams{
+		To:        multisigAddr,
+		Method:    builtin.MethodsMultisig.AddSigner,
+		Threshold: 2,
+	})
+	require.True(t, proposeRet.Applied)
+	require.Equal(t, exitcode.Ok, proposeRet.Code)
+
+	addBob := &multisig.AddSignerParams{Signer: bob}
+
+	// A batch adding a signer requires the policy threshold.
+	proposeRet = propose(builtin.MethodsMultisig.ExecuteBatch, batchOf(builtin.MethodsMultisig.AddSigner, addBob))
+	assert.False(t, proposeRet.Applied)
+
+	// A batch wrapping that batch is rejected on execution rather than adding the signer.
+	nested := batchOf(builtin.MethodsMultisig.ExecuteBatch, batchOf(builtin.MethodsMultisig.AddSigner, addBob))
+	proposeRet = propose(builtin.MethodsMultisig.ExecuteBatch, nested)
+	assert.True(t, proposeRet.Applied)
+	assert.Equal(t, exitcode.ErrIllegalArgument, proposeRet.Code)
+
+	var st multisig.State
+	require.NoError(t, v.GetState(multisigAddr, &st))
+	assert.Len(t, st.Signers, 2)
+}
+
+func TestMultisigNestedBatchCannotBypassRevocationThreshold(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 3, big.Mul(big.NewInt(10_000), big.NewInt(1e18)), 93837778)
+	signer, alice, bob := addrs[0], addrs[1], addrs[2]
+
+	multisigParams := multisig.ConstructorParams{
+		Signers:               []addr.Address{signer, alice},
+		NumApprovalsThreshold: 1,
+	}
+	paramBuf := new(bytes.Buffer)
+	require.NoError(t, multisigParams.MarshalCBOR(paramBuf))
+	initParam := init_.ExecParams{
+		CodeCID:           builtin.MultisigActorCodeID,
+		ConstructorParams: paramBuf.Bytes(),
+	}
+	msigBalance := big.Mul(big.NewInt(100), big.NewInt(1e18))
+	ret := vm.ApplyOk(t, v, signer, builtin.InitActorAddr, msigBalance, builtin.MethodsInit.Exec, &initParam)
+	multisigAddr := ret.(*init_.ExecReturn).IDAddress
+
+	propose := func(method abi.MethodNum, params cbor.Marshaler) *multisig.ProposeReturn {
+		return proposeToSelf(t, v, signer, multisigAddr, method, params)
+	}
+	batchOf := func(method abi.MethodNum, params cbor.Marshaler) *multisig.ExecuteBatchParams {
+		return selfBatch(t, multisigAddr, method, params)
+	}
+
+	// Vest the whole balance over a year, revocable by both signers.
+	proposeRet := propose(builtin.MethodsMultisig.SetVestingSchedule, &multisig.SetVestingScheduleParams{
+		StartEpoch:          v.GetEpoch(),
+		Tranches:            []multisig.VestingTranche{{Duration: 365 * builtin.EpochsInDay, Amount: msigBalance}},
+		RevocationThreshold: 2,
+	})
+	require.True(t, proposeRet.Applied)
+	require.Equal(t, exitcode.Ok, proposeRet.Code)
+
+	revoke := &multisig.RevokeVestingParams{To: bob}
+
+	// Revocation in a batch requires the revocation threshold.
+	proposeRet = propose(builtin.MethodsMultisig.ExecuteBatch, batchOf(builtin.MethodsMultisig.RevokeVesting, revoke))
+	assert.False(t, proposeRet.Applied)
+
+	// Revocation two batches deep is rejected on execution rather than revoking the schedule.
+	nested := batchOf(builtin.MethodsMultisig.ExecuteBatch, batchOf(builtin.MethodsMultisig.RevokeVesting, revoke))
+	proposeRet = propose(builtin.MethodsMultisig.ExecuteBatch, nested)
+	assert.True(t, proposeRet.Applied)
+	assert.Equal(t, exitcode.ErrIllegalArgument, proposeRet.Code)
+
+	var st multisig.State
+	require.NoError(t, v.GetState(multisigAddr, &st))
+	require.NotNil(t, st.VestingSchedule)
+	assert.Equal(t, msigBalance, st.InitialBalance)
+	act, found, err := v.GetActor(multisigAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, msigBalance, act.Balance)
+}
+
+// Proposes a transaction from a multisig to itself.
+func proposeToSelf(t *testing.T, v *vm.VM, proposer, multisigAddr addr.Address, method abi.MethodNum, params cbor.Marshaler) *multisig.ProposeReturn {
+	buf := new(bytes.Buffer)
+	require.NoError(t, params.MarshalCBOR(buf))
+	ret := vm.ApplyOk(t, v, proposer, multisigAddr, big.Zero(), builtin.MethodsMultisig.Propose, &multisig.ProposeParams{
+		To:     multisigAddr,
+		Value:  big.Zero(),
+		Method: method,
+		Params: buf.Bytes(),
+	})
+	return ret.(*multisig.ProposeReturn)
+}
+
+// A batch of a single send from a multisig to itself.
+func selfBatch(t *testing.T, multisigAddr addr.Address, method abi.MethodNum, params cbor.Marshaler) *multisig.ExecuteBatchParams {
+	buf := new(bytes.Buffer)
+	require.NoError(t, params.MarshalCBOR(buf))
+	return &multisig.ExecuteBatchParams{Sends: []multisig.BatchSend{
+		{To: multisigAddr, Value: big.Zero(), Method: method, Params: buf.Bytes()},
+	}}
+}
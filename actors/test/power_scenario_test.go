@@ -6,11 +6,15 @@ import (
 
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v3/actors/states"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v3/support/ipld"
 	vm "github.com/filecoin-project/specs-actors/v3/support/vm"
 )
@@ -136,3 +140,56 @@ func TestOnEpochTickEnd(t *testing.T) {
 		}},
 	}.Matches(t, v.Invocations()[0])
 }
+
+func TestRestoreDeadlineCron(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 1, big.Mul(big.NewInt(10_000), big.NewInt(1e18)), 93837778)
+
+	params := power.CreateMinerParams{Owner: addrs[0], Worker: addrs[0],
+		WindowPoStProofType: abi.RegisteredPoStProof_StackedDrgWindow32GiBV1,
+		Peer:                abi.PeerID("pid")}
+	ret := vm.ApplyOk(t, v, addrs[0], builtin.StoragePowerActorAddr, big.NewInt(1e10), builtin.MethodsPower.CreateMiner, &params)
+	minerAddrs, ok := ret.(*power.CreateMinerReturn)
+	require.True(t, ok)
+	cronConfig, ok := vm.ParamsForInvocation(t, v, 0, 0, 0, 0).(*power.EnrollCronEventParams)
+	require.True(t, ok)
+
+	cronFaults := func() []states.MinerCronFault {
+		var st power.State
+		require.NoError(t, v.GetState(builtin.StoragePowerActorAddr, &st))
+		faults, err := states.FindMinerCronFaults(v.Store(), &st)
+		require.NoError(t, err)
+		return faults
+	}
+	minerCrons := func() []power.MinerCronEvent {
+		var st power.State
+		require.NoError(t, v.GetState(builtin.StoragePowerActorAddr, &st))
+		events, err := st.LoadMinerCronEvents(v.Store(), minerAddrs.IDAddress)
+		require.NoError(t, err)
+		return events
+	}
+	assert.Empty(t, cronFaults())
+
+	// Restoring a callback that is in place has no effect.
+	vm.ApplyOk(t, v, addrs[0], minerAddrs.RobustAddress, big.Zero(), builtin.MethodsMiner.RestoreDeadlineCron, nil)
+	assert.Equal(t, []power.MinerCronEvent{{Epoch: cronConfig.EventEpoch, Payload: cronConfig.Payload}}, minerCrons())
+
+	// Lose the callback.
+	var st power.State
+	require.NoError(t, v.GetState(builtin.StoragePowerActorAddr, &st))
+	emptyQueue, err := adt.StoreEmptyMultimap(v.Store(), power.CronQueueHamtBitwidth, power.CronQueueAmtBitwidth)
+	require.NoError(t, err)
+	st.CronEventQueue = emptyQueue
+	require.NoError(t, v.SetActorState(ctx, builtin.StoragePowerActorAddr, &st))
+	assert.Equal(t, []states.MinerCronFault{{Miner: minerAddrs.IDAddress}}, cronFaults())
+
+	// Only the miner's owner, worker or control addresses may restore it.
+	others := vm.CreateAccounts(ctx, t, v, 1, big.Mul(big.NewInt(10_000), big.NewInt(1e18)), 93837779)
+	_, code := v.ApplyMessage(others[0], minerAddrs.RobustAddress, big.Zero(), builtin.MethodsMiner.RestoreDeadlineCron, nil)
+	assert.Equal(t, exitcode.ErrForbidden, code)
+
+	vm.ApplyOk(t, v, addrs[0], minerAddrs.RobustAddress, big.Zero(), builtin.MethodsMiner.RestoreDeadlineCron, nil)
+	assert.Empty(t, cronFaults())
+	assert.Equal(t, []power.MinerCronEvent{{Epoch: cronConfig.EventEpoch, Payload: cronConfig.Payload}}, minerCrons())
+}
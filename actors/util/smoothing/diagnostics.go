@@ -0,0 +1,197 @@
+package smoothing
+
+import (
+	gbig "math/big"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/util/math"
+)
+
+// Tools for offline evaluation of alternative filters against the one used by the actors.
+// None of this is used in actor code.
+
+// Produces a filter estimate from the previous estimate and a new observation.
+// An estimator may keep state between observations, so each series of observations needs a new instance.
+type Estimator interface {
+	NextEstimate(prev FilterEstimate, observation big.Int, epochDelta abi.ChainEpoch) FilterEstimate
+}
+
+type alphaBetaEstimator struct {
+	alpha, beta big.Int // Q.128
+}
+
+// An estimator applying the actors' alpha-beta filter with fixed Q.128 parameters.
+func AlphaBetaEstimator(alpha, beta big.Int) Estimator {
+	return &alphaBetaEstimator{alpha: alpha, beta: beta}
+}
+
+// The estimator used by the actors for reward and network power.
+func DefaultEstimator() Estimator {
+	return AlphaBetaEstimator(DefaultAlpha, DefaultBeta)
+}
+
+func (e *alphaBetaEstimator) NextEstimate(prev FilterEstimate, observation big.Int, epochDelta abi.ChainEpoch) FilterEstimate {
+	return LoadFilter(prev, e.alpha, e.beta).NextEstimate(observation, epochDelta)
+}
+
+// An estimator applying the alpha-beta filter with gains from a Kalman filter of a constant-velocity model.
+// The gains adapt from large to small as the uncertainty in the estimate falls, converging to a steady state
+// determined by the ratio of process to measurement noise.
+// The covariance is computed in floating point, but the estimate is updated with the actors' fixed-point code.
+type kalmanEstimator struct {
+	processNoise     float64 // Variance of acceleration, per epoch
+	measurementNoise float64 // Variance of observations
+	p00, p01, p11    float64 // Covariance of the position and velocity estimates
+}
+
+// An estimator with Kalman filter gains, starting from an initial variance of the position estimate and
+// of the velocity estimate.
+// Noise and variances are in units of the observations, so only their ratios affect the gains.
+func KalmanEstimator(processNoise, measurementNoise, initialPositionVariance, initialVelocityVariance float64) Estimator {
+	return &kalmanEstimator{
+		processNoise:     processNoise,
+		measurementNoise: measurementNoise,
+		p00:              initialPositionVariance,
+		p11:              initialVelocityVariance,
+	}
+}
+
+func (e *kalmanEstimator) NextEstimate(prev FilterEstimate, observation big.Int, epochDelta abi.ChainEpoch) FilterEstimate {
+	dt := float64(epochDelta)
+	// Predict: P = F P F' + Q, for F = [1 dt; 0 1] and Q the white-noise acceleration model.
+	p00 := e.p00 + 2*dt*e.p01 + dt*dt*e.p11 + e.processNoise*dt*dt*dt/3
+	p01 := e.p01 + dt*e.p11 + e.processNoise*dt*dt/2
+	p11 := e.p11 + e.processNoise*dt
+
+	// Gains for the position and velocity, from the innovation variance.
+	s := p00 + e.measurementNoise
+	k0 := p00 / s
+	k1 := p01 / s
+
+	// Update: P = (I - K H) P
+	e.p00 = (1 - k0) * p00
+	e.p01 = (1 - k0) * p01
+	e.p11 = p11 - k1*p01
+
+	// The alpha-beta filter divides the velocity revision by the epoch delta.
+	alpha := Q128FromFloat(k0)
+	beta := Q128FromFloat(k1 * dt)
+	return LoadFilter(prev, alpha, beta).NextEstimate(observation, epochDelta)
+}
+
+// Converts a number to Q.128 format, for expressing filter parameters.
+func Q128FromFloat(f float64) big.Int {
+	q := new(gbig.Float).SetFloat64(f)
+	q.SetMantExp(q, math.Precision128)
+	i, _ := q.Int(nil)
+	return big.NewFromGo(i)
+}
+
+// An observation of the per-epoch reward and the network's quality-adjusted power, as made by the reward
+// and power actors.
+type Observation struct {
+	Epoch   abi.ChainEpoch
+	Reward  abi.TokenAmount
+	QAPower abi.StoragePower
+}
+
+type ReplayConfig struct {
+	// Epoch of the initial estimates, before the first observation.
+	StartEpoch    abi.ChainEpoch
+	InitialReward FilterEstimate
+	InitialPower  FilterEstimate
+	// Epochs over which the cumulative ratio of reward to power is extrapolated from each pair of estimates,
+	// e.g. the initial pledge projection period.
+	ProjectionDuration abi.ChainEpoch
+	// Computes a pledge from a pair of estimates, e.g. by wrapping miner.InitialPledgeForPower.
+	// If nil, pledges are not reported.
+	Pledge func(reward, power FilterEstimate) abi.TokenAmount
+}
+
+// A way of estimating reward and power, to be compared with others.
+// Since estimators may keep state, a candidate makes new ones for each series it replays.
+type Candidate struct {
+	Name   string
+	Reward func() Estimator
+	Power  func() Estimator
+}
+
+// The estimates made by a candidate after each observation, and the quantities the actors derive from them.
+type CandidateResult struct {
+	Name            string
+	RewardEstimates []FilterEstimate
+	PowerEstimates  []FilterEstimate
+	// ExtrapolatedCumSumOfRatio of reward to power over the projection duration, in Q.128 format.
+	CumSumRatios []big.Int
+	// Differences of the cumulative ratios from those of the first candidate, in Q.128 format.
+	CumSumRatioDiffs []big.Int
+	// Pledges, and their differences from those of the first candidate, if a pledge function is configured.
+	Pledges     []abi.TokenAmount
+	PledgeDiffs []abi.TokenAmount
+	// The greatest magnitude of the pledge differences.
+	MaxPledgeDiff abi.TokenAmount
+}
+
+// Replays a series of observations through each candidate's estimators, and reports the results of each
+// against the first candidate, which is typically the actors' own filter.
+// Observations must be in increasing order of epoch, after the start epoch.
+func CompareEstimators(config ReplayConfig, observations []Observation, candidates ...Candidate) ([]CandidateResult, error) {
+	if len(candidates) == 0 {
+		return nil, xerrors.Errorf("no candidates to compare")
+	}
+	prevEpoch := config.StartEpoch
+	for _, o := range observations {
+		if o.Epoch <= prevEpoch {
+			return nil, xerrors.Errorf("observation epoch %d not after %d", o.Epoch, prevEpoch)
+		}
+		prevEpoch = o.Epoch
+	}
+
+	for _, c := range candidates {
+		if c.Reward == nil || c.Power == nil {
+			return nil, xerrors.Errorf("candidate %s missing an estimator", c.Name)
+		}
+	}
+
+	results := make([]CandidateResult, len(candidates))
+	for i, c := range candidates {
+		result := CandidateResult{Name: c.Name, MaxPledgeDiff: big.Zero()}
+		rewardEstimator, powerEstimator := c.Reward(), c.Power()
+		reward, power := config.InitialReward, config.InitialPower
+		prevEpoch := config.StartEpoch
+		for _, o := range observations {
+			delta := o.Epoch - prevEpoch
+			prevEpoch = o.Epoch
+			reward = rewardEstimator.NextEstimate(reward, o.Reward, delta)
+			power = powerEstimator.NextEstimate(power, o.QAPower, delta)
+			if power.PositionEstimate.LessThanEqual(big.Zero()) {
+				return nil, xerrors.Errorf("candidate %s estimated non-positive power at epoch %d", c.Name, o.Epoch)
+			}
+
+			result.RewardEstimates = append(result.RewardEstimates, reward)
+			result.PowerEstimates = append(result.PowerEstimates, power)
+			result.CumSumRatios = append(result.CumSumRatios, ExtrapolatedCumSumOfRatio(config.ProjectionDuration, 0, reward, power))
+			if config.Pledge != nil {
+				result.Pledges = append(result.Pledges, config.Pledge(reward, power))
+			}
+		}
+
+		baseline := &results[0]
+		if i == 0 {
+			baseline = &result
+		}
+		for j := range result.CumSumRatios {
+			result.CumSumRatioDiffs = append(result.CumSumRatioDiffs, big.Sub(result.CumSumRatios[j], baseline.CumSumRatios[j]))
+		}
+		for j := range result.Pledges {
+			diff := big.Sub(result.Pledges[j], baseline.Pledges[j])
+			result.PledgeDiffs = append(result.PledgeDiffs, diff)
+			result.MaxPledgeDiff = big.Max(result.MaxPledgeDiff, diff.Abs())
+		}
+		results[i] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,123 @@
+package smoothing_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/util/math"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
+)
+
+func TestCompareEstimators(t *testing.T) {
+	// Reward falls and power grows steadily, from estimates that have not yet learned the trend.
+	var observations []smoothing.Observation
+	for e := abi.ChainEpoch(1); e <= 3000; e++ {
+		observations = append(observations, smoothing.Observation{
+			Epoch:   e,
+			Reward:  big.NewInt(6e18 - int64(e)*1e13),
+			QAPower: big.NewInt(1<<50 + int64(e)<<30),
+		})
+	}
+	sectorPower := big.NewInt(32 << 30)
+	config := smoothing.ReplayConfig{
+		StartEpoch:         0,
+		InitialReward:      smoothing.NewEstimate(big.NewInt(6e18), big.Zero()),
+		InitialPower:       smoothing.NewEstimate(big.NewInt(1<<50), big.Zero()),
+		ProjectionDuration: 20 * 2880,
+		Pledge: func(reward, power smoothing.FilterEstimate) abi.TokenAmount {
+			ratio := smoothing.ExtrapolatedCumSumOfRatio(20*2880, 0, reward, power)
+			return big.Rsh(big.Mul(sectorPower, ratio), math.Precision128)
+		},
+	}
+
+	fast := func() smoothing.Estimator {
+		return smoothing.AlphaBetaEstimator(smoothing.Q128FromFloat(1e-2), smoothing.Q128FromFloat(1e-5))
+	}
+	kalman := func() smoothing.Estimator {
+		return smoothing.KalmanEstimator(1e-4, 1, 1, 1)
+	}
+	results, err := smoothing.CompareEstimators(config, observations,
+		smoothing.Candidate{Name: "default", Reward: smoothing.DefaultEstimator, Power: smoothing.DefaultEstimator},
+		smoothing.Candidate{Name: "fast", Reward: fast, Power: fast},
+		smoothing.Candidate{Name: "kalman", Reward: kalman, Power: kalman},
+	)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	// The first candidate reproduces the actors' filter, and is the baseline for differences.
+	reward, power := config.InitialReward, config.InitialPower
+	prev := abi.ChainEpoch(0)
+	for i, o := range observations {
+		reward = smoothing.LoadFilter(reward, smoothing.DefaultAlpha, smoothing.DefaultBeta).NextEstimate(o.Reward, o.Epoch-prev)
+		power = smoothing.LoadFilter(power, smoothing.DefaultAlpha, smoothing.DefaultBeta).NextEstimate(o.QAPower, o.Epoch-prev)
+		prev = o.Epoch
+		assert.Equal(t, reward, results[0].RewardEstimates[i])
+		assert.Equal(t, power, results[0].PowerEstimates[i])
+		assert.True(t, results[0].CumSumRatioDiffs[i].IsZero())
+		assert.True(t, results[0].PledgeDiffs[i].IsZero())
+	}
+	assert.True(t, results[0].MaxPledgeDiff.IsZero())
+
+	// The alternatives learn the trend faster than the actors' filter, so their final reward estimates are
+	// closer to it, and their pledges differ.
+	last := len(observations) - 1
+	trendError := func(estimate smoothing.FilterEstimate) big.Int {
+		return big.Sub(estimate.Estimate(), big.NewInt(6e18-int64(last+1)*1e13)).Abs()
+	}
+	for _, result := range results[1:] {
+		assert.Len(t, result.Pledges, len(observations))
+		assert.True(t, trendError(result.RewardEstimates[last]).LessThan(trendError(results[0].RewardEstimates[last])), result.Name)
+		assert.True(t, result.MaxPledgeDiff.GreaterThanEqual(result.PledgeDiffs[last].Abs()), result.Name)
+		assert.True(t, result.MaxPledgeDiff.GreaterThan(big.Zero()), result.Name)
+		for i := range result.CumSumRatios {
+			assert.Equal(t, big.Sub(result.CumSumRatios[i], results[0].CumSumRatios[i]), result.CumSumRatioDiffs[i])
+		}
+	}
+
+	// Each replay starts from new estimators, so a candidate replayed again reproduces its results.
+	again, err := smoothing.CompareEstimators(config, observations,
+		smoothing.Candidate{Name: "kalman", Reward: kalman, Power: kalman},
+		smoothing.Candidate{Name: "kalman again", Reward: kalman, Power: kalman},
+	)
+	require.NoError(t, err)
+	for _, result := range again {
+		assert.Equal(t, results[2].RewardEstimates, result.RewardEstimates)
+		assert.Equal(t, results[2].PowerEstimates, result.PowerEstimates)
+	}
+}
+
+func TestCompareEstimatorsValidation(t *testing.T) {
+	config := smoothing.ReplayConfig{
+		StartEpoch:         10,
+		InitialReward:      smoothing.NewEstimate(big.NewInt(1), big.Zero()),
+		InitialPower:       smoothing.NewEstimate(big.NewInt(1), big.Zero()),
+		ProjectionDuration: 10,
+	}
+	candidate := smoothing.Candidate{Name: "default", Reward: smoothing.DefaultEstimator, Power: smoothing.DefaultEstimator}
+
+	_, err := smoothing.CompareEstimators(config, nil)
+	assert.Error(t, err)
+
+	_, err = smoothing.CompareEstimators(config, nil, smoothing.Candidate{Name: "incomplete", Reward: smoothing.DefaultEstimator})
+	assert.Error(t, err)
+
+	_, err = smoothing.CompareEstimators(config, []smoothing.Observation{{Epoch: 10, Reward: big.NewInt(1), QAPower: big.NewInt(1)}}, candidate)
+	assert.Error(t, err)
+
+	results, err := smoothing.CompareEstimators(config, []smoothing.Observation{{Epoch: 11, Reward: big.NewInt(1), QAPower: big.NewInt(1)}}, candidate)
+	require.NoError(t, err)
+	assert.Nil(t, results[0].Pledges)
+}
+
+func TestQ128FromFloat(t *testing.T) {
+	assert.Equal(t, big.Lsh(big.NewInt(3), math.Precision128-1), smoothing.Q128FromFloat(1.5))
+	// The default parameters are within a part in 10^4 of their decimal descriptions.
+	alpha := smoothing.Q128FromFloat(9.25e-4)
+	assert.True(t, big.Mul(big.Sub(alpha, smoothing.DefaultAlpha).Abs(), big.NewInt(1e4)).LessThan(smoothing.DefaultAlpha))
+	beta := smoothing.Q128FromFloat(2.84e-7)
+	assert.True(t, big.Mul(big.Sub(beta, smoothing.DefaultBeta).Abs(), big.NewInt(1e4)).LessThan(smoothing.DefaultBeta))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/askreg"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/cron"
 	init_ "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/market"
@@ -55,6 +56,13 @@ func main() {
 	if err := gen.WriteTupleEncodersToFile("./actors/builtin/account/cbor_gen.go", "account",
 		// actor state
 		account.State{},
+		account.RecoveryConfig{},
+		account.RecoveryApproval{},
+		account.PendingRecovery{},
+		// method params and returns
+		account.RotateKeyParams{},
+		account.SetGuardiansParams{},
+		account.ApproveRecoveryParams{},
 	); err != nil {
 		panic(err)
 	}
@@ -62,10 +70,14 @@ func main() {
 	if err := gen.WriteTupleEncodersToFile("./actors/builtin/init/cbor_gen.go", "init",
 		// actor state
 		init_.State{},
+		init_.ActorAlias{},
 		// method params and returns
 		//init_.ConstructorParams{}, // Aliased from v0
 		//init_.ExecParams{}, // Aliased from v0
 		//init_.ExecReturn{}, // Aliased from v0
+		init_.Exec2Params{},
+		init_.SetAliasParams{},
+		init_.UpdateAccountAddressParams{},
 	); err != nil {
 		panic(err)
 	}
@@ -74,8 +86,13 @@ func main() {
 		// actor state
 		cron.State{},
 		cron.Entry{},
+		cron.FailureRecord{},
 		// method params and returns
-		//cron.ConstructorParams{}, // Aliased from v0
+		cron.ConstructorParams{},
+		cron.RegisterEntryParams{},
+		cron.RemoveEntryParams{},
+		cron.SetGovernorParams{},
+		cron.ListEntriesReturn{},
 	); err != nil {
 		panic(err)
 	}
@@ -83,9 +100,13 @@ func main() {
 	if err := gen.WriteTupleEncodersToFile("./actors/builtin/reward/cbor_gen.go", "reward",
 		// actor state
 		reward.State{},
+		reward.RewardPolicy{},
+		reward.RewardConstants{},
+		reward.Checkpoint{},
 		// method params and returns
 		//reward.AwardBlockRewardParams{}, // Aliased from v0
 		reward.ThisEpochRewardReturn{},
+		reward.ConstructorParams{},
 	); err != nil {
 		panic(err)
 	}
@@ -93,11 +114,15 @@ func main() {
 	if err := gen.WriteTupleEncodersToFile("./actors/builtin/multisig/cbor_gen.go", "multisig",
 		// actor state
 		multisig.State{},
-		//multisig.Transaction{}, // Aliased from v0
-		//multisig.ProposalHashData{}, // Aliased from v0
+		multisig.Transaction{},
+		multisig.ProposalHashData{},
+		multisig.ApprovalPolicy{},
+		multisig.VestingSchedule{},
+		multisig.VestingTranche{},
 		// method params and returns
 		// multisig.ConstructorParams{}, // Aliased from v2
 		//multisig.ProposeParams{}, // Aliased from v0
+		multisig.ProposeWithScheduleParams{},
 		//multisig.ProposeReturn{}, // Aliased from v0
 		//multisig.AddSignerParams{}, // Aliased from v0
 		//multisig.RemoveSignerParams{}, // Aliased from v0
@@ -106,6 +131,16 @@ func main() {
 		//multisig.ChangeNumApprovalsThresholdParams{}, // Aliased from v0
 		//multisig.SwapSignerParams{}, // Aliased from v0
 		//multisig.LockBalanceParams{}, // Aliased from v0
+		multisig.PruneExpiredParams{},
+		multisig.SetSignerWeightParams{},
+		multisig.SetApprovalPolicyParams{},
+		multisig.BatchSend{},
+		multisig.ExecuteBatchParams{},
+		multisig.BatchSendResult{},
+		multisig.ApplyBatchParams{},
+		multisig.ExecuteBatchReturn{},
+		multisig.SetVestingScheduleParams{},
+		multisig.RevokeVestingParams{},
 	); err != nil {
 		panic(err)
 	}
@@ -114,11 +149,19 @@ func main() {
 		// actor state
 		paych.State{},
 		paych.LaneState{},
+		paych.Deposit{},
+		paych.ConditionalVoucher{},
 		// method params and returns
 		//paych.ConstructorParams{}, // Aliased from v0
 		// paych.UpdateChannelStateParams{}, // Aliased from v2
 		//paych.SignedVoucher{}, // Aliased from v0
 		//paych.ModVerifyParams{}, // Aliased from v0
+		paych.WithdrawParams{},
+		paych.LockConditionalVoucherParams{},
+		paych.RevealSecretParams{},
+		paych.ReleaseConditionalVoucherParams{},
+		paych.ApproveVoucherParams{},
+		paych.DelegateSignerParams{},
 		// other types
 		//paych.Merge{}, // Aliased from v0
 	); err != nil {
@@ -136,6 +179,7 @@ func main() {
 		//power.EnrollCronEventParams{}, // Aliased from v0
 		//power.UpdateClaimedPowerParams{}, // Aliased from v0
 		power.CurrentTotalPowerReturn{},
+		power.MinerPowerReturn{},
 		// other types
 		power.MinerConstructorParams{},
 	); err != nil {
@@ -147,19 +191,25 @@ func main() {
 		market.State{},
 		// method params and returns
 		//market.WithdrawBalanceParams{}, // Aliased from v0
-		//market.PublishStorageDealsParams{}, // Aliased from v0
+		market.PublishStorageDealsParams{},
 		//market.PublishStorageDealsReturn{}, // Aliased from v0
 		//market.ActivateDealsParams{}, // Aliased from v0
 		market.VerifyDealsForActivationParams{},
 		market.VerifyDealsForActivationReturn{},
+		market.SettleDealPaymentsParams{},
+		market.SettleDealPaymentsReturn{},
 		//market.ComputeDataCommitmentParams{}, // Aliased from v0
 		//market.OnMinerSectorsTerminateParams{}, // Aliased from v0
 		// other types
-		//market.DealProposal{}, // Aliased from v0
-		//market.ClientDealProposal{}, // Aliased from v0
+		market.DealProposal{},
+		market.ClientDealProposal{},
+		market.DealMetadata{},
 		market.SectorDeals{},
 		market.SectorWeights{},
 		market.DealState{},
+		market.PieceReplicas{},
+		market.ProviderReplicas{},
+		market.DealSettlement{},
 	); err != nil {
 		panic(err)
 	}
@@ -213,12 +263,35 @@ func main() {
 	if err := gen.WriteTupleEncodersToFile("./actors/builtin/verifreg/cbor_gen.go", "verifreg",
 		// actor state
 		verifreg.State{},
+		verifreg.Allocation{},
+		verifreg.RmDcProposalID{},
+		verifreg.VerifierInfo{},
+		verifreg.Grant{},
 		// method params and returns
 		//verifreg.AddVerifierParams{}, // Aliased from v0
+		verifreg.AddVerifierWithPolicyParams{},
 		//verifreg.AddVerifiedClientParams{}, // Aliased from v0
 		//verifreg.UseBytesParams{}, // Aliased from v0
 		//verifreg.RestoreBytesParams{}, // Aliased from v0
+		verifreg.RemoveDataCapParams{},
+		verifreg.RemoveDataCapReturn{},
+		// other types
+		verifreg.RemoveDataCapProposal{},
+		verifreg.RemoveDataCapRequest{},
+		verifreg.VerifierPolicy{},
+		verifreg.RecentGrant{},
+	); err != nil {
+		panic(err)
+	}
+
+	if err := gen.WriteTupleEncodersToFile("./actors/builtin/askreg/cbor_gen.go", "askreg",
+		// actor state
+		askreg.State{},
+		// method params and returns
+		askreg.PublishAskParams{},
+		askreg.GetAskReturn{},
 		// other types
+		askreg.StorageAsk{},
 	); err != nil {
 		panic(err)
 	}
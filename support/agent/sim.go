@@ -78,7 +78,7 @@ func (s *Sim) Tick() error {
 	var blockMessages []message
 
 	// compute power table before state transition to create block rewards at the end
-	powerTable, err := computePowerTable(s.v, s.Agents)
+	powerTable, err := computePowerTable(s.v)
 	if err != nil {
 		return err
 	}
@@ -242,7 +242,7 @@ func (s *Sim) rewardMiner(addr address.Address, wins uint64) error {
 	return nil
 }
 
-func computePowerTable(v *vm.VM, agents []Agent) (powerTable, error) {
+func computePowerTable(v *vm.VM) (powerTable, error) {
 	pt := powerTable{}
 
 	var rwst reward.State
@@ -255,20 +255,14 @@ func computePowerTable(v *vm.VM, agents []Agent) (powerTable, error) {
 	if err := v.GetState(builtin.StoragePowerActorAddr, &st); err != nil {
 		return powerTable{}, err
 	}
-	pt.totalQAPower = st.TotalQualityAdjPower
-
-	for _, agent := range agents {
-		if miner, ok := agent.(*MinerAgent); ok {
-			if claim, found, err := st.GetClaim(v.Store(), miner.IDAddress); err != nil {
-				return pt, err
-			} else if found {
-				if sufficient, err := st.MinerNominalPowerMeetsConsensusMinimum(v.Store(), miner.IDAddress); err != nil {
-					return pt, err
-				} else if sufficient {
-					pt.minerPower = append(pt.minerPower, minerPowerTable{miner.IDAddress, claim.QualityAdjPower})
-				}
-			}
-		}
+	table, err := st.PowerTable(v.Store())
+	if err != nil {
+		return powerTable{}, err
+	}
+	pt.totalQAPower = table.TotalQualityAdjPower
+
+	for _, entry := range table.Miners {
+		pt.minerPower = append(pt.minerPower, minerPowerTable{entry.Miner, entry.QualityAdjPower})
 	}
 	return pt, nil
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/askreg"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/cron"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/exported"
 	initactor "github.com/filecoin-project/specs-actors/v3/actors/builtin/init"
@@ -66,10 +67,13 @@ func NewVMWithSingletons(ctx context.Context, t testing.TB, bs ipldcbor.IpldBloc
 	require.NoError(t, err)
 	initializeActor(ctx, t, vm, initState, builtin.InitActorCodeID, builtin.InitActorAddr, big.Zero())
 
-	rewardState := reward.ConstructState(abi.NewStoragePower(0))
+	rewardState, err := reward.ConstructState(store, abi.NewStoragePower(0), reward.DefaultRewardPolicy())
+	require.NoError(t, err)
 	initializeActor(ctx, t, vm, rewardState, builtin.RewardActorCodeID, builtin.RewardActorAddr, reward.StorageMiningAllocationCheck)
 
-	cronState := cron.ConstructState(cron.BuiltInEntries())
+	// The verified registry root also governs the cron table, as it does after migration.
+	cronGovernor := VerifregRoot
+	cronState := cron.ConstructState(cron.BuiltInEntries(), &cronGovernor)
 	initializeActor(ctx, t, vm, cronState, builtin.CronActorCodeID, builtin.CronActorAddr, big.Zero())
 
 	powerState, err := power.ConstructState(store)
@@ -86,6 +90,10 @@ func NewVMWithSingletons(ctx context.Context, t testing.TB, bs ipldcbor.IpldBloc
 	require.NoError(t, err)
 	initializeActor(ctx, t, vm, vrState, builtin.VerifiedRegistryActorCodeID, builtin.VerifiedRegistryActorAddr, big.Zero())
 
+	askState, err := askreg.ConstructState(store)
+	require.NoError(t, err)
+	initializeActor(ctx, t, vm, askState, builtin.StorageAskRegistryActorCodeID, builtin.StorageAskRegistryActorAddr, big.Zero())
+
 	// burnt funds
 	initializeActor(ctx, t, vm, &account.State{Address: builtin.BurntFundsActorAddr}, builtin.AccountActorCodeID, builtin.BurntFundsActorAddr, big.Zero())
 